@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
 )
 
 type request struct {
@@ -44,8 +46,10 @@ type completionProvider struct {
 }
 
 type completionItem struct {
-	Label string `json:"label"`
-	Kind  int    `json:"kind,omitempty"`
+	Label    string `json:"label"`
+	Kind     int    `json:"kind,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	SortText string `json:"sortText,omitempty"`
 }
 
 type completionParams struct {
@@ -196,14 +200,38 @@ func fetchTagCompletions(raw json.RawMessage) []completionItem {
 		return nil
 	}
 
-	items := make([]completionItem, 0, len(resp.Tags))
-	for _, tag := range resp.Tags {
-		items = append(items, completionItem{Label: tag.Tag, Kind: 1})
+	// Rank by fuzzy match on the word being typed plus frecency; sortText
+	// keeps the editor from reordering alphabetically.
+	tags := make([]string, len(resp.Tags))
+	byTag := make(map[string]api.TagStat, len(resp.Tags))
+	frecency := make(map[string]int, len(resp.Tags))
+	for i, t := range resp.Tags {
+		tags[i] = t.Tag
+		byTag[t.Tag] = t
+		frecency[t.Tag] = t.Frecency
+	}
+	ranked := util.RankCompletions(currentWord(params), tags, frecency, 0)
+	items := make([]completionItem, 0, len(ranked))
+	for i, name := range ranked {
+		items = append(items, completionItem{Label: name, Kind: 1, Detail: byTag[name].Description, SortText: fmt.Sprintf("%05d", i)})
 	}
 
 	return items
 }
 
+// currentWord returns the partial tag before the cursor on a Tags: line.
+func currentWord(params completionParams) string {
+	line := currentLine(params.TextDocument.URI, params.Position.Line)
+	if c := params.Position.Character; c >= 0 && c < len(line) {
+		line = line[:c]
+	}
+	line = strings.TrimPrefix(line, "Tags: ")
+	if i := strings.LastIndexAny(line, ", "); i >= 0 {
+		line = line[i+1:]
+	}
+	return line
+}
+
 func shouldCompleteTags(params completionParams) bool {
 	line := currentLine(params.TextDocument.URI, params.Position.Line)
 	if line == "" {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -15,6 +17,8 @@ import (
 
 	"github.com/mithrel/ginkgo/internal/config"
 	"github.com/mithrel/ginkgo/internal/daemon"
+	"github.com/mithrel/ginkgo/internal/db"
+	"github.com/mithrel/ginkgo/internal/editor"
 	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/internal/wire"
 	"github.com/mithrel/ginkgo/pkg/api"
@@ -80,6 +84,20 @@ func writeConfigTOML(t *testing.T, dir string) string {
 	return cfg
 }
 
+// runCLI runs the root command against cfgPath with stdin as its input and
+// returns what it wrote to stdout and stderr.
+func runCLI(t *testing.T, cfgPath, stdin string, args ...string) (string, error) {
+	t.Helper()
+	root := NewRootCmd()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetIn(strings.NewReader(stdin))
+	root.SetArgs(append([]string{"--config", cfgPath}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
 func TestCLIAddShowDeleteJSON(t *testing.T) {
 	cancel, _, dataDir := startTestDaemon(t)
 	defer cancel()
@@ -265,6 +283,9 @@ func TestImportJSON(t *testing.T) {
 	if got.Tags[0] != "cli" || got.Tags[1] != "imported" {
 		t.Fatalf("tags mismatch: %v", got.Tags)
 	}
+	if want := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC); !got.CreatedAt.Equal(want) || !got.UpdatedAt.Equal(want) {
+		t.Fatalf("timestamps not preserved: created=%v updated=%v", got.CreatedAt, got.UpdatedAt)
+	}
 }
 
 func TestConfigNamespaceDelete(t *testing.T) {
@@ -347,3 +368,458 @@ func TestNoteDeleteDryRunOutput(t *testing.T) {
 		t.Fatalf("expected empty body in dry-run output, got %q", entries[0].Body)
 	}
 }
+
+func TestTodayAppendsToOneNotePerDay(t *testing.T) {
+	cancel, sock, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := runCLI(t, cfgPath, "", args...)
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, out)
+		}
+		return strings.Split(strings.TrimSpace(out), "\t")[0]
+	}
+	id := run("today", "shipped", "the", "fix")
+	if again := run("note", "daily", "--date", "today", "fixed", "the", "fix"); again != id {
+		t.Fatalf("second append went to %s, want %s", again, id)
+	}
+	if want := api.DailyID("testcli", time.Now()); id != want {
+		t.Fatalf("daily id=%s want %s", id, want)
+	}
+	yesterday := run("note", "daily", "--date", "yesterday", "late entry")
+	if yesterday == id {
+		t.Fatalf("yesterday reused today's note %s", id)
+	}
+	late, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.show", ID: yesterday, Namespace: "testcli"})
+	if err != nil || !late.OK || late.Entry == nil {
+		t.Fatalf("show yesterday: %v %+v", err, late)
+	}
+	// Created on the day it is for, not today.
+	if got := api.DailyID("testcli", late.Entry.CreatedAt.Local()); got != yesterday {
+		t.Fatalf("yesterday's note created at %s", late.Entry.CreatedAt)
+	}
+
+	show, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.show", ID: id, Namespace: "testcli"})
+	if err != nil || !show.OK || show.Entry == nil {
+		t.Fatalf("show: %v %+v", err, show)
+	}
+	e := show.Entry
+	if e.Title != time.Now().Format("2006-01-02 Monday") || len(e.Tags) != 1 || e.Tags[0] != "daily" {
+		t.Fatalf("daily note title=%q tags=%v", e.Title, e.Tags)
+	}
+	if strings.Count(e.Body, "\n## ") != 1 || !strings.HasSuffix(e.Body, "fixed the fix") || !strings.Contains(e.Body, "shipped the fix\n\n## ") {
+		t.Fatalf("daily body=%q", e.Body)
+	}
+}
+
+func TestNoteAddFromTemplate(t *testing.T) {
+	cancel, sock, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+	if err := os.MkdirAll(filepath.Join(dataDir, "templates"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	src := "Tags: standup\n---\nIn {{.Namespace}}: {{stdin}}{{prompt \"Blockers?\"}}\n"
+	if err := os.WriteFile(filepath.Join(dataDir, "templates", "standup.md"), []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if out, err := runCLI(t, cfgPath, "", "template", "list"); err != nil || !strings.HasPrefix(out, "standup\t") {
+		t.Fatalf("template list: %v %q", err, out)
+	}
+	out, err := runCLI(t, cfgPath, "piped text\n", "note", "add", "--template", "standup", "Monday", "standup")
+	if err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+	id := strings.Split(out, "\t")[0]
+	show, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.show", ID: id, Namespace: "testcli"})
+	if err != nil || !show.OK || show.Entry == nil {
+		t.Fatalf("show: %v %+v", err, show)
+	}
+	e := show.Entry
+	if e.Title != "Monday standup" || len(e.Tags) != 1 || e.Tags[0] != "standup" || e.Body != "In testcli: piped text" {
+		t.Fatalf("note title=%q tags=%v body=%q", e.Title, e.Tags, e.Body)
+	}
+}
+
+func TestNoteAppendAndPipedAdd(t *testing.T) {
+	cancel, sock, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+
+	run := func(stdin string, args ...string) string {
+		t.Helper()
+		out, err := runCLI(t, cfgPath, stdin, args...)
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, out)
+		}
+		return strings.Split(strings.TrimSpace(out), "\t")[0]
+	}
+	body := func(id string) string {
+		t.Helper()
+		show, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.show", ID: id, Namespace: "testcli"})
+		if err != nil || !show.OK || show.Entry == nil {
+			t.Fatalf("show: %v %+v", err, show)
+		}
+		return show.Entry.Body
+	}
+
+	id := run("build log\nline two\n", "note", "add")
+	if got := body(id); got != "build log\nline two" {
+		t.Fatalf("piped body=%q", got)
+	}
+	if got := run("", "note", "append", id, "first", "addition"); got != id {
+		t.Fatalf("append went to %s, want %s", got, id)
+	}
+	if got := run("from stdin\n", "note", "append", "--last", "--timestamp"); got != id {
+		t.Fatalf("--last went to %s, want %s", got, id)
+	}
+	got := body(id)
+	if !strings.HasPrefix(got, "build log\nline two\n\nfirst addition\n\n") || !strings.HasSuffix(got, " from stdin") {
+		t.Fatalf("appended body=%q", got)
+	}
+}
+
+func TestNoteAddAt(t *testing.T) {
+	cancel, sock, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+
+	out, err := runCLI(t, cfgPath, "", "note", "add", "--at", "yesterday 17:00", "Standup", "notes")
+	if err != nil {
+		t.Fatalf("add execute: %v\n%s", err, out)
+	}
+	id := strings.Split(out, "\t")[0]
+	show, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.show", ID: id, Namespace: "testcli"})
+	if err != nil || !show.OK || show.Entry == nil {
+		t.Fatalf("show: %v %+v", err, show)
+	}
+	y := time.Now().AddDate(0, 0, -1)
+	if want := time.Date(y.Year(), y.Month(), y.Day(), 17, 0, 0, 0, time.Local); !show.Entry.CreatedAt.Equal(want) {
+		t.Fatalf("created_at=%v want %v", show.Entry.CreatedAt, want)
+	}
+}
+
+func TestNoteAddDraftsAndResume(t *testing.T) {
+	cancel, sock, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "true") // leaves the file as is
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, err := runCLI(t, cfgPath, "", args...)
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, out)
+		}
+		return out
+	}
+	count := func() int {
+		t.Helper()
+		resp, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.list", Namespace: "testcli"})
+		if err != nil || !resp.OK {
+			t.Fatalf("list: %v %+v", err, resp)
+		}
+		return len(resp.Entries)
+	}
+
+	// An untouched editor adds nothing and leaves no draft.
+	if out := run("note", "add"); !strings.Contains(out, "not added") {
+		t.Fatalf("add output=%q", out)
+	}
+	if n := count(); n != 0 {
+		t.Fatalf("notes after aborted add=%d", n)
+	}
+	if out := run("note", "drafts", "list"); strings.TrimSpace(out) != "No drafts." {
+		t.Fatalf("drafts list=%q", out)
+	}
+
+	// A draft left by a crash is listed and saved as one new note.
+	id := api.NewID()
+	path, err := editor.PathForID(id, "testcli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.PrepareAt(path, []byte(editor.ComposeContent("Recovered", []string{"x"}, "lost words"))); err != nil {
+		t.Fatal(err)
+	}
+	if out := run("note", "drafts", "list"); !strings.HasPrefix(out, id+"\ttestcli\t") || !strings.HasSuffix(out, "\tRecovered\n") {
+		t.Fatalf("drafts list=%q", out)
+	}
+	if out := run("note", "drafts", "resume", id); !strings.HasSuffix(out, "\tRecovered\n") {
+		t.Fatalf("resume output=%q", out)
+	}
+	if n := count(); n != 1 {
+		t.Fatalf("notes after resume=%d", n)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("draft not removed: %v", err)
+	}
+}
+
+func TestNoteDraftsSnapshotDiffAndDiscard(t *testing.T) {
+	cancel, sock, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "true")
+
+	run := func(args ...string) (string, error) { return runCLI(t, cfgPath, "", args...) }
+	out, err := run("note", "add", "Original")
+	if err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+	id := strings.Split(out, "\t")[0]
+
+	// An edit whose file is gone survives as the daemon's snapshot.
+	path, err := editor.PathForID(id, "testcli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft := editor.ComposeContent("Edited", nil, "new body")
+	if resp, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "draft.save", ID: id, Namespace: "testcli", Path: path, IfVersion: 1, Body: draft}); err != nil || !resp.OK {
+		t.Fatalf("draft.save: %v %+v", err, resp)
+	}
+	if out, _ := run("note", "drafts", "list"); !strings.HasPrefix(out, id+"\ttestcli\tv1\t") || !strings.HasSuffix(out, "\tEdited\n") {
+		t.Fatalf("drafts list=%q", out)
+	}
+
+	// The note moves on; the draft diffs against it and does not save over it.
+	if resp, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "note.edit", ID: id, IfVersion: 1, Title: "Changed", Namespace: "testcli"}); err != nil || !resp.OK {
+		t.Fatalf("note.edit: %v %+v", err, resp)
+	}
+	if out, err := run("note", "drafts", "diff", id); err != nil || !strings.Contains(out, "now v2") || !strings.Contains(out, "-Title: Changed\n") || !strings.Contains(out, "+Title: Edited\n") {
+		t.Fatalf("diff err=%v out=%q", err, out)
+	}
+	if out, err := run("note", "drafts", "resume", id); err == nil || !errors.Is(err, db.ErrConflict) {
+		t.Fatalf("resume over a newer note: err=%v out=%q", err, out)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("draft file not restored: %v", err)
+	}
+
+	if out, err := run("note", "drafts", "discard", id); err != nil {
+		t.Fatalf("discard: %v\n%s", err, out)
+	}
+	if out, _ := run("note", "drafts", "list"); strings.TrimSpace(out) != "No drafts." {
+		t.Fatalf("drafts list after discard=%q", out)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("draft file kept: %v", err)
+	}
+}
+
+func TestFrontmatterEditorAndMarkdownExportImport(t *testing.T) {
+	cancel, _, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+	f, err := os.OpenFile(cfgPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("[editor]\nformat = \"frontmatter\"\n")
+	_ = f.Close()
+
+	// The editor saves the file of each run from step<n> and keeps what it
+	// was shown as seen<n>.
+	steps := t.TempDir()
+	script := filepath.Join(steps, "ed.sh")
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+n=$(cat "$STEPS/count" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$STEPS/count"
+cp "$1" "$STEPS/seen$n"
+cp "$STEPS/step$n" "$1"
+`), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range []string{
+		"---\ntitle: Trip plan\ntags: [travel, todo]\ncreated_at: 2024-05-01T10:00:00Z\n---\nPack light",
+		"---\ntitle: [Trip plan\n---\nPack light",
+		"---\ntitle: Trip plan v2\ntags: [travel]\ncreated_at: 2024-05-02T10:00:00Z\n---\nPack light",
+	} {
+		if err := os.WriteFile(filepath.Join(steps, fmt.Sprintf("step%d", i+1)), []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("STEPS", steps)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	run := func(args ...string) (string, error) { return runCLI(t, cfgPath, "", args...) }
+	show := func(id string) api.Entry {
+		t.Helper()
+		out, err := run("note", "show", id, "--output", "json")
+		if err != nil {
+			t.Fatalf("show: %v\n%s", err, out)
+		}
+		var e api.Entry
+		if err := json.Unmarshal([]byte(out), &e); err != nil {
+			t.Fatalf("decode %q: %v", out, err)
+		}
+		return e
+	}
+
+	out, err := run("note", "add")
+	if err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+	id := strings.Split(out, "\t")[0]
+	e := show(id)
+	if e.Title != "Trip plan" || strings.Join(e.Tags, ",") != "travel,todo" || !e.CreatedAt.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) || e.Body != "Pack light" {
+		t.Fatalf("added %+v", e)
+	}
+
+	// A YAML error is shown at the top of the file until it is fixed.
+	if out, err := run("note", "edit", id); err != nil {
+		t.Fatalf("edit: %v\n%s", err, out)
+	}
+	if seen, _ := os.ReadFile(filepath.Join(steps, "seen2")); !strings.Contains(string(seen), "namespace: testcli\n") || !strings.Contains(string(seen), "created_at: ") {
+		t.Fatalf("edit shown %q", seen)
+	}
+	if seen, _ := os.ReadFile(filepath.Join(steps, "seen3")); !strings.HasPrefix(string(seen), "# ginkgo: line ") {
+		t.Fatalf("error not shown: %q", seen)
+	}
+	e = show(id)
+	if e.Title != "Trip plan v2" || !e.CreatedAt.Equal(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("edited %+v", e)
+	}
+
+	// Export writes Markdown with frontmatter that import reads back.
+	dir := filepath.Join(t.TempDir(), "vault")
+	if out, err := run("export", dir); err != nil || out != "Exported: 1\n" {
+		t.Fatalf("export: %v\n%s", err, out)
+	}
+	md, err := os.ReadFile(filepath.Join(dir, "trip-plan-v2.md"))
+	if err != nil || !strings.HasPrefix(string(md), "---\ntitle: Trip plan v2\ntags: [travel]\nnamespace: testcli\n") {
+		t.Fatalf("exported %q %v", md, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Plain idea.md"), []byte("Just text\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".obsidian"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".obsidian", "hidden.md"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run("import", dir); err != nil || !strings.Contains(out, "Imported: 1\n") || !strings.Contains(out, "Skipped (duplicate): 1\n") {
+		t.Fatalf("import: %v\n%s", err, out)
+	}
+	if out, _ := run("note", "list", "--output", "plain"); !strings.Contains(out, "Plain idea") {
+		t.Fatalf("list after import:\n%s", out)
+	}
+
+	// A broken file imports nothing.
+	if err := os.WriteFile(filepath.Join(dir, "broken.md"), []byte("---\ntitle: [x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("import", dir); err == nil || !strings.Contains(err.Error(), "broken.md: line 1") {
+		t.Fatalf("import broken: %v", err)
+	}
+}
+
+func TestCustomFields(t *testing.T) {
+	cancel, _, dataDir := startTestDaemon(t)
+	defer cancel()
+	cfgPath := writeConfigTOML(t, dataDir)
+	f, err := os.OpenFile(cfgPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("[editor]\nformat = \"frontmatter\"\n")
+	_ = f.Close()
+
+	// The editor saves the file of each run from step<n>.
+	steps := t.TempDir()
+	script := filepath.Join(steps, "ed.sh")
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+n=$(cat "$STEPS/count" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$STEPS/count"
+cp "$1" "$STEPS/seen$n"
+cp "$STEPS/step$n" "$1"
+`), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range []string{
+		"---\ntitle: Rest\nmood: 5\nid: x\n---\nSlept in",
+		"---\ntitle: Rest\nmood: 5\nenergy: high\n---\nSlept in",
+	} {
+		if err := os.WriteFile(filepath.Join(steps, fmt.Sprintf("step%d", i+1)), []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("STEPS", steps)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	run := func(args ...string) (string, error) { return runCLI(t, cfgPath, "", args...) }
+	add := func(args ...string) string {
+		t.Helper()
+		out, err := run(append([]string{"note", "add"}, args...)...)
+		if err != nil {
+			t.Fatalf("add: %v\n%s", err, out)
+		}
+		return strings.Split(out, "\t")[0]
+	}
+	titles := func(args ...string) []string {
+		t.Helper()
+		out, err := run(append([]string{"note", "list", "--output", "plain", "--noheaders"}, args...)...)
+		if err != nil {
+			t.Fatalf("list: %v\n%s", err, out)
+		}
+		var got []string
+		for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+			if f := strings.Fields(l); len(f) > 1 {
+				got = append(got, f[1])
+			}
+		}
+		return got
+	}
+
+	add("Run", "--field", "mood=4", "--field", "duration=45m", "--field", "project=ginkgo")
+	rest := add("Rest", "--field", "mood=2")
+	add("Plain")
+
+	if got := titles("--where", "mood>=4"); strings.Join(got, ",") != "Run" {
+		t.Fatalf("where mood>=4: %v", got)
+	}
+	if got := titles("--where", "duration<1h", "--where", "project=GINKGO"); strings.Join(got, ",") != "Run" {
+		t.Fatalf("where duration, project: %v", got)
+	}
+	if got := titles("--sort", "-mood"); strings.Join(got, ",") != "Run,Rest,Plain" {
+		t.Fatalf("sort -mood: %v", got)
+	}
+	if out, _ := run("note", "list", "--output", "plain", "--where", "project"); !strings.Contains(out, "duration=45m,mood=4,project=ginkgo") {
+		t.Fatalf("plain fields:\n%s", out)
+	}
+	if _, err := run("note", "list", "--where", "mood>>4"); err == nil {
+		t.Fatal("bad --where accepted")
+	}
+	if _, err := run("note", "add", "Bad", "--field", "2x=1"); err == nil {
+		t.Fatal("bad --field accepted")
+	}
+
+	// Fields are edited in frontmatter; a bad key is shown in the file.
+	if out, err := run("note", "edit", rest); err != nil {
+		t.Fatalf("edit: %v\n%s", err, out)
+	}
+	if seen, _ := os.ReadFile(filepath.Join(steps, "seen1")); !strings.Contains(string(seen), "\nmood: 2\n") {
+		t.Fatalf("edit shown %q", seen)
+	}
+	if seen, _ := os.ReadFile(filepath.Join(steps, "seen2")); !strings.HasPrefix(string(seen), "# ginkgo: line 6: invalid field name") {
+		t.Fatalf("error not shown: %q", seen)
+	}
+	out, err := run("note", "show", rest, "--output", "json")
+	if err != nil {
+		t.Fatalf("show: %v\n%s", err, out)
+	}
+	var e api.Entry
+	if err := json.Unmarshal([]byte(out), &e); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if len(e.Fields) != 2 || e.Fields["mood"] != 5.0 || e.Fields["energy"] != "high" {
+		t.Fatalf("edited fields %v", e.Fields)
+	}
+}
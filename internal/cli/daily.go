@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/db"
+	"github.com/mithrel/ginkgo/internal/editor"
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+const dailyLong = `Open the day's note in $EDITOR, creating it on first use. There is one
+daily note per day and namespace; its ID is derived from the date, so every
+device opens the same note.
+
+A new daily note is titled with daily.title (a Go time layout), tagged with
+daily.tags and pre-filled with a heading per daily.prompts. Opening it again
+adds a section headed with the current time. Text given as arguments is
+appended as such a section without opening the editor.`
+
+// newTodayCmd defines "today", a shortcut for "note daily".
+func newTodayCmd() *cobra.Command {
+	var nsFlag string
+	cmd := &cobra.Command{
+		Use:   "today [text...]",
+		Short: "Open or append to today's daily note",
+		Long:  dailyLong,
+		Example: `  ginkgo-cli today
+  ginkgo-cli today shipped the sync fix`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDaily(cmd, time.Now(), args)
+		},
+	}
+	cmd.Flags().StringVarP(&nsFlag, "namespace", "n", "", "override namespace for this command")
+	registerNamespaceCompletion(cmd)
+	return cmd
+}
+
+func newNoteDailyCmd() *cobra.Command {
+	var date string
+	cmd := &cobra.Command{
+		Use:   "daily [text...]",
+		Short: "Open or append to a day's daily note",
+		Long: dailyLong + `
+
+--date picks another day: yesterday, a weekday (the latest one), 3d (three
+days ago) or 2025-03-01.`,
+		Example: `  ginkgo-cli note daily --date yesterday
+  ginkgo-cli note daily --date friday forgot to mention the release`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day, err := util.ParseDay(date, time.Now())
+			if err != nil {
+				return err
+			}
+			return runDaily(cmd, day, args)
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "today", "day of the note: today, yesterday, a weekday, 3d or YYYY-MM-DD")
+	return cmd
+}
+
+func runDaily(cmd *cobra.Command, day time.Time, args []string) error {
+	app := getApp(cmd)
+	ns := resolveNamespace(cmd)
+	if err := ensureNamespaceConfigured(cmd, ns); err != nil {
+		return err
+	}
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return err
+	}
+	m := ipc.Message{
+		Name:      "note.daily",
+		Namespace: ns,
+		Date:      day.Format("2006-01-02"),
+		Title:     day.Format(app.Cfg.GetString("daily.title")),
+		Tags:      app.Cfg.GetStringSlice("daily.tags"),
+	}
+
+	// Append flow
+	if text := strings.TrimSpace(strings.Join(args, " ")); text != "" {
+		m.Body = dailySection(time.Now(), text)
+		resp, err := dailyRequest(cmd, sock, m)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", resp.Entry.ID, resp.Entry.Title)
+		warnLinks(cmd.ErrOrStderr(), resp.Links)
+		return nil
+	}
+
+	// Editor flow: nothing is stored until the editor saves a change.
+	resp, err := ipc.Request(cmd.Context(), sock, m)
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		return errors.New(resp.Msg)
+	}
+	cur := resp.Entry
+	section := dailySection(time.Now(), "")
+	format := editorFormat(cmd)
+	var initial string
+	if cur == nil {
+		initial = editor.Compose(format, editor.Note{Title: m.Title, Tags: m.Tags, Body: dailyTemplate(app.Cfg.GetStringSlice("daily.prompts"))})
+	} else {
+		initial = editor.Compose(format, editor.Note{Title: cur.Title, Tags: cur.Tags, Body: api.AppendSection(cur.Body, section)})
+	}
+	path, err := editor.PathForID(api.DailyID(ns, day), ns)
+	if err != nil {
+		return err
+	}
+	n, _, changed, err := editor.Edit(path, format, []byte(initial), nil)
+	_ = os.Remove(path)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No changes.")
+		return nil
+	}
+	title, tags, body := n.Title, n.Tags, n.Body
+	if title == "" {
+		title = m.Title
+	}
+
+	var saved ipc.Response
+	if cur == nil {
+		m.Title, m.Tags, m.Body = title, tags, body
+		if strings.TrimSpace(body) == "" {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Note aborted: empty content.")
+			return nil
+		}
+		if saved, err = dailyRequest(cmd, sock, m); err != nil {
+			return err
+		}
+	} else {
+		// Drop the new section's heading when nothing was written under it.
+		body = strings.TrimRight(strings.TrimSuffix(body, strings.TrimSpace(section)), "\n")
+		if title == cur.Title && body == strings.TrimSpace(cur.Body) && slices.Equal(tags, cur.Tags) {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No changes.")
+			return nil
+		}
+		saved, err = ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.edit", ID: cur.ID, IfVersion: cur.Version, Title: title, Body: body, Tags: tags, Namespace: ns})
+		if err != nil {
+			return err
+		}
+		if !saved.OK || saved.Entry == nil {
+			if saved.Msg == "conflict" {
+				return fmt.Errorf("%w: the daily note changed while it was open; rerun to edit the latest", db.ErrConflict)
+			}
+			return errors.New(saved.Msg)
+		}
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", saved.Entry.ID, saved.Entry.Title)
+	warnLinks(cmd.ErrOrStderr(), saved.Links)
+	return nil
+}
+
+// dailyRequest sends a note.daily append and checks that it stored a note.
+func dailyRequest(cmd *cobra.Command, sock string, m ipc.Message) (ipc.Response, error) {
+	resp, err := ipc.Request(cmd.Context(), sock, m)
+	if err != nil {
+		return resp, err
+	}
+	if !resp.OK || resp.Entry == nil {
+		if resp.Msg != "" {
+			return resp, errors.New(resp.Msg)
+		}
+		return resp, errors.New("failed to save daily note")
+	}
+	return resp, nil
+}
+
+// dailyTemplate is the body of a new daily note: a heading per prompt.
+func dailyTemplate(prompts []string) string {
+	var b strings.Builder
+	for _, p := range prompts {
+		if p = strings.TrimSpace(p); p != "" {
+			b.WriteString("## " + p + "\n\n")
+		}
+	}
+	return b.String()
+}
+
+// dailySection is an appended section headed with the time of day.
+func dailySection(now time.Time, text string) string {
+	return "## " + now.Format("15:04") + "\n\n" + text
+}
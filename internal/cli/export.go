@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/editor"
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/util"
+)
+
+func newExportCmd() *cobra.Command {
+	var filters FilterOpts
+	var queryExpr string
+	var where []string
+	cmd := &cobra.Command{
+		Use:   "export <dir>",
+		Short: "Export notes as Markdown files with YAML frontmatter",
+		Long: `Export notes as Markdown files, one per note, named after the title.
+Title, tags, namespace and creation time go into YAML frontmatter, as
+Obsidian and static site generators read it; ginkgo-cli import reads the
+directory back. Files of an earlier export with the same names are
+overwritten.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := getApp(cmd)
+			dir := args[0]
+			sinceStr, untilStr, err := util.NormalizeTimeRange(filters.Since, filters.Until)
+			if err != nil {
+				return err
+			}
+			queryExpr, err := whereQuery(queryExpr, where)
+			if err != nil {
+				return err
+			}
+			if queryExpr != "" {
+				if err := checkQuery(queryExpr); err != nil {
+					return err
+				}
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			entries, err := fetchAllEntries(cmd.Context(), sock, app.Cfg.GetInt("export.page_size"), func(cursor string) ipc.Message {
+				return ipc.Message{
+					Name:        "note.list",
+					Namespace:   resolveNamespace(cmd),
+					TagsAny:     splitCSV(filters.TagsAny),
+					TagsAll:     splitCSV(filters.TagsAll),
+					Since:       sinceStr,
+					Until:       untilStr,
+					IncludeBody: true,
+					Query:       queryExpr,
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			used := map[string]bool{}
+			for _, e := range entries {
+				name := slugify(e.Title)
+				if name == "" {
+					name = e.ID
+				}
+				for i, base := 2, name; used[name]; i++ {
+					name = fmt.Sprintf("%s-%d", base, i)
+				}
+				used[name] = true
+				content := editor.ComposeFrontmatter(editor.Note{Title: e.Title, Tags: e.Tags, Namespace: e.Namespace, CreatedAt: e.CreatedAt, Fields: e.Fields, Body: e.Body})
+				if err := os.WriteFile(filepath.Join(dir, name+".md"), []byte(content), 0o644); err != nil {
+					return err
+				}
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Exported: %d\n", len(entries))
+			return nil
+		},
+	}
+	addFilterFlags(cmd, &filters)
+	cmd.Flags().StringVarP(&queryExpr, "query", "q", "", "filter with a search query, e.g. 'tag:work -tag:draft created:>7d' (see note search fts --help)")
+	addWhereFlag(cmd, &where)
+	return cmd
+}
+
+// slugify turns a title into a file name: lowercase letters and digits
+// joined by dashes, at most 60 runes.
+func slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	n := 0
+	for _, r := range strings.ToLower(title) {
+		if n >= 60 {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+				n++
+			}
+			b.WriteRune(r)
+			n++
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
@@ -6,40 +6,63 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/mithrel/ginkgo/internal/editor"
 	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
 func newImportCmd() *cobra.Command {
+	var dedupe string
 	cmd := &cobra.Command{
-		Use:   "import <file>",
-		Short: "Import notes from JSON (array or NDJSON)",
-		Args:  cobra.ExactArgs(1),
+		Use:   "import <file|dir>",
+		Short: "Import notes from JSON (array or NDJSON) or Markdown",
+		Long: `Import notes from JSON (array or NDJSON), a Markdown file, or a directory
+of Markdown files such as an Obsidian vault or the output of ginkgo-cli
+export. Markdown files may carry YAML frontmatter with title, tags,
+namespace and created_at; a file without a title is named after the file
+and one without created_at takes the file's modification time.
+
+Records duplicating a stored note (same title and body, or nearly the same
+text) are handled by --dedupe: skip leaves the stored note alone, merge folds
+the record's tags and any new text into it, keep imports it anyway.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch dedupe {
+			case "skip", "merge", "keep":
+			default:
+				return fmt.Errorf("invalid --dedupe: %s", dedupe)
+			}
 			file := args[0]
 			app := getApp(cmd)
 
-			f, err := os.Open(file)
+			md, err := markdownFiles(file)
 			if err != nil {
 				return err
 			}
-			defer f.Close()
-
-			br := bufio.NewReader(f)
-			// Peek first non-space byte to decide array vs NDJSON
-			first, err := peekFirstNonSpace(br)
-			if err != nil {
-				return err
+			imported, merged, duplicates, skipped := 0, 0, 0, 0
+			tally := func(e api.Entry) {
+				switch status, err := importOne(cmd, e, dedupe); {
+				case err != nil:
+					skipped++
+				case status == "duplicate":
+					duplicates++
+				case status == "merged":
+					merged++
+				default:
+					imported++
+				}
+			}
+			report := func() {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Imported: %d\nMerged: %d\nSkipped (duplicate): %d\nSkipped (conflict): %d\n", imported, merged, duplicates, skipped)
 			}
-
-			dec := json.NewDecoder(br)
-			imported := 0
-			skipped := 0
 			now := time.Now().UTC()
 
 			normalize := func(e *api.Entry) {
@@ -56,6 +79,38 @@ func newImportCmd() *cobra.Command {
 				}
 			}
 
+			if md != nil {
+				// Read every file first so a broken one imports nothing.
+				notes := make([]api.Entry, 0, len(md))
+				for _, path := range md {
+					e, err := readMarkdownNote(path)
+					if err != nil {
+						return err
+					}
+					normalize(&e)
+					notes = append(notes, e)
+				}
+				for _, e := range notes {
+					tally(e)
+				}
+				report()
+				return nil
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			br := bufio.NewReader(f)
+			// Peek first non-space byte to decide array vs NDJSON
+			first, err := peekFirstNonSpace(br)
+			if err != nil {
+				return err
+			}
+
+			dec := json.NewDecoder(br)
 			if first == '[' {
 				// JSON array
 				var arr []api.Entry
@@ -64,11 +119,7 @@ func newImportCmd() *cobra.Command {
 				}
 				for i := range arr {
 					normalize(&arr[i])
-					if err := importOne(cmd, arr[i]); err != nil {
-						skipped++
-						continue
-					}
-					imported++
+					tally(arr[i])
 				}
 			} else {
 				// NDJSON stream
@@ -81,29 +132,31 @@ func newImportCmd() *cobra.Command {
 						return err
 					}
 					normalize(&e)
-					if err := importOne(cmd, e); err != nil {
-						skipped++
-						continue
-					}
-					imported++
+					tally(e)
 				}
 			}
 
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Imported: %d\nSkipped (conflict): %d\n", imported, skipped)
+			report()
 			return nil
 		},
 	}
+	cmd.Flags().StringVar(&dedupe, "dedupe", "skip", "how to handle duplicates of stored notes: skip|merge|keep")
+	_ = cmd.RegisterFlagCompletionFunc("dedupe", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"skip", "merge", "keep"}, cobra.ShellCompDirectiveNoFileComp
+	})
 	return cmd
 }
 
-func importOne(cmd *cobra.Command, e api.Entry) error {
+// importOne creates e via the daemon and returns its status: "duplicate"
+// when skipped, "merged" when folded into a stored note, else "".
+func importOne(cmd *cobra.Command, e api.Entry, dedupe string) (string, error) {
 	// Create via daemon; let it generate the ID and normalize tags.
 	if err := ensureNamespaceConfigured(cmd, e.Namespace); err != nil {
-		return err
+		return "", err
 	}
 	sock, err := ipc.SocketPath()
 	if err != nil {
-		return err
+		return "", err
 	}
 	m := ipc.Message{
 		Name:      "note.add",
@@ -111,18 +164,87 @@ func importOne(cmd *cobra.Command, e api.Entry) error {
 		Body:      e.Body,
 		Tags:      e.Tags,
 		Namespace: e.Namespace,
+		Dedupe:    dedupe,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		Fields:    e.Fields,
 	}
 	resp, err := ipc.Request(cmd.Context(), sock, m)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if !resp.OK || resp.Entry == nil {
 		if resp.Msg != "" {
-			return errors.New(resp.Msg)
+			return "", errors.New(resp.Msg)
+		}
+		return "", fmt.Errorf("failed to import entry")
+	}
+	return resp.Msg, nil
+}
+
+// markdownFiles lists the Markdown files to import from path: path itself
+// when it is one, the *.md files under it when it is a directory (skipping
+// hidden ones such as .obsidian), nil otherwise.
+func markdownFiles(path string) ([]string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		if isMarkdown(path) {
+			return []string{path}, nil
+		}
+		return nil, nil
+	}
+	out := []string{}
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != path && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() && isMarkdown(p) {
+			out = append(out, p)
+		}
+		return nil
+	})
+	return out, err
+}
+
+func isMarkdown(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
+
+// readMarkdownNote reads a Markdown file with optional YAML frontmatter.
+func readMarkdownNote(path string) (api.Entry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return api.Entry{}, err
+	}
+	n, err := editor.ParseFrontmatter(string(b))
+	if err != nil {
+		return api.Entry{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := checkFields(n); err != nil {
+		return api.Entry{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if n.Title == "" {
+		n.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if n.CreatedAt.IsZero() {
+		if fi, err := os.Stat(path); err == nil {
+			n.CreatedAt = fi.ModTime().UTC()
 		}
-		return fmt.Errorf("failed to import entry")
 	}
-	return nil
+	return api.Entry{Title: n.Title, Tags: n.Tags, Namespace: n.Namespace, CreatedAt: n.CreatedAt, Fields: n.Fields, Body: n.Body}, nil
 }
 
 func peekFirstNonSpace(r *bufio.Reader) (byte, error) {
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/mithrel/ginkgo/internal/ipc"
@@ -31,21 +32,33 @@ func newNoteCmd() *cobra.Command {
 	// Attach subcommands under note
 	cmd.AddCommand(newNoteAddCmd())
 	cmd.AddCommand(newNoteEditCmd())
+	cmd.AddCommand(newNoteAppendCmd())
+	cmd.AddCommand(newNoteDraftsCmd())
 	cmd.AddCommand(newNoteShowCmd())
 	cmd.AddCommand(newNoteDeleteCmd())
 	cmd.AddCommand(newNoteListCmd())
 	cmd.AddCommand(newNoteSearchCmd())
+	cmd.AddCommand(newNoteRelatedCmd())
+	cmd.AddCommand(newNoteDupesCmd())
+	cmd.AddCommand(newNoteLinksCmd())
+	cmd.AddCommand(newNoteBacklinksCmd())
+	cmd.AddCommand(newNoteRemindCmd())
+	cmd.AddCommand(newNoteRemindersCmd())
+	cmd.AddCommand(newNoteDailyCmd())
 	cmd.AddCommand(newNoteSyncCmd())
 	cmd.AddCommand(newNoteQueueCmd())
 	cmd.AddCommand(newNoteCompleteTagsCmd())
 
 	// Flags: allow tags for one-liner adds and an optional namespace override
 	cmd.Flags().StringSliceVarP(&tagsFlag, "tags", "t", nil, "tags for one-liner add (comma-separated or repeated)")
+	cmd.Flags().String("template", "", "pre-fill the note from a template (see: template list)")
+	cmd.Flags().String("at", "", atFlagUsage)
 	cmd.PersistentFlags().StringVarP(&nsFlag, "namespace", "n", "", "override namespace for this command")
 
 	registerNamespaceCompletion(cmd)
 
 	_ = cmd.RegisterFlagCompletionFunc("tags", completeTags)
+	_ = cmd.RegisterFlagCompletionFunc("template", completeTemplates)
 
 	return cmd
 }
@@ -64,8 +77,12 @@ func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]strin
 	}
 
 	tags := make([]string, len(resp.Tags))
+	descriptions := make(map[string]string, len(resp.Tags))
+	frecency := make(map[string]int, len(resp.Tags))
 	for i, t := range resp.Tags {
 		tags[i] = t.Tag
+		descriptions[t.Tag] = t.Description
+		frecency[t.Tag] = t.Frecency
 	}
 
 	// Handle comma-separated tags
@@ -76,15 +93,18 @@ func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]strin
 		query = toComplete[idx+1:]
 	}
 
-	matches := util.ScoreCompletions(query, tags, 0)
-	// Re-attach prefix to matches so the shell replaces the whole token correctly
-	if prefix != "" {
-		for i, m := range matches {
-			matches[i] = prefix + m
+	matches := util.RankCompletions(query, tags, frecency, 0)
+	// Re-attach prefix to matches so the shell replaces the whole token
+	// correctly; shells that support it show the description alongside.
+	for i, m := range matches {
+		matches[i] = prefix + m
+		if d := descriptions[m]; d != "" {
+			matches[i] += "\t" + d
 		}
 	}
 
-	return matches, cobra.ShellCompDirectiveNoFileComp
+	// Keep the ranking; shells would otherwise sort alphabetically.
+	return matches, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
 }
 
 // resolveNamespace checks for a --namespace flag; if not set, uses app config.
@@ -107,3 +127,28 @@ func addFilterFlags(cmd *cobra.Command, opts *FilterOpts) {
 	_ = cmd.RegisterFlagCompletionFunc("tags-any", completeTags)
 	_ = cmd.RegisterFlagCompletionFunc("tags-all", completeTags)
 }
+
+// addWhereFlag adds --where, field conditions such as mood>=4 that
+// whereQuery turns into field: search terms.
+func addWhereFlag(cmd *cobra.Command, where *[]string) {
+	cmd.Flags().StringArrayVar(where, "where", nil, "match a custom field, e.g. 'mood>=4' or 'project=ginkgo' (repeatable; all must match)")
+}
+
+// whereQuery adds the --where conditions to the search query q.
+func whereQuery(q string, where []string) (string, error) {
+	terms := make([]string, 0, len(where)+1)
+	if q = strings.TrimSpace(q); q != "" {
+		terms = append(terms, q)
+	}
+	for _, w := range where {
+		term := "field:" + strings.TrimSpace(w)
+		if strings.ContainsAny(term, " \t\"") {
+			return "", fmt.Errorf("invalid --where %q: quote-free values only", w)
+		}
+		if err := checkQuery(term); err != nil {
+			return "", fmt.Errorf("invalid --where %q: %w", w, err)
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, " "), nil
+}
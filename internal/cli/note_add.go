@@ -3,15 +3,21 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/mithrel/ginkgo/internal/db"
 	"github.com/mithrel/ginkgo/internal/editor"
 	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/templates"
+	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
 )
 
+const atFlagUsage = `creation time, e.g. "yesterday 17:00", "last friday", "noon", "2h", "2025-W10" or "2025-03-01 09:30"`
+
 // newNoteAddCmd registers `note add`, but doesn't own wiring; parent calls it.
 func newNoteAddCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -22,6 +28,10 @@ func newNoteAddCmd() *cobra.Command {
 	}
 	// Tags only apply to one-liner usage of add
 	cmd.Flags().StringSliceP("tags", "t", nil, "tags for one-liner add (comma-separated or repeated)")
+	cmd.Flags().String("template", "", "pre-fill the note from a template (see: template list)")
+	cmd.Flags().String("at", "", atFlagUsage)
+	cmd.Flags().StringArray("field", nil, "custom field name=value, e.g. mood=4 or duration=45m (repeatable)")
+	_ = cmd.RegisterFlagCompletionFunc("template", completeTemplates)
 	return cmd
 }
 
@@ -37,22 +47,73 @@ func runNoteAdd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	var at time.Time
+	if expr, _ := cmd.Flags().GetString("at"); expr != "" {
+		if at, err = util.ParseAt(expr, time.Now()); err != nil {
+			return fmt.Errorf("invalid --at: %w", err)
+		}
+	}
+	fieldArgs, _ := cmd.Flags().GetStringArray("field")
+	fields, err := parseFieldArgs(fieldArgs)
+	if err != nil {
+		return err
+	}
+
+	// A template fills in what the command line leaves out. Without one,
+	// piped stdin is the body.
+	var tpl templates.Note
+	piped := false
+	if name, _ := cmd.Flags().GetString("template"); name != "" {
+		t, err := findTemplate(cmd, name)
+		if err != nil {
+			return err
+		}
+		if tpl, err = renderTemplate(cmd, t, ns); err != nil {
+			return err
+		}
+	} else {
+		in, err := readStdin(cmd)
+		if err != nil {
+			return err
+		}
+		tpl.Body = strings.TrimSpace(in)
+		piped = tpl.Body != ""
+	}
+
+	// --field values win over the template's.
+	for k, v := range tpl.Fields {
+		if _, ok := fields[k]; !ok {
+			if fields == nil {
+				fields = map[string]any{}
+			}
+			fields[k] = v
+		}
+	}
 
-	// One-liner flow
-	if len(args) > 0 {
+	// One-liner flow; piped input needs no editor either.
+	if len(args) > 0 || piped {
 		title := strings.TrimSpace(strings.Join(args, " "))
+		if len(args) == 0 {
+			title = editor.FirstLine(tpl.Body)
+		}
 		if title == "" {
 			return fmt.Errorf("empty title")
 		}
 		tags, _ := cmd.Flags().GetStringSlice("tags")
+		if len(tags) == 0 {
+			tags = tpl.Tags
+		}
 		if len(tags) == 0 {
 			tags = app.Cfg.GetStringSlice("default_tags")
 		}
 		resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{
 			Name:      "note.add",
 			Title:     title,
+			Body:      tpl.Body,
 			Tags:      tags,
 			Namespace: ns,
+			CreatedAt: at,
+			Fields:    fields,
 		})
 		if err != nil {
 			return err
@@ -64,97 +125,121 @@ func runNoteAdd(cmd *cobra.Command, args []string) error {
 			return errors.New("failed to add note")
 		}
 		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", resp.Entry.ID, resp.Entry.Title)
+		warnDuplicates(cmd.ErrOrStderr(), resp.Duplicates)
+		warnLinks(cmd.ErrOrStderr(), resp.Links)
 		return nil
 	}
 
-	// Editor flow
-	resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{
-		Name:      "note.add",
-		Namespace: ns,
-	})
+	// Editor flow: the note is a local draft until the editor saves it, so
+	// nothing is sent (or replicated) for an aborted note. A draft left by a
+	// crash is recovered with "note drafts".
+	id := api.NewID()
+	path, err := editor.PathForID(id, ns)
 	if err != nil {
 		return err
 	}
-	if !resp.OK || resp.Entry == nil {
-		if resp.Msg != "" {
-			return errors.New(resp.Msg)
-		}
-		return errors.New("failed to create note")
+	format := editorFormat(cmd)
+	remind := ""
+	initial := []byte(editor.Compose(format, editor.Note{Title: tpl.Title, Tags: tpl.Tags, Namespace: ns, CreatedAt: at, Remind: &remind, Fields: fields, Body: tpl.Body}))
+	startDraft(cmd, sock, ns, id, 0, path, initial)
+	n, _, changed, err := editor.Edit(path, format, initial, checkFields)
+	if err != nil {
+		return draftKept(err, path)
 	}
-	e := resp.Entry
 
-	path, err := editor.PathForID(e.ID, ns)
-	if err != nil {
-		return err
+	// A rendered template is content even when saved as is.
+	if !changed && tpl.Title == "" && tpl.Body == "" {
+		dropDraft(cmd, sock, path)
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No edits; note not added.")
+		return nil
 	}
-	initial := []byte(editor.ComposeContent(e.Title, e.Tags, e.Body))
-	out, changed, err := editor.OpenAt(path, initial)
-	if err != nil {
-		return err
+	if n.Namespace != "" && n.Namespace != ns {
+		if err := ensureNamespaceConfigured(cmd, n.Namespace); err != nil {
+			return draftKept(err, path)
+		}
+		ns = n.Namespace
+	}
+	// The headers format has no place for --field values.
+	if n.Fields == nil {
+		n.Fields = fields
 	}
-	_ = os.Remove(path)
+	return saveDraft(cmd, sock, ns, "", 0, path, n, at)
+}
 
-	if !changed {
-		if app.Cfg.GetBool("editor.delete_empty") {
-			_, _ = ipc.Request(cmd.Context(), sock, ipc.Message{
-				Name:      "note.delete",
-				ID:        e.ID,
-				Namespace: ns,
-			})
+// parseFieldArgs reads --field name=value flags into custom fields.
+func parseFieldArgs(args []string) (map[string]any, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	fields := map[string]any{}
+	for _, a := range args {
+		name, value, ok := strings.Cut(a, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !api.ValidFieldName(name) {
+			return nil, fmt.Errorf("invalid --field %q (want name=value, e.g. mood=4)", a)
 		}
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No edits; note unchanged.")
-		return nil
+		fields[strings.ToLower(name)] = api.ParseFieldValue(value)
 	}
+	return fields, nil
+}
 
-	title, tags, body := editor.ParseEditedNote(string(out))
-	if title == "" && strings.TrimSpace(body) == "" {
-		if app.Cfg.GetBool("editor.delete_empty") {
-			_, _ = ipc.Request(cmd.Context(), sock, ipc.Message{
-				Name:      "note.delete",
-				ID:        e.ID,
-				Namespace: ns,
-			})
-		}
+// editorFormat is the configured editor file format.
+func editorFormat(cmd *cobra.Command) string {
+	return strings.ToLower(strings.TrimSpace(getApp(cmd).Cfg.GetString("editor.format")))
+}
+
+// saveDraft sends the note n edited in the draft at path as note id, or as
+// a new note when id is empty, and drops the draft once saved. It is kept
+// on failure. An edit only applies to version ifVersion of the note (0:
+// any). A new note is created at n.CreatedAt, else at.
+func saveDraft(cmd *cobra.Command, sock, ns, id string, ifVersion int64, path string, n editor.Note, at time.Time) error {
+	app := getApp(cmd)
+	if n.Title == "" {
+		n.Title = editor.FirstLine(n.Body)
+	}
+	if n.Title == "" {
+		dropDraft(cmd, sock, path)
 		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Note aborted: empty content.")
 		return nil
 	}
-	if title == "" {
-		title = editor.FirstLine(body)
-	}
 	// Apply default tags if none provided
-	if len(tags) == 0 {
-		tags = append(tags, app.Cfg.GetStringSlice("default_tags")...)
-	}
-	if title == "" {
-		if app.Cfg.GetBool("editor.delete_empty") {
-			_, _ = ipc.Request(cmd.Context(), sock, ipc.Message{
-				Name:      "note.delete",
-				ID:        e.ID,
-				Namespace: ns,
-			})
-		}
-		return fmt.Errorf("note aborted: empty content")
-	}
-
-	resp2, err := ipc.Request(cmd.Context(), sock, ipc.Message{
-		Name:      "note.edit",
-		ID:        e.ID,
-		IfVersion: e.Version,
-		Title:     title,
-		Body:      body,
-		Tags:      tags,
-		Namespace: ns,
-	})
-	if err != nil {
-		return err
+	if len(n.Tags) == 0 && id == "" {
+		n.Tags = append(n.Tags, app.Cfg.GetStringSlice("default_tags")...)
+	}
+	if !n.CreatedAt.IsZero() {
+		at = n.CreatedAt
 	}
-	if !resp2.OK || resp2.Entry == nil {
-		if resp2.Msg != "" {
-			return errors.New(resp2.Msg)
+
+	// Only frontmatter files carry fields; an edit in another format keeps
+	// the note's.
+	m := ipc.Message{Name: "note.add", Title: n.Title, Body: n.Body, Tags: n.Tags, Namespace: ns, CreatedAt: at, Fields: n.Fields}
+	if id != "" {
+		m = ipc.Message{Name: "note.edit", ID: id, IfVersion: ifVersion, Title: n.Title, Body: n.Body, Tags: n.Tags, Namespace: ns, CreatedAt: n.CreatedAt, Fields: n.Fields, SetFields: n.Fields != nil}
+	}
+	resp, err := ipc.Request(cmd.Context(), sock, m)
+	if err == nil && (!resp.OK || resp.Entry == nil) {
+		switch {
+		case resp.Msg == "conflict":
+			err = fmt.Errorf("%w: note %s changed since the draft started; compare with: ginkgo-cli note drafts diff %s", db.ErrConflict, id, editor.DraftID(path))
+		case resp.Msg != "":
+			err = errors.New(resp.Msg)
+		default:
+			err = errors.New("failed to save note")
 		}
-		return errors.New("failed to save note")
 	}
+	if err != nil {
+		return draftKept(err, path)
+	}
+	dropDraft(cmd, sock, path)
 
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", resp2.Entry.ID, resp2.Entry.Title)
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", resp.Entry.ID, resp.Entry.Title)
+	applyRemind(cmd, sock, ns, resp.Entry.ID, "", n.RemindText())
+	warnDuplicates(cmd.ErrOrStderr(), resp.Duplicates)
+	warnLinks(cmd.ErrOrStderr(), resp.Links)
 	return nil
 }
+
+// draftKept adds how to resume the draft at path to err.
+func draftKept(err error, path string) error {
+	return fmt.Errorf("%w (draft kept; retry with: ginkgo-cli note drafts resume %s)", err, editor.DraftID(path))
+}
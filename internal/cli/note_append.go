@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/db"
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// maxAppendAttempts bounds the retries of an append whose note changed
+// between reading and saving it.
+const maxAppendAttempts = 3
+
+func newNoteAppendCmd() *cobra.Command {
+	var daily, last, stamp bool
+	cmd := &cobra.Command{
+		Use:   "append <id|--daily|--last> [text...]",
+		Short: "Append text to a note without opening the editor",
+		Long: `Append text to the end of a note, after a blank line. The text is taken
+from the arguments, or from stdin when none are given.
+
+--daily appends to today's daily note (creating it), --last to the newest
+note in the namespace. --timestamp prefixes the text with the current time;
+daily note sections are always headed with it.`,
+		Example: `  ginkgo-cli note append 01J... remember to rotate the keys
+  make test 2>&1 | ginkgo-cli note append --last
+  ginkgo-cli note append --daily --timestamp deployed v1.4`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if daily && last {
+				return errors.New("--daily and --last are mutually exclusive")
+			}
+			if !daily && !last && len(args) == 0 {
+				return errors.New("requires a note id, --daily or --last")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ns := resolveNamespace(cmd)
+			if err := ensureNamespaceConfigured(cmd, ns); err != nil {
+				return err
+			}
+			id := ""
+			if !daily && !last {
+				id, args = args[0], args[1:]
+			}
+			text := strings.TrimSpace(strings.Join(args, " "))
+			if text == "" {
+				in, err := readStdin(cmd)
+				if err != nil {
+					return err
+				}
+				text = strings.TrimSpace(in)
+			}
+			if text == "" {
+				return errors.New("nothing to append; pass text or pipe it on stdin")
+			}
+			if daily {
+				return runDaily(cmd, time.Now(), []string{text})
+			}
+			if stamp {
+				text = time.Now().Format(remindLayout) + " " + text
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			if last {
+				if id, err = lastNoteID(cmd, sock, ns); err != nil {
+					return err
+				}
+			}
+			e, resp, err := appendNote(cmd, sock, ns, id, text)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", e.ID, e.Title)
+			warnLinks(cmd.ErrOrStderr(), resp.Links)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&daily, "daily", false, "append to today's daily note")
+	cmd.Flags().BoolVar(&last, "last", false, "append to the newest note")
+	cmd.Flags().BoolVar(&stamp, "timestamp", false, "prefix the text with the current time")
+	return cmd
+}
+
+// appendNote appends text to note id with a CAS edit, retrying on the
+// latest version when the note changed in between.
+func appendNote(cmd *cobra.Command, sock, ns, id, text string) (api.Entry, ipc.Response, error) {
+	show, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.show", ID: id, Namespace: ns})
+	if err != nil {
+		return api.Entry{}, show, err
+	}
+	if !show.OK || show.Entry == nil {
+		return api.Entry{}, show, errors.New(show.Msg)
+	}
+	cur := *show.Entry
+	for range maxAppendAttempts {
+		resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{
+			Name:      "note.edit",
+			ID:        cur.ID,
+			IfVersion: cur.Version,
+			Body:      api.AppendSection(cur.Body, text),
+			Namespace: ns,
+		})
+		if err != nil {
+			return api.Entry{}, resp, err
+		}
+		if resp.OK && resp.Entry != nil {
+			return *resp.Entry, resp, nil
+		}
+		if resp.Msg != "conflict" || resp.Entry == nil {
+			return api.Entry{}, resp, errors.New(resp.Msg)
+		}
+		cur = *resp.Entry
+	}
+	return api.Entry{}, ipc.Response{}, fmt.Errorf("%w: note %s keeps changing; try again", db.ErrConflict, id)
+}
+
+// lastNoteID returns the ID of the newest note in ns.
+func lastNoteID(cmd *cobra.Command, sock, ns string) (string, error) {
+	resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.list", Namespace: ns, Limit: 1})
+	if err != nil {
+		return "", err
+	}
+	if !resp.OK {
+		return "", errors.New(resp.Msg)
+	}
+	if len(resp.Entries) == 0 {
+		return "", fmt.Errorf("no notes in namespace %s", ns)
+	}
+	return resp.Entries[0].ID, nil
+}
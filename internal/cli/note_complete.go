@@ -11,7 +11,7 @@ import (
 func newNoteCompleteTagsCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "complete-tags [input]",
-		Short: "Get fuzzy matches for tags",
+		Short: "Get fuzzy matches for tags, most used first",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			input := ""
@@ -36,13 +36,15 @@ func newNoteCompleteTagsCmd() *cobra.Command {
 				return fmt.Errorf("daemon error: %s", resp.Msg)
 			}
 
-			// Extract tag strings for scoring
+			// Extract tag strings and frecency for scoring
 			tags := make([]string, len(resp.Tags))
+			frecency := make(map[string]int, len(resp.Tags))
 			for i, t := range resp.Tags {
 				tags[i] = t.Tag
+				frecency[t.Tag] = t.Frecency
 			}
 
-			matches := util.ScoreCompletions(input, tags, 20)
+			matches := util.RankCompletions(input, tags, frecency, 20)
 
 			for _, m := range matches {
 				fmt.Println(m)
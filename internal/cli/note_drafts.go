@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/db"
+	"github.com/mithrel/ginkgo/internal/editor"
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func newNoteDraftsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drafts",
+		Short: "Recover notes left in the editor",
+		Long: `A note being written or edited lives in a local draft file until the
+editor saves it. The daemon records each draft with the note version it
+started from and snapshots the file every editor.snapshot_interval, so a
+draft survives a crash, a killed terminal, a failed save and a reboot that
+clears the file.`,
+	}
+	cmd.AddCommand(newNoteDraftsListCmd())
+	cmd.AddCommand(newNoteDraftsResumeCmd())
+	cmd.AddCommand(newNoteDraftsDiffCmd())
+	cmd.AddCommand(newNoteDraftsDiscardCmd())
+	return cmd
+}
+
+func newNoteDraftsListCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List unsaved drafts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ds, err := loadDrafts(cmd)
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				if ds == nil {
+					ds = []api.Draft{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(ds)
+			case "plain":
+				if len(ds) == 0 {
+					_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No drafts.")
+					return nil
+				}
+				for _, d := range ds {
+					base := "new"
+					if d.BaseVersion > 0 {
+						base = fmt.Sprintf("v%d", d.BaseVersion)
+					}
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\t%s\t%s\n", d.ID, d.Namespace, base, d.UpdatedAt.Local().Format(time.DateTime), draftTitle(d))
+				}
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newNoteDraftsResumeCmd() *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Reopen a draft in the editor and save it",
+		Long: `Reopen a draft in the editor and save it when the editor exits. A draft
+of a stored note is saved over it only if the note is still at the version
+the draft started from; --force saves over newer versions.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDraftArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := findDraft(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			ns := d.Namespace
+			if ns == "" {
+				ns = resolveNamespace(cmd)
+			}
+			if err := ensureNamespaceConfigured(cmd, ns); err != nil {
+				return err
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			// The file may be gone with only the daemon's snapshot left.
+			path, err := editor.PathForID(d.ID, ns)
+			if err != nil {
+				return err
+			}
+			// A draft of a stored note saves over it; any other is a new note.
+			id, base := "", d.BaseVersion
+			show, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.show", ID: d.ID, Namespace: ns})
+			if err != nil {
+				return err
+			}
+			var was time.Time
+			if show.OK && show.Entry != nil {
+				id, was = d.ID, show.Entry.CreatedAt
+			} else if show.Msg != db.ErrNotFound.Error() {
+				return errors.New(show.Msg)
+			}
+			if force {
+				base = 0
+			}
+			startDraft(cmd, sock, ns, d.ID, d.BaseVersion, path, []byte(d.Content))
+			if d.Path != path {
+				_ = os.Remove(d.Path)
+			}
+			check := checkFields
+			if id != "" {
+				check = sameNamespace(ns)
+			}
+			n, _, _, err := editor.Edit(path, editor.DetectFormat(d.Content), []byte(d.Content), check)
+			if err != nil {
+				return draftKept(err, path)
+			}
+			if id == "" && n.Namespace != "" && n.Namespace != ns {
+				if err := ensureNamespaceConfigured(cmd, n.Namespace); err != nil {
+					return draftKept(err, path)
+				}
+				ns = n.Namespace
+			}
+			if id != "" {
+				n.CreatedAt = n.NewCreatedAt(was)
+			}
+			return saveDraft(cmd, sock, ns, id, base, path, n, time.Time{})
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "save over the note even if it changed since the draft started")
+	return cmd
+}
+
+func newNoteDraftsDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "diff <id>",
+		Short:             "Show a draft's changes against the stored note",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDraftArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := findDraft(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			from, fromName := "", "/dev/null"
+			show, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.show", ID: d.ID, Namespace: d.Namespace})
+			if err != nil {
+				return err
+			}
+			if show.OK && show.Entry != nil {
+				e := show.Entry
+				remind := remindHeader(show)
+				from = editor.Compose(editor.DetectFormat(d.Content), editor.Note{Title: e.Title, Tags: e.Tags, Namespace: e.Namespace, CreatedAt: e.CreatedAt, Remind: &remind, Fields: e.Fields, Body: e.Body})
+				fromName = fmt.Sprintf("note %s v%d", e.ID, e.Version)
+				if d.BaseVersion > 0 && e.Version != d.BaseVersion {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Note changed since the draft started (v%d, now v%d).\n", d.BaseVersion, e.Version)
+				}
+			} else if show.Msg != db.ErrNotFound.Error() {
+				return errors.New(show.Msg)
+			}
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(from),
+				B:        difflib.SplitLines(d.Content),
+				FromFile: fromName,
+				ToFile:   "draft " + d.ID,
+				Context:  3,
+			})
+			if err != nil {
+				return err
+			}
+			if diff == "" {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Draft matches the stored note.")
+				return nil
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), diff)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newNoteDraftsDiscardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "discard <id>...",
+		Short:             "Throw drafts away",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeDraftArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			for _, id := range args {
+				d, err := findDraft(cmd, id)
+				if err != nil {
+					return err
+				}
+				dropDraft(cmd, sock, d.Path)
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Discarded draft %s\n", d.ID)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// startDraft records the editor file of note id with the daemon, which
+// snapshots it until the draft is dropped. base is the note version the
+// edit starts from (0 for a new note). The daemon not knowing drafts leaves
+// the file as the only copy, as before.
+func startDraft(cmd *cobra.Command, sock, ns, id string, base int64, path string, initial []byte) {
+	_, _ = ipc.Request(cmd.Context(), sock, ipc.Message{Name: "draft.save", ID: id, Namespace: ns, Path: path, IfVersion: base, Body: string(initial)})
+}
+
+// dropDraft removes the draft file at path and the daemon's record of it.
+func dropDraft(cmd *cobra.Command, sock, path string) {
+	_ = os.Remove(path)
+	forgetDraft(cmd, sock, path)
+}
+
+// forgetDraft removes the daemon's record of the draft at path only.
+func forgetDraft(cmd *cobra.Command, sock, path string) {
+	_, _ = ipc.Request(cmd.Context(), sock, ipc.Message{Name: "draft.delete", ID: editor.DraftID(path)})
+}
+
+// loadDrafts merges the daemon's drafts with the draft files on disk, newest
+// first. A file is fresher than its snapshot; a file the daemon does not
+// know (it was unreachable) has an unknown base version.
+func loadDrafts(cmd *cobra.Command) ([]api.Draft, error) {
+	files, err := editor.ListDrafts()
+	if err != nil {
+		return nil, err
+	}
+	var out []api.Draft
+	byID := map[string]int{}
+	if sock, err := ipc.SocketPath(); err == nil {
+		if resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "draft.list", All: true}); err == nil && resp.OK {
+			for _, d := range resp.Drafts {
+				byID[d.ID] = len(out)
+				out = append(out, d)
+			}
+		}
+	}
+	for _, f := range files {
+		i, ok := byID[f.ID]
+		if !ok {
+			out = append(out, f)
+			continue
+		}
+		out[i].Path = f.Path
+		if f.Content != out[i].Content {
+			out[i].Content, out[i].UpdatedAt = f.Content, f.UpdatedAt
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].UpdatedAt.After(out[j].UpdatedAt) })
+	return out, nil
+}
+
+func findDraft(cmd *cobra.Command, id string) (api.Draft, error) {
+	ds, err := loadDrafts(cmd)
+	if err != nil {
+		return api.Draft{}, err
+	}
+	for _, d := range ds {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return api.Draft{}, fmt.Errorf("no such draft: %s", id)
+}
+
+func completeDraftArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ds, err := loadDrafts(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var out []string
+	for _, d := range ds {
+		if strings.HasPrefix(d.ID, toComplete) {
+			out = append(out, d.ID+"\t"+draftTitle(d))
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// draftTitle is the title in a draft's content, "" when it does not parse.
+func draftTitle(d api.Draft) string {
+	n, _ := editor.Parse(editor.DetectFormat(d.Content), d.Content)
+	return n.Title
+}
+
+// sameNamespace rejects an edit that moves a stored note out of ns, or
+// that checkFields rejects.
+func sameNamespace(ns string) func(editor.Note) error {
+	return func(n editor.Note) error {
+		if n.Namespace != "" && n.Namespace != ns {
+			return n.Errorf("namespace", "namespace: moving a note to another namespace is not supported (it is in %q)", ns)
+		}
+		return checkFields(n)
+	}
+}
+
+// checkFields rejects custom fields the daemon would not store, at their
+// line in the editor file.
+func checkFields(n editor.Note) error {
+	for _, k := range api.FieldNames(n.Fields) {
+		if _, err := api.NormalizeFields(map[string]any{k: n.Fields[k]}); err != nil {
+			return n.Errorf(k, "%v", err)
+		}
+	}
+	return nil
+}
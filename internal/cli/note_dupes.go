@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func newNoteDupesCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "dupes",
+		Short: "List groups of duplicate notes",
+		Long: `List groups of duplicate notes in the namespace, oldest note first.
+
+Exact duplicates share title and body up to case and whitespace; near
+duplicates differ by a few words. Review a group with "note show" and fold
+it into one note with "note dupes merge".`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusters, err := fetchDupeClusters(cmd)
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				if clusters == nil {
+					clusters = []api.DupeCluster{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(clusters)
+			case "plain":
+				writeDupeClusters(cmd.OutOrStdout(), clusters)
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.AddCommand(newNoteDupesMergeCmd())
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newNoteDupesMergeCmd() *cobra.Command {
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "merge [<keep-id> [<id>...]]",
+		Short: "Merge duplicate notes into one",
+		Long: `Merge duplicate notes into the note keep-id and delete the others.
+
+Tags are combined and text missing from keep-id is appended to its body.
+Without further ids, the rest of keep-id's duplicate group is merged. With
+--all, every group is merged into its oldest note.`,
+		Example: `  ginkgo-cli note dupes merge 01J8ZK3Q
+  ginkgo-cli note dupes merge 01J8ZK3Q 01J9A0B1 01J9A0C7
+  ginkgo-cli note dupes merge --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) > 0) {
+				return errors.New("pass a note id or --all")
+			}
+			var jobs [][]string
+			if len(args) > 1 {
+				jobs = append(jobs, args)
+			} else {
+				clusters, err := fetchDupeClusters(cmd)
+				if err != nil {
+					return err
+				}
+				for _, c := range clusters {
+					ids := make([]string, 0, len(c.Entries))
+					for _, e := range c.Entries {
+						ids = append(ids, e.ID)
+					}
+					if all {
+						jobs = append(jobs, ids)
+						continue
+					}
+					for i, id := range ids {
+						if id == args[0] {
+							ids[0], ids[i] = ids[i], ids[0]
+							jobs = append(jobs, ids)
+						}
+					}
+				}
+				if !all && len(jobs) == 0 {
+					return fmt.Errorf("note %s has no duplicates", args[0])
+				}
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			for _, ids := range jobs {
+				resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.merge", ID: ids[0], Args: ids[1:], Namespace: resolveNamespace(cmd)})
+				if err != nil {
+					return err
+				}
+				if !resp.OK || resp.Entry == nil {
+					return fmt.Errorf("merge into %s: %s", ids[0], resp.Msg)
+				}
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "merged %d into %s\t%s\n", len(ids)-1, resp.Entry.ID, resp.Entry.Title)
+			}
+			if len(jobs) == 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "no duplicates")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "merge every duplicate group into its oldest note")
+	return cmd
+}
+
+func fetchDupeClusters(cmd *cobra.Command) ([]api.DupeCluster, error) {
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.dupes", Namespace: resolveNamespace(cmd)})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return nil, errors.New(resp.Msg)
+	}
+	return resp.Clusters, nil
+}
+
+func writeDupeClusters(w io.Writer, clusters []api.DupeCluster) {
+	if len(clusters) == 0 {
+		_, _ = fmt.Fprintln(w, "no duplicates")
+		return
+	}
+	for i, c := range clusters {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		kind := "near"
+		if c.Exact {
+			kind = "exact"
+		}
+		_, _ = fmt.Fprintf(w, "%s duplicates (%d notes)\n", kind, len(c.Entries))
+		for _, e := range c.Entries {
+			_, _ = fmt.Fprintf(w, "  %s  %s  %s\n", e.ID, e.CreatedAt.Local().Format(time.DateTime), e.Title)
+		}
+	}
+}
+
+// warnDuplicates tells the user a note they just saved repeats stored ones.
+func warnDuplicates(w io.Writer, dups []api.Duplicate) {
+	if len(dups) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "warning: duplicates %d existing note(s); review with \"note dupes\":\n", len(dups))
+	for _, d := range dups {
+		kind := "near"
+		if d.Exact {
+			kind = "exact"
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t(%s)\n", d.Entry.ID, d.Entry.Title, kind)
+	}
+}
@@ -3,8 +3,6 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/mithrel/ginkgo/internal/db"
@@ -41,44 +39,53 @@ func newNoteEditCmd() *cobra.Command {
 				return errors.New("not found")
 			}
 			cur := *show.Entry
+			remind := remindHeader(show)
 			// Prefill editor content
-			initial := []byte(editor.ComposeContent(cur.Title, cur.Tags, cur.Body))
+			format := editorFormat(cmd)
+			initial := []byte(editor.Compose(format, editor.Note{Title: cur.Title, Tags: cur.Tags, Namespace: ns, CreatedAt: cur.CreatedAt, Remind: &remind, Fields: cur.Fields, Body: cur.Body}))
 
 			path, err := editor.PathForID(id, ns)
 			if err != nil {
 				return err
 			}
-			out, changed, err := editor.OpenAt(path, initial)
-			if err != nil {
-				return err
+			// The edit is a draft until saved; it is kept when saving fails.
+			done := func() {
+				if keepTmp {
+					forgetDraft(cmd, sock, path)
+				} else {
+					dropDraft(cmd, sock, path)
+				}
 			}
-			if !keepTmp {
-				_ = os.Remove(path)
+			startDraft(cmd, sock, ns, id, cur.Version, path, initial)
+			n, _, changed, err := editor.Edit(path, format, initial, sameNamespace(ns))
+			if err != nil {
+				return draftKept(err, path)
 			}
 			if !changed {
+				done()
 				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No changes.")
 				return nil
 			}
-			title, tags, body := editor.ParseEditedNote(string(out))
-			if title == "" && strings.TrimSpace(body) == "" {
-				return fmt.Errorf("edit aborted: empty content")
+			if n.Title == "" {
+				n.Title = editor.FirstLine(n.Body)
 			}
-			if title == "" {
-				title = editor.FirstLine(body)
-				if title == "" {
-					return fmt.Errorf("edit aborted: empty content")
-				}
+			if n.Title == "" {
+				done()
+				return fmt.Errorf("edit aborted: empty content")
 			}
-			cur.Title = title
-			cur.Tags = tags
-			cur.Body = body
+			cur.Title = n.Title
+			cur.Tags = n.Tags
+			cur.Body = n.Body
 			cur.UpdatedAt = time.Now().UTC()
-			eResp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.edit", ID: cur.ID, IfVersion: cur.Version, Title: cur.Title, Body: cur.Body, Tags: cur.Tags, Namespace: ns})
+			eResp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.edit", ID: cur.ID, IfVersion: cur.Version, Title: cur.Title, Body: cur.Body, Tags: cur.Tags, Namespace: ns, CreatedAt: n.NewCreatedAt(cur.CreatedAt), Fields: n.Fields, SetFields: n.Fields != nil})
 			if err != nil {
 				return err
 			}
 			if eResp.OK && eResp.Entry != nil {
+				done()
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", eResp.Entry.ID, eResp.Entry.Title)
+				applyRemind(cmd, sock, ns, cur.ID, remind, n.RemindText())
+				warnLinks(cmd.ErrOrStderr(), eResp.Links)
 				return nil
 			}
 			if eResp.Msg != "conflict" {
@@ -89,9 +96,9 @@ func newNoteEditCmd() *cobra.Command {
 			}
 
 			// Conflict: load latest and optionally reopen
-			latest := cur
+			latest, latestRemind := cur, remind
 			if show2, gerr := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.show", ID: id, Namespace: ns}); gerr == nil && show2.Entry != nil {
-				latest = *show2.Entry
+				latest, latestRemind = *show2.Entry, remindHeader(show2)
 			}
 			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Conflict: note has changed since you opened it.")
 			if cur.Title != latest.Title {
@@ -101,31 +108,31 @@ func newNoteEditCmd() *cobra.Command {
 				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "(body differs)\n")
 			}
 			if !force {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Your edit is kept as a draft: ginkgo-cli note drafts diff %s\n", id)
 				return db.ErrConflict
 			}
 
 			// Reopen against latest
-			reopen := editor.ComposeContent(latest.Title, latest.Tags, latest.Body)
-			out2, changed2, err := editor.OpenAt(path, []byte(reopen))
+			reopen := editor.Compose(format, editor.Note{Title: latest.Title, Tags: latest.Tags, Namespace: ns, CreatedAt: latest.CreatedAt, Remind: &latestRemind, Fields: latest.Fields, Body: latest.Body})
+			startDraft(cmd, sock, ns, id, latest.Version, path, []byte(reopen))
+			n2, _, changed2, err := editor.Edit(path, format, []byte(reopen), sameNamespace(ns))
 			if err != nil {
-				return err
-			}
-			if !keepTmp {
-				_ = os.Remove(path)
+				return draftKept(err, path)
 			}
 			if !changed2 {
+				done()
 				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No changes.")
 				return nil
 			}
-			t2, tg2, b2 := editor.ParseEditedNote(string(out2))
-			if t2 == "" && strings.TrimSpace(b2) == "" {
-				return fmt.Errorf("edit aborted: empty content")
+			if n2.Title == "" {
+				n2.Title = editor.FirstLine(n2.Body)
 			}
-			if t2 == "" {
-				t2 = editor.FirstLine(b2)
+			if n2.Title == "" {
+				done()
+				return fmt.Errorf("edit aborted: empty content")
 			}
-			latest.Title, latest.Tags, latest.Body = t2, tg2, b2
-			e2, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.edit", ID: latest.ID, IfVersion: latest.Version, Title: latest.Title, Body: latest.Body, Tags: latest.Tags, Namespace: ns})
+			latest.Title, latest.Tags, latest.Body = n2.Title, n2.Tags, n2.Body
+			e2, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.edit", ID: latest.ID, IfVersion: latest.Version, Title: latest.Title, Body: latest.Body, Tags: latest.Tags, Namespace: ns, CreatedAt: n2.NewCreatedAt(latest.CreatedAt), Fields: n2.Fields, SetFields: n2.Fields != nil})
 			if err != nil {
 				return err
 			}
@@ -135,7 +142,10 @@ func newNoteEditCmd() *cobra.Command {
 				}
 				return db.ErrConflict
 			}
+			done()
 			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", e2.Entry.ID, e2.Entry.Title)
+			applyRemind(cmd, sock, ns, latest.ID, latestRemind, n2.RemindText())
+			warnLinks(cmd.ErrOrStderr(), e2.Links)
 			return nil
 		},
 	}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/present"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func newNoteLinksCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "links <id>",
+		Short: "List the notes a note links to with [[...]]",
+		Long: `List the [[target]] links in a note's body, in order.
+
+A target is a note ID or a note title (case-insensitive) in the same
+namespace; when several notes share a title the oldest wins. Links that
+match no note are listed as unresolved.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.links", ID: args[0], Namespace: resolveNamespace(cmd)})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				links := resp.Links
+				if links == nil {
+					links = []api.Link{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(links)
+			case "plain":
+				if len(resp.Links) == 0 {
+					_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No links.")
+					return nil
+				}
+				for _, l := range resp.Links {
+					if l.Entry == nil {
+						_, _ = fmt.Fprintf(cmd.OutOrStdout(), "[[%s]]\t-\t(unresolved)\n", l.Target)
+						continue
+					}
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "[[%s]]\t%s\t%s\n", l.Target, l.Entry.ID, l.Entry.Title)
+				}
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newNoteBacklinksCmd() *cobra.Command {
+	var outputMode string
+	var noHeaders bool
+	cmd := &cobra.Command{
+		Use:   "backlinks <id>",
+		Short: "List notes linking to a note",
+		Long: `List the notes whose [[target]] links resolve to a note, by its ID or
+title, most recently updated first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mode, ok := present.ParseMode(strings.ToLower(outputMode))
+			if !ok || mode == present.ModeTUI {
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.backlinks", ID: args[0], Namespace: resolveNamespace(cmd)})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			if len(resp.Entries) == 0 {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No backlinks.")
+				return nil
+			}
+			opts := present.Options{Mode: mode, JSONIndent: false, Headers: !noHeaders}
+			return withPager(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), func(w io.Writer) error {
+				writer := newEntryStreamWriter(w, opts)
+				if err := writer.WriteEntries(resp.Entries); err != nil && !isBrokenPipe(err) {
+					return err
+				}
+				if err := writer.Close(); err != nil && !isBrokenPipe(err) {
+					return err
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|pretty|json|ndjson")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "pretty", "json", "ndjson"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVarP(&noHeaders, "noheaders", "H", false, "hide column headers (plain)")
+	return cmd
+}
+
+// warnLinks tells the user a note they just saved has links to no note.
+func warnLinks(w io.Writer, unresolved []api.Link) {
+	for _, l := range unresolved {
+		_, _ = fmt.Fprintf(w, "warning: link [[%s]] matches no note\n", l.Target)
+	}
+}
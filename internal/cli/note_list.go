@@ -9,91 +9,137 @@ import (
 
 	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/internal/present"
+	"github.com/mithrel/ginkgo/internal/templates"
 	"github.com/mithrel/ginkgo/internal/util"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
 
+// listOpts holds the flags shared by note list and view run.
+type listOpts struct {
+	filters    FilterOpts
+	outputMode string
+	noHeaders  bool
+	pageSize   int
+	export     bool
+	queryExpr  string
+	where      []string
+	sort       string
+	view       string
+}
+
 func newNoteListCmd() *cobra.Command {
-	var filters FilterOpts
-	var outputMode string
-	var noHeaders bool
-	var pageSize int
-	var export bool
+	var o listOpts
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List notes",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			app := getApp(cmd)
-			sinceStr, untilStr, err := util.NormalizeTimeRange(filters.Since, filters.Until)
-			if err != nil {
-				return err
-			}
-			any := splitCSV(filters.TagsAny)
-			all := splitCSV(filters.TagsAll)
-
-			sock, err := ipc.SocketPath()
-			if err != nil {
-				return err
-			}
-
-			if pageSize <= 0 {
-				pageSize = app.Cfg.GetInt("export.page_size")
-			}
-			mode, ok := present.ParseMode(strings.ToLower(outputMode))
-			if !ok {
-				return fmt.Errorf("invalid --output: %s", outputMode)
-			}
-			if mode == present.ModeTUI {
-				if outFile, ok := cmd.OutOrStdout().(*os.File); !ok || !term.IsTerminal(int(outFile.Fd())) {
-					mode = present.ModePlain
-				}
-			}
-			dur := time.Duration(0)
-			if !ok {
-				return fmt.Errorf("invalid --output: %s", outputMode)
-			}
-			opts := present.Options{
-				Mode:            mode,
-				JSONIndent:      false, // pretty-print via external tools like jq
-				Headers:         !noHeaders,
-				InitialStatus:   "loaded successfully",
-				InitialDuration: dur,
-				FilterTagsAny:   filters.TagsAny,
-				FilterTagsAll:   filters.TagsAll,
-				FilterSince:     filters.Since,
-				FilterUntil:     filters.Until,
-				Namespace:       resolveNamespace(cmd),
-				TUIBufferRatio:  app.Cfg.GetFloat64("tui.buffer_ratio"),
-			}
-			if mode == present.ModeTUI {
-				return renderEntries(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), nil, opts)
-			}
-			return withPager(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), func(w io.Writer) error {
-				writer := newEntryStreamWriter(w, opts)
-				return streamEntries(cmd.Context(), sock, pageSize, func(cursor string) ipc.Message {
-					return ipc.Message{
-						Name:        "note.list",
-						Namespace:   resolveNamespace(cmd),
-						TagsAny:     any,
-						TagsAll:     all,
-						Since:       sinceStr, // RFC3339 string or ""
-						Until:       untilStr, // RFC3339 string or ""
-						IncludeBody: export,
-					}
-				}, writer)
-			})
+			return runList(cmd, &o)
 		},
 	}
-	addFilterFlags(cmd, &filters)
-	cmd.Flags().StringVar(&outputMode, "output", "tui", "output mode: plain|pretty|json|ndjson|tui")
-	cmd.Flags().IntVar(&pageSize, "page-size", 0, "page size for export paging (0 uses config)")
-	cmd.Flags().BoolVar(&export, "export", false, "include note bodies in output")
+	addListFlags(cmd, &o)
+	cmd.Flags().StringVar(&o.view, "view", "", "start from a saved view; other filter flags override its fields")
+	_ = cmd.RegisterFlagCompletionFunc("view", completeViews)
+	return cmd
+}
+
+func addListFlags(cmd *cobra.Command, o *listOpts) {
+	addFilterFlags(cmd, &o.filters)
+	cmd.Flags().StringVar(&o.outputMode, "output", "tui", "output mode: plain|pretty|json|ndjson|tui")
+	cmd.Flags().IntVar(&o.pageSize, "page-size", 0, "page size for export paging (0 uses config)")
+	cmd.Flags().BoolVar(&o.export, "export", false, "include note bodies in output")
+	cmd.Flags().StringVarP(&o.queryExpr, "query", "q", "", "filter with a search query, e.g. 'tag:work -tag:draft created:>7d' (see note search fts --help)")
 	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"plain", "pretty", "json", "ndjson", "tui"}, cobra.ShellCompDirectiveNoFileComp
 	})
-	cmd.Flags().BoolVar(&noHeaders, "noheaders", false, "hide column headers (plain/tui)")
-	return cmd
+	cmd.Flags().BoolVar(&o.noHeaders, "noheaders", false, "hide column headers (plain/tui)")
+	addWhereFlag(cmd, &o.where)
+	cmd.Flags().StringVar(&o.sort, "sort", "", "order by a custom field, '-field' for descending (not in the TUI)")
+}
+
+func runList(cmd *cobra.Command, o *listOpts) error {
+	app := getApp(cmd)
+	if o.view != "" {
+		if err := applyView(cmd, o); err != nil {
+			return err
+		}
+	}
+	filters := o.filters
+	queryExpr, err := whereQuery(o.queryExpr, o.where)
+	if err != nil {
+		return err
+	}
+	sinceStr, untilStr, err := util.NormalizeTimeRange(filters.Since, filters.Until)
+	if err != nil {
+		return err
+	}
+	any := splitCSV(filters.TagsAny)
+	all := splitCSV(filters.TagsAll)
+	if queryExpr != "" {
+		if err := checkQuery(queryExpr); err != nil {
+			return err
+		}
+	}
+
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return err
+	}
+
+	pageSize := o.pageSize
+	if pageSize <= 0 {
+		pageSize = app.Cfg.GetInt("export.page_size")
+	}
+	mode, ok := present.ParseMode(strings.ToLower(o.outputMode))
+	if !ok {
+		return fmt.Errorf("invalid --output: %s", o.outputMode)
+	}
+	if mode == present.ModeTUI {
+		// The TUI pages by creation time, so field-sorted lists print plain.
+		if outFile, ok := cmd.OutOrStdout().(*os.File); !ok || !term.IsTerminal(int(outFile.Fd())) || o.sort != "" {
+			mode = present.ModePlain
+		}
+	}
+	dur := time.Duration(0)
+	status := "loaded successfully"
+	if o.view != "" {
+		status = fmt.Sprintf("view %s", o.view)
+	}
+	opts := present.Options{
+		Mode:            mode,
+		JSONIndent:      false, // pretty-print via external tools like jq
+		Headers:         !o.noHeaders,
+		InitialStatus:   status,
+		InitialDuration: dur,
+		FilterTagsAny:   filters.TagsAny,
+		FilterTagsAll:   filters.TagsAll,
+		FilterSince:     filters.Since,
+		FilterUntil:     filters.Until,
+		FilterQuery:     queryExpr,
+		Namespace:       resolveNamespace(cmd),
+		TUIBufferRatio:  app.Cfg.GetFloat64("tui.buffer_ratio"),
+		EditorFormat:    editorFormat(cmd),
+		Templates:       func() ([]templates.Template, error) { return loadTemplates(cmd) },
+	}
+	if mode == present.ModeTUI {
+		return renderEntries(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), nil, opts)
+	}
+	return withPager(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), func(w io.Writer) error {
+		writer := newEntryStreamWriter(w, opts)
+		return streamEntries(cmd.Context(), sock, pageSize, func(cursor string) ipc.Message {
+			return ipc.Message{
+				Name:        "note.list",
+				Namespace:   resolveNamespace(cmd),
+				TagsAny:     any,
+				TagsAll:     all,
+				Since:       sinceStr, // RFC3339 string or ""
+				Until:       untilStr, // RFC3339 string or ""
+				IncludeBody: o.export,
+				Query:       queryExpr,
+				Sort:        o.sort,
+			}
+		}, writer)
+	})
 }
 
 // splitCSV splits a comma-separated list into trimmed non-empty strings.
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/present"
+	"github.com/spf13/cobra"
+)
+
+func newNoteRelatedCmd() *cobra.Command {
+	var outputMode string
+	var noHeaders bool
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "related <id>",
+		Short: "List notes similar to a note (TF-IDF over title, body and tags)",
+		Long: `List the notes most similar to a note.
+
+Notes in the same namespace are ranked by cosine similarity of their TF-IDF
+term vectors over title, body and tags; title words and tags weigh double.
+The score column is the similarity, from 0 (nothing shared) to 1.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mode, ok := present.ParseMode(strings.ToLower(outputMode))
+			if !ok || mode == present.ModeTUI {
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.related", ID: args[0], Namespace: resolveNamespace(cmd), Limit: limit})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			if len(resp.Hits) == 0 {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No related notes.")
+				return nil
+			}
+			opts := present.Options{Mode: mode, JSONIndent: false, Headers: !noHeaders}
+			return withPager(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), func(w io.Writer) error {
+				writer := newEntryStreamWriter(w, opts)
+				if hw, ok := writer.(hitStreamWriter); ok {
+					err = hw.WriteHits(resp.Hits)
+				} else {
+					err = writer.WriteEntries(resp.Entries)
+				}
+				if err != nil && !isBrokenPipe(err) {
+					return err
+				}
+				if err := writer.Close(); err != nil && !isBrokenPipe(err) {
+					return err
+				}
+				return nil
+			})
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 10, "maximum number of related notes")
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|pretty|json|ndjson")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "pretty", "json", "ndjson"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVarP(&noHeaders, "noheaders", "H", false, "hide column headers (plain)")
+	return cmd
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// remindLayout renders reminder times; util.ParseWhen reads it back.
+const remindLayout = "2006-01-02 15:04"
+
+func newNoteRemindCmd() *cobra.Command {
+	var device string
+	var clear bool
+	cmd := &cobra.Command{
+		Use:   "remind <id> <when...>",
+		Short: "Set or clear a note's reminder",
+		Long: `Set a reminder that the daemon fires as a notification at the given time.
+
+Times are relative ("in 2h", "3d"), a day with an optional clock ("today",
+"tonight", "tomorrow 9am", "friday 14:30"), a bare clock ("9am", "noon";
+the next one to come) or absolute ("2025-03-01 09:00"). Days without a
+clock mean 9:00.
+
+Reminders replicate like notes but fire on one device only: by default the
+one setting it (namespaces.<name>.origin_label, notifications.device or
+the hostname). A note has at most one reminder; setting another replaces it.`,
+		Example: `  ginkgo-cli note remind 01J... tomorrow 9am
+  ginkgo-cli note remind 01J... in 2h --device phone
+  ginkgo-cli note remind 01J... --clear`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if clear {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			if len(args) < 2 {
+				return errors.New("requires a note id and a time")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			m := ipc.Message{Name: "note.remind", ID: args[0], Namespace: resolveNamespace(cmd), Device: device}
+			if !clear {
+				at, err := util.ParseWhen(strings.Join(args[1:], " "), time.Now())
+				if err != nil {
+					return err
+				}
+				if !at.After(time.Now()) {
+					return fmt.Errorf("reminder time %s is in the past", at.Format(remindLayout))
+				}
+				m.At = at.UTC().Format(time.RFC3339)
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, m)
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			if clear || len(resp.Reminders) == 0 {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\treminder cleared\n", args[0])
+				return nil
+			}
+			r := resp.Reminders[0]
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", r.NoteID, r.At.Local().Format(remindLayout), r.Device)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&device, "device", "", "device that fires the reminder (default: this one)")
+	cmd.Flags().BoolVar(&clear, "clear", false, "remove the note's reminder")
+	return cmd
+}
+
+func newNoteRemindersCmd() *cobra.Command {
+	var outputMode string
+	var all bool
+	cmd := &cobra.Command{
+		Use:   "reminders",
+		Short: "List upcoming reminders",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.reminders", Namespace: resolveNamespace(cmd), All: all})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				rs := resp.Reminders
+				if rs == nil {
+					rs = []api.Reminder{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(rs)
+			case "plain":
+				if len(resp.Reminders) == 0 {
+					_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No reminders.")
+					return nil
+				}
+				writeReminders(cmd.OutOrStdout(), resp.Reminders)
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "include reminders that already fired")
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func writeReminders(w io.Writer, rs []api.Reminder) {
+	for _, r := range rs {
+		device := r.Device
+		if device == "" {
+			device = "-"
+		}
+		line := fmt.Sprintf("%s\t%s\t%s\t%s", r.At.Local().Format(remindLayout), r.NoteID, device, r.Title)
+		if !r.FiredAt.IsZero() {
+			line += "\t(fired " + r.FiredAt.Local().Format(remindLayout) + ")"
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+}
+
+// remindHeader is the editor's Remind header for a note.show response:
+// the pending reminder's time, or "" when there is none.
+func remindHeader(resp ipc.Response) string {
+	for _, r := range resp.Reminders {
+		if r.FiredAt.IsZero() {
+			return r.At.Local().Format(remindLayout)
+		}
+	}
+	return ""
+}
+
+// applyRemind sets or clears note id's reminder when an editor session
+// changed its Remind header from prev to next. Problems are reported on
+// stderr since the note itself has been saved.
+func applyRemind(cmd *cobra.Command, sock, ns, id, prev, next string) {
+	if next == prev {
+		return
+	}
+	m := ipc.Message{Name: "note.remind", ID: id, Namespace: ns}
+	if next != "" {
+		at, err := util.ParseWhen(next, time.Now())
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "reminder not set: %v\n", err)
+			return
+		}
+		m.At = at.UTC().Format(time.RFC3339)
+	}
+	resp, err := ipc.Request(cmd.Context(), sock, m)
+	if err == nil && !resp.OK {
+		err = errors.New(resp.Msg)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "reminder not updated: %v\n", err)
+	}
+}
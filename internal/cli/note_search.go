@@ -1,13 +1,16 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/internal/present"
+	"github.com/mithrel/ginkgo/internal/query"
 	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
 	"github.com/spf13/cobra"
 )
 
@@ -16,60 +19,144 @@ func newNoteSearchCmd() *cobra.Command {
 	var outputMode string
 	var noHeaders bool
 	var pageSize int
+	var sortBy string
+	var where []string
 	cmd := &cobra.Command{
 		Use:   "search",
-		Short: "Search notes (fts|regex)",
+		Short: "Search notes (fts|fuzzy|regex)",
+	}
+
+	// textSearch streams note.search.fts or note.search.fuzzy results for q
+	// and reports spelling suggestions on stderr.
+	textSearch := func(cmd *cobra.Command, name, q string) error {
+		app := getApp(cmd)
+		q, err := whereQuery(q, where)
+		if err != nil {
+			return err
+		}
+		if err := checkQuery(q); err != nil {
+			return err
+		}
+		switch sortBy {
+		case api.SortCreated, api.SortRelevance:
+		default:
+			return fmt.Errorf("invalid --sort: %s (want created|relevance)", sortBy)
+		}
+		sinceStr, untilStr, err := util.NormalizeTimeRange(filters.Since, filters.Until)
+		if err != nil {
+			return err
+		}
+		any := splitCSV(filters.TagsAny)
+		all := splitCSV(filters.TagsAll)
+
+		sock, err := ipc.SocketPath()
+		if err != nil {
+			return err
+		}
+
+		if pageSize <= 0 {
+			pageSize = app.Cfg.GetInt("export.page_size")
+		}
+		mode, ok := present.ParseMode(strings.ToLower(outputMode))
+		if !ok || mode == present.ModeTUI {
+			return fmt.Errorf("invalid --output: %s", outputMode)
+		}
+		opts := present.Options{Mode: mode, JSONIndent: false, Headers: !noHeaders}
+		build := func(name string) func(cursor string) ipc.Message {
+			return func(cursor string) ipc.Message {
+				return ipc.Message{
+					Name:      name,
+					Title:     q,
+					Namespace: resolveNamespace(cmd),
+					TagsAny:   any,
+					TagsAll:   all,
+					Since:     sinceStr, // RFC3339 or ""
+					Until:     untilStr, // RFC3339 or ""
+					SortBy:    sortBy,
+				}
+			}
+		}
+		errOut := cmd.ErrOrStderr()
+		found, suggested := false, false
+		onPage := func(resp ipc.Response) {
+			found = found || len(resp.Entries) > 0
+			if resp.Corrected != "" && !suggested {
+				suggested = true
+				printSuggestions(errOut, resp.Corrected, resp.Suggestions)
+			}
+		}
+		return withPager(cmd.Context(), cmd.OutOrStdout(), errOut, func(w io.Writer) error {
+			writer := newEntryStreamWriter(w, opts)
+			if err := streamEntriesWith(cmd.Context(), sock, pageSize, build(name), writer, onPage); err != nil {
+				return err
+			}
+			if found || name != "note.search.fts" {
+				return nil
+			}
+			// Nothing matched as typed: offer a correction without running it.
+			msg := build("note.search.fuzzy")("")
+			msg.Limit = 1
+			resp, err := ipc.Request(cmd.Context(), sock, msg)
+			if err == nil && resp.OK && resp.Corrected != "" && len(resp.Entries) > 0 {
+				fmt.Fprintf(errOut, "No matches. Did you mean: %s (try note search fuzzy)\n", resp.Corrected)
+			}
+			return nil
+		})
 	}
 
 	// Full-text search
 	fts := &cobra.Command{
 		Use:   "fts <query>",
-		Short: "Full-text style search",
-		Args:  cobra.ExactArgs(1),
+		Short: "Full-text search using the query language",
+		Long: `Full-text search using the query language.
+
+Terms are ANDed; OR binds looser and parentheses group. Bare words and
+"phrases" match title, body and tags; a trailing * matches a prefix.
+
+  title:<term> / body:<term>   match one column
+  tag:<tag> / tag:<prefix>*    require a tag; -tag:<tag> excludes it
+  created:<op><date>           also updated:; op is >, >=, <, <= or =
+                               date is 2025, 2025-01, 2025-01-02, today,
+                               yesterday, or an age such as 7d, 2w, 3mo
+  field:<name><op><value>      compare a custom field (op also !=);
+                               field:<name> requires it; see --where
+  -<term> / NOT <term>         exclude
+
+Results are newest first; --sort relevance ranks them by BM25 (title
+matches weigh most, then tags, then body). Plain and pretty output include
+the score and a snippet with matches highlighted.
+
+Example:
+  ginkgo-cli note search fts 'title:standup tag:work -tag:draft created:>2025-01 "exact phrase" OR deploy*'`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			app := getApp(cmd)
-			q := args[0]
-			sinceStr, untilStr, err := util.NormalizeTimeRange(filters.Since, filters.Until)
-			if err != nil {
-				return err
-			}
-			any := splitCSV(filters.TagsAny)
-			all := splitCSV(filters.TagsAll)
+			return textSearch(cmd, "note.search.fts", args[0])
+		},
+	}
 
-			sock, err := ipc.SocketPath()
-			if err != nil {
-				return err
-			}
+	// Typo-tolerant search
+	fuzzy := &cobra.Command{
+		Use:   "fuzzy <query>",
+		Short: "Full-text search that corrects misspelled terms",
+		Long: `Full-text search that corrects misspelled terms.
 
-			if pageSize <= 0 {
-				pageSize = app.Cfg.GetInt("export.page_size")
-			}
-			mode, ok := present.ParseMode(strings.ToLower(outputMode))
-			if !ok || mode == present.ModeTUI {
-				return fmt.Errorf("invalid --output: %s", outputMode)
-			}
-			opts := present.Options{Mode: mode, JSONIndent: false, Headers: !noHeaders}
-			return withPager(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), func(w io.Writer) error {
-				writer := newEntryStreamWriter(w, opts)
-				return streamEntries(cmd.Context(), sock, pageSize, func(cursor string) ipc.Message {
-					return ipc.Message{
-						Name:      "note.search.fts",
-						Title:     q,
-						Namespace: resolveNamespace(cmd),
-						TagsAny:   any,
-						TagsAll:   all,
-						Since:     sinceStr, // RFC3339 or ""
-						Until:     untilStr, // RFC3339 or ""
-					}
-				}, writer)
-			})
+Words that occur in no note are replaced by the closest indexed term (one
+typo for words up to four letters, two for longer ones) before the query
+runs, and the corrected query is reported on stderr as "did you mean".
+Accepts the same query language and flags as fts.
+
+Example:
+  ginkgo-cli note search fuzzy 'kubernets upgrade'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return textSearch(cmd, "note.search.fuzzy", args[0])
 		},
 	}
 
 	// Regex search (narrowed via trigram-like prefilter in daemon)
 	rx := &cobra.Command{
 		Use:   "regex <pattern>",
-		Short: "Regex search (with FTS narrowing)",
+		Short: "Regex search (with trigram index narrowing)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app := getApp(cmd)
@@ -109,7 +196,15 @@ func newNoteSearchCmd() *cobra.Command {
 		},
 	}
 
-	cmd.AddCommand(fts, rx)
+	for _, c := range []*cobra.Command{fts, fuzzy} {
+		c.Flags().StringVar(&sortBy, "sort", api.SortCreated, "result order: created|relevance")
+		addWhereFlag(c, &where)
+		_ = c.RegisterFlagCompletionFunc("sort", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{api.SortCreated, api.SortRelevance}, cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+
+	cmd.AddCommand(fts, fuzzy, rx)
 	addFilterFlags(cmd, &filters)
 	cmd.PersistentFlags().StringVar(&outputMode, "output", "plain", "output mode: plain|pretty|json|ndjson")
 	cmd.PersistentFlags().IntVar(&pageSize, "page-size", 0, "page size for export paging (0 uses config)")
@@ -120,3 +215,27 @@ func newNoteSearchCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(&noHeaders, "noheaders", "H", false, "hide column headers (plain)")
 	return cmd
 }
+
+// checkQuery parses q locally so syntax errors point at the offending column
+// before anything is sent to the daemon.
+func checkQuery(q string) error {
+	if _, err := query.Parse(q); err != nil {
+		var se *query.SyntaxError
+		if errors.As(err, &se) {
+			return fmt.Errorf("%w\n%s", err, se.Context(q))
+		}
+		return err
+	}
+	return nil
+}
+
+// printSuggestions reports the query a fuzzy search ran and the other
+// candidates for each corrected term.
+func printSuggestions(w io.Writer, corrected string, sugg []api.TermSuggestion) {
+	fmt.Fprintf(w, "Did you mean: %s\n", corrected)
+	for _, ts := range sugg {
+		if len(ts.Suggestions) > 1 {
+			fmt.Fprintf(w, "  %s → %s (also: %s)\n", ts.Term, ts.Suggestions[0], strings.Join(ts.Suggestions[1:], ", "))
+		}
+	}
+}
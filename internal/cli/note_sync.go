@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"context"
 	"errors"
 	"fmt"
 
@@ -14,21 +13,24 @@ func newNoteSyncCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "Replicate local note changes to remotes",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			sock, err := ipc.SocketPath()
-			if err != nil {
-				return err
-			}
-			resp, err := ipc.Request(context.Background(), sock, ipc.Message{Name: "sync.run"})
-			if err != nil {
-				return err
-			}
-			if !resp.OK {
-				return errors.New(resp.Msg)
-			}
-			_, _ = fmt.Fprintln(cmd.OutOrStdout(), "sync: triggered")
-			return nil
-		},
+		RunE:  runSyncNow,
 	}
 	return cmd
 }
+
+// runSyncNow asks the daemon for an immediate sync pass.
+func runSyncNow(cmd *cobra.Command, args []string) error {
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return err
+	}
+	resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "sync.run"})
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		return errors.New(resp.Msg)
+	}
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "sync: triggered")
+	return nil
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/util"
+)
+
+// newNotifyCmd defines "notify", the controls for the daemon's nudges.
+// Unlike other commands, --namespace defaults to every namespace.
+func newNotifyCmd() *cobra.Command {
+	var nsFlag string
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Inspect, snooze and mute notifications",
+		Long: `The daemon nudges when a namespace has had no new note for a while
+(notifications.every_days, or namespaces.<name>.notify_every_days). Nudges
+are held back during notifications.quiet_hours and while snoozed or muted;
+snooze and mute state survives daemon restarts.`,
+	}
+	cmd.AddCommand(newNotifyStatusCmd())
+	cmd.AddCommand(newNotifySnoozeCmd())
+	cmd.AddCommand(newNotifyMuteCmd(true))
+	cmd.AddCommand(newNotifyMuteCmd(false))
+	cmd.PersistentFlags().StringVarP(&nsFlag, "namespace", "n", "", "only this namespace (default: all)")
+	registerNamespaceCompletion(cmd)
+	return cmd
+}
+
+func newNotifyStatusCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show notification rules, when they are due and snooze/mute state",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := notifyRequest(cmd, ipc.Message{Name: "notify.status"})
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				sts := resp.NotifyStatus
+				if sts == nil {
+					sts = []ipc.NotifyStatus{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(sts)
+			case "plain":
+				writeNotifyStatus(cmd.OutOrStdout(), resp.NotifyStatus, time.Now())
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newNotifySnoozeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snooze [duration]",
+		Short: "Hold back notifications for a while (default 1d; 0 resumes)",
+		Example: `  ginkgo-cli notify snooze 4h
+  ginkgo-cli notify snooze 1w -n work
+  ginkgo-cli notify snooze 0`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d := 24 * time.Hour
+			if len(args) == 1 {
+				var err error
+				if d, err = util.ParseDuration(args[0]); err != nil {
+					return err
+				}
+			}
+			m := ipc.Message{Name: "notify.snooze"}
+			if d > 0 {
+				m.Until = time.Now().Add(d).UTC().Format(time.RFC3339)
+			}
+			if _, err := notifyRequest(cmd, m); err != nil {
+				return err
+			}
+			if d == 0 {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "notifications resumed for %s\n", notifyScope(cmd))
+				return nil
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "notifications snoozed for %s until %s\n", notifyScope(cmd), time.Now().Add(d).Format("2006-01-02 15:04"))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newNotifyMuteCmd(mute bool) *cobra.Command {
+	use, short, done := "mute", "Silence notifications until unmuted", "muted"
+	if !mute {
+		use, short, done = "unmute", "Resume muted notifications", "unmuted"
+	}
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := notifyRequest(cmd, ipc.Message{Name: "notify." + use}); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "notifications %s for %s\n", done, notifyScope(cmd))
+			return nil
+		},
+	}
+}
+
+// notifyRequest sends m for the --namespace flag, which unlike
+// resolveNamespace is empty (all namespaces) unless given.
+func notifyRequest(cmd *cobra.Command, m ipc.Message) (ipc.Response, error) {
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return ipc.Response{}, err
+	}
+	m.Namespace, _ = cmd.Flags().GetString("namespace")
+	resp, err := ipc.Request(cmd.Context(), sock, m)
+	if err != nil {
+		return resp, err
+	}
+	if !resp.OK {
+		return resp, errors.New(resp.Msg)
+	}
+	return resp, nil
+}
+
+func notifyScope(cmd *cobra.Command) string {
+	if ns, _ := cmd.Flags().GetString("namespace"); ns != "" {
+		return "namespace " + ns
+	}
+	return "all namespaces"
+}
+
+func writeNotifyStatus(w io.Writer, sts []ipc.NotifyStatus, now time.Time) {
+	if len(sts) == 0 {
+		_, _ = fmt.Fprintln(w, "no notification rules configured")
+		return
+	}
+	if !sts[0].Enabled {
+		_, _ = fmt.Fprintln(w, "notifications disabled (set notifications.enabled = true)")
+	}
+	if q := sts[0].QuietHours; q != "" {
+		_, _ = fmt.Fprintf(w, "quiet hours: %s\n", q)
+	}
+	for _, st := range sts {
+		state := "active"
+		switch {
+		case st.Muted:
+			state = "muted"
+		case !st.SnoozedUntil.IsZero():
+			state = "snoozed until " + st.SnoozedUntil.Local().Format("2006-01-02 15:04")
+		}
+		every := time.Duration(st.EveryMS) * time.Millisecond
+		_, _ = fmt.Fprintf(w, "namespace=%s every=%dd state=%s\n", st.Namespace, int(every.Hours()/24), state)
+		_, _ = fmt.Fprintf(w, "  last note: %s\n", ago(st.LastNote, now))
+		_, _ = fmt.Fprintf(w, "  last sent: %s\n", ago(st.LastSent, now))
+		switch {
+		case st.Due.IsZero() || !st.Due.After(now):
+			_, _ = fmt.Fprintln(w, "  next:      due now")
+		default:
+			_, _ = fmt.Fprintf(w, "  next:      %s\n", st.Due.Local().Format(time.RFC3339))
+		}
+	}
+}
@@ -20,6 +20,12 @@ type entryStreamWriter interface {
 	Close() error
 }
 
+// hitStreamWriter is implemented by writers that render search hits (score
+// and highlighted snippets) instead of bare entries.
+type hitStreamWriter interface {
+	WriteHits([]api.SearchHit) error
+}
+
 func renderEntries(ctx context.Context, out, errOut io.Writer, entries []api.Entry, opts present.Options) error {
 	if opts.Mode == present.ModeTUI {
 		return present.RenderEntries(ctx, out, entries, opts)
@@ -68,7 +74,7 @@ func newEntryStreamWriter(w io.Writer, opts present.Options) entryStreamWriter {
 	case present.ModeNDJSON:
 		return &ndjsonStreamWriter{nw: format.NewNDJSONStreamWriter(w)}
 	case present.ModePretty:
-		return &plainStreamWriter{pw: format.NewPlainStreamWriter(w, opts.Headers)}
+		return &prettyStreamWriter{plainStreamWriter: plainStreamWriter{pw: format.NewPlainStreamWriter(w, opts.Headers)}, w: w}
 	case present.ModePlain:
 		return &plainStreamWriter{pw: format.NewPlainStreamWriter(w, opts.Headers)}
 	default:
@@ -88,6 +94,21 @@ func (w *plainStreamWriter) Close() error {
 	return w.pw.Close()
 }
 
+func (w *plainStreamWriter) WriteHits(hits []api.SearchHit) error {
+	return w.pw.WriteHits(hits)
+}
+
+// prettyStreamWriter lists entries like plain output and renders search
+// hits as markdown with highlighted matches.
+type prettyStreamWriter struct {
+	plainStreamWriter
+	w io.Writer
+}
+
+func (w *prettyStreamWriter) WriteHits(hits []api.SearchHit) error {
+	return format.WritePrettyHits(w.w, hits)
+}
+
 type jsonStreamWriter struct {
 	jw *format.JSONStreamWriter
 }
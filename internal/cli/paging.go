@@ -41,6 +41,12 @@ func fetchAllEntries(ctx context.Context, sock string, pageSize int, build func(
 }
 
 func streamEntries(ctx context.Context, sock string, pageSize int, build func(cursor string) ipc.Message, writer entryStreamWriter) error {
+	return streamEntriesWith(ctx, sock, pageSize, build, writer, nil)
+}
+
+// streamEntriesWith is streamEntries with a callback that sees each page's
+// response before it is written (e.g. for search suggestions).
+func streamEntriesWith(ctx context.Context, sock string, pageSize int, build func(cursor string) ipc.Message, writer entryStreamWriter, onPage func(ipc.Response)) error {
 	if pageSize <= 0 {
 		pageSize = 200
 	}
@@ -53,10 +59,18 @@ func streamEntries(ctx context.Context, sock string, pageSize int, build func(cu
 		if err != nil {
 			return err
 		}
+		if onPage != nil {
+			onPage(resp)
+		}
 		if len(resp.Entries) == 0 {
 			break
 		}
-		if err := writer.WriteEntries(resp.Entries); err != nil {
+		if hw, ok := writer.(hitStreamWriter); ok && len(resp.Hits) > 0 {
+			err = hw.WriteHits(resp.Hits)
+		} else {
+			err = writer.WriteEntries(resp.Entries)
+		}
+		if err != nil {
 			if isBrokenPipe(err) {
 				return nil
 			}
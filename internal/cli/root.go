@@ -62,8 +62,15 @@ func NewRootCmd() *cobra.Command {
 	cmd.AddCommand(newCompletionCmd())
 	cmd.AddCommand(newConfigCmd())
 	cmd.AddCommand(newImportCmd())
+	cmd.AddCommand(newExportCmd())
 	cmd.AddCommand(newQuicCmd())
 	cmd.AddCommand(newServerCmd())
+	cmd.AddCommand(newSyncCmd())
+	cmd.AddCommand(newViewCmd())
+	cmd.AddCommand(newTagCmd())
+	cmd.AddCommand(newNotifyCmd())
+	cmd.AddCommand(newTodayCmd())
+	cmd.AddCommand(newTemplateCmd())
 
 	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Help() }
 
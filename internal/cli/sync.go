@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/util"
+)
+
+func newSyncCmd() *cobra.Command {
+	var dryRun bool
+	var remote string
+	var limit int
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Run replication and inspect remote health",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !dryRun {
+				if remote != "" {
+					return errors.New("--remote requires --dry-run")
+				}
+				return runSyncNow(cmd, args)
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "sync.plan", Remote: remote, Limit: limit})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				plans := resp.SyncPlan
+				if plans == nil {
+					plans = []ipc.SyncPlan{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(plans)
+			case "plain":
+				writeSyncPlan(cmd.OutOrStdout(), resp.SyncPlan)
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be pushed and applied without writing anything")
+	cmd.Flags().StringVar(&remote, "remote", "", "dry-run a single remote (may be disabled)")
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "max events per remote to list in --dry-run (0 for all)")
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "dry-run output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = cmd.RegisterFlagCompletionFunc("remote", completeRemotes)
+	cmd.AddCommand(newSyncStatusCmd())
+	cmd.AddCommand(newSyncReplayCmd())
+	return cmd
+}
+
+func newSyncReplayCmd() *cobra.Command {
+	var from string
+	var remote string
+	cmd := &cobra.Command{
+		Use:   "replay --from <cursor>",
+		Short: "Re-pull and re-apply remote events from a cursor",
+		Long: `Re-pull events newer than or equal to --from and re-apply them locally, for example
+after fixing a namespace key. Saved sync cursors are not changed. The cursor is an
+RFC3339 timestamp (as shown by "sync status --output json"), a date, or a relative
+expression such as 3d.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ts, err := parseReplayCursor(from)
+			if err != nil {
+				return err
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "sync.replay", Remote: remote, Since: ts.UTC().Format(time.RFC3339Nano)})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "sync: %s\n", resp.Msg)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "cursor to replay from (RFC3339, date or relative)")
+	_ = cmd.MarkFlagRequired("from")
+	cmd.Flags().StringVar(&remote, "remote", "", "only replay this remote")
+	_ = cmd.RegisterFlagCompletionFunc("remote", completeRemotes)
+	return cmd
+}
+
+// parseReplayCursor keeps full precision for RFC3339 cursors and falls back
+// to the shared time-expression parser for dates and relative values.
+func parseReplayCursor(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	norm, _, err := util.NormalizeTimeRange(s, "")
+	if err != nil || norm == "" {
+		return time.Time{}, fmt.Errorf("invalid --from: %q", s)
+	}
+	return time.Parse(time.RFC3339, norm)
+}
+
+// completeRemotes suggests configured remote names.
+func completeRemotes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	app := getApp(cmd)
+	names := make([]string, 0)
+	for name := range app.Cfg.GetStringMap("remotes") {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func writeSyncPlan(w io.Writer, plans []ipc.SyncPlan) {
+	if len(plans) == 0 {
+		_, _ = fmt.Fprintln(w, "no remotes configured")
+		return
+	}
+	for _, p := range plans {
+		_, _ = fmt.Fprintf(w, "remote=%s url=%s\n", p.Name, p.URL)
+		_, _ = fmt.Fprintf(w, "  push: %d events\n", p.Push)
+		_, _ = fmt.Fprintf(w, "  pull: %d create, %d update, %d delete, %d unchanged, %d errors\n", p.Create, p.Update, p.Delete, p.Unchanged, p.Errors)
+		for _, ev := range p.Events {
+			line := fmt.Sprintf("  %s %-9s %-6s %s", ev.Time.UTC().Format(time.RFC3339), ev.Action, ev.Type, ev.ID)
+			if ev.Title != "" {
+				line += fmt.Sprintf(" %q", ev.Title)
+			}
+			if ev.Err != "" {
+				line += " (" + ev.Err + ")"
+			}
+			_, _ = fmt.Fprintln(w, line)
+		}
+		total := p.Push + p.Create + p.Update + p.Delete + p.Unchanged + p.Errors
+		if shown := int64(len(p.Events)); shown < total {
+			_, _ = fmt.Fprintf(w, "  ... (%d more)\n", total-shown)
+		}
+	}
+}
+
+func newSyncStatusCmd() *cobra.Command {
+	var outputMode string
+	var remote string
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show per-remote sync state, counters and backoff",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "sync.status", Remote: remote})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				sts := resp.SyncStatus
+				if sts == nil {
+					sts = []ipc.SyncStatus{}
+				}
+				return enc.Encode(sts)
+			case "plain":
+				writeSyncStatus(cmd.OutOrStdout(), resp.SyncStatus, time.Now())
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&remote, "remote", "", "only show this remote")
+	return cmd
+}
+
+func writeSyncStatus(w io.Writer, sts []ipc.SyncStatus, now time.Time) {
+	if len(sts) == 0 {
+		_, _ = fmt.Fprintln(w, "no remotes configured")
+		return
+	}
+	for _, st := range sts {
+		state := "ok"
+		switch {
+		case st.LastAttempt.IsZero():
+			state = "never synced"
+		case st.Failures > 0:
+			state = fmt.Sprintf("failing (%d consecutive)", st.Failures)
+		}
+		_, _ = fmt.Fprintf(w, "remote=%s url=%s state=%s pending=%d\n", st.Name, st.URL, state, st.Pending)
+		_, _ = fmt.Fprintf(w, "  last success: %s\n", ago(st.LastSuccess, now))
+		if st.LastError != "" {
+			_, _ = fmt.Fprintf(w, "  last error:   %s (%s)\n", st.LastError, ago(st.LastErrorAt, now))
+		}
+		_, _ = fmt.Fprintf(w, "  pushed: %d events, %d bytes\n", st.PushedEvents, st.PushedBytes)
+		_, _ = fmt.Fprintf(w, "  pulled: %d events, %d bytes\n", st.PulledEvents, st.PulledBytes)
+		if st.BackoffMS > 0 {
+			_, _ = fmt.Fprintf(w, "  backoff: %s, next run %s\n", time.Duration(st.BackoffMS)*time.Millisecond, st.NextRun.Local().Format(time.RFC3339))
+		}
+	}
+}
+
+func ago(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	d := now.Sub(t).Truncate(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Local().Format(time.RFC3339), d)
+}
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// newTagCmd defines "tag". Tags may be hierarchical (work/ginkgo/sync);
+// filtering on a tag also matches the tags below it.
+func newTagCmd() *cobra.Command {
+	var nsFlag string
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "List, describe, rename, merge and delete tags",
+	}
+	cmd.AddCommand(newTagListCmd())
+	cmd.AddCommand(newTagDescribeCmd())
+	cmd.AddCommand(newTagRenameCmd())
+	cmd.AddCommand(newTagMergeCmd())
+	cmd.AddCommand(newTagDeleteCmd())
+	cmd.PersistentFlags().StringVarP(&nsFlag, "namespace", "n", "", "override namespace for this command")
+	registerNamespaceCompletion(cmd)
+	return cmd
+}
+
+func newTagListCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tags with note counts and descriptions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "tag.list", Namespace: resolveNamespace(cmd)})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				tags := resp.Tags
+				if tags == nil {
+					tags = []api.TagStat{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(tags)
+			case "plain":
+				if len(resp.Tags) == 0 {
+					_, _ = fmt.Fprintln(cmd.OutOrStdout(), "no tags")
+					return nil
+				}
+				width := 0
+				for _, t := range resp.Tags {
+					width = max(width, len(t.Tag))
+				}
+				for _, t := range resp.Tags {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%-*s  %5d  %s\n", width, t.Tag, t.Count, t.Description)
+				}
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newTagDescribeCmd() *cobra.Command {
+	var clear bool
+	cmd := &cobra.Command{
+		Use:   "describe <tag> [description]",
+		Short: "Set the description shown for a tag in listings and completions",
+		Example: `  ginkgo-cli tag describe work/ginkgo "Notes about the ginkgo project"
+  ginkgo-cli tag describe work/ginkgo --clear`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeTagArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clear == (len(args) == 2) {
+				return errors.New("pass a description or --clear")
+			}
+			desc := ""
+			if len(args) == 2 {
+				desc = args[1]
+			}
+			if _, err := tagRequest(cmd, ipc.Message{Name: "tag.describe", Tags: args[:1], Body: desc}); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "described %s\n", args[0])
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&clear, "clear", false, "remove the description")
+	return cmd
+}
+
+func newTagRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <tag> <new-name>",
+		Short: "Rename a tag and the tags below it on every note",
+		Long: `Rename a tag on every note of the namespace. Child tags move along:
+renaming proj to work/proj turns proj/api into work/proj/api. Notes are
+rewritten as ordinary edits, so the change syncs to other devices. Use
+"tag merge" when the new name is already in use.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeTagArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := tagRequest(cmd, ipc.Message{Name: "tag.rename", Tags: args[:1], Title: args[1]})
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "renamed %s to %s on %d note(s)\n", args[0], args[1], n)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newTagMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "merge <tag>... <into>",
+		Short:             "Merge tags into another tag on every note",
+		Long:              `Replace each tag (and the tags below it) with <into> on every note of the namespace.`,
+		Example:           `  ginkgo-cli tag merge meeting meetings mtg meetings`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeTagArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			into := args[len(args)-1]
+			n, err := tagRequest(cmd, ipc.Message{Name: "tag.merge", Tags: args[:len(args)-1], Title: into})
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "merged into %s on %d note(s)\n", into, n)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newTagDeleteCmd() *cobra.Command {
+	var recursive bool
+	cmd := &cobra.Command{
+		Use:               "delete <tag>...",
+		Short:             "Remove tags from every note",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeTagArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, err := tagRequest(cmd, ipc.Message{Name: "tag.delete", Tags: args, Recursive: recursive})
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "removed %s from %d note(s)\n", strings.Join(args, ", "), n)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "also remove the tags below each tag")
+	return cmd
+}
+
+// tagRequest sends a tag edit and returns the number of notes changed.
+func tagRequest(cmd *cobra.Command, m ipc.Message) (int, error) {
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return 0, err
+	}
+	m.Namespace = resolveNamespace(cmd)
+	resp, err := ipc.Request(cmd.Context(), sock, m)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.OK {
+		return resp.Changed, errors.New(resp.Msg)
+	}
+	return resp.Changed, nil
+}
+
+// completeTagArgs completes existing tags for the first n arguments, or
+// for every argument when n is 0.
+func completeTagArgs(n int) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if n > 0 && len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeTags(cmd, args, toComplete)
+	}
+}
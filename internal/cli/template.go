@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/templates"
+)
+
+const templateLong = `Templates pre-fill new notes. A template is a <name>.md file in the
+templates directory (templates.dir, default templates/ next to the config
+file) or a note tagged with templates.tag whose title is the template's name;
+files win over notes of the same name.
+
+A template uses the editor's format (Title:, Tags:, '---', body) and Go
+text/template syntax with these values:
+
+  {{.Date}} {{.Time}} {{.Weekday}} {{.Now}}   current date and time
+  {{.Namespace}} {{.Hostname}} {{.Name}}      namespace, host, template
+  {{prompt "What did you do?"}}               asked for before the editor opens
+  {{stdin}} {{clipboard}}                     piped input, clipboard contents
+
+Use one with "note add --template <name>".`
+
+func newTemplateCmd() *cobra.Command {
+	var nsFlag string
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "List and show note templates",
+		Long:  templateLong,
+	}
+	cmd.AddCommand(newTemplateListCmd())
+	cmd.AddCommand(newTemplateShowCmd())
+	cmd.PersistentFlags().StringVarP(&nsFlag, "namespace", "n", "", "override namespace for this command")
+	registerNamespaceCompletion(cmd)
+	return cmd
+}
+
+func newTemplateListCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List note templates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ts, err := loadTemplates(cmd)
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				if ts == nil {
+					ts = []templates.Template{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(ts)
+			case "plain":
+				if len(ts) == 0 {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "No templates in %s.\n", templates.Dir(getApp(cmd).Cfg))
+					return nil
+				}
+				for _, t := range ts {
+					_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", t.Name, t.Origin)
+				}
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func newTemplateShowCmd() *cobra.Command {
+	var render bool
+	cmd := &cobra.Command{
+		Use:               "show <name>",
+		Short:             "Print a template's source, or render it",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeTemplateArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := findTemplate(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			if !render {
+				_, _ = io.WriteString(cmd.OutOrStdout(), t.Source)
+				return nil
+			}
+			n, err := renderTemplate(cmd, t, resolveNamespace(cmd))
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Title: %s\nTags: %s\n---\n%s\n", n.Title, strings.Join(n.Tags, ", "), n.Body)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&render, "render", false, "render the template, asking its prompts")
+	return cmd
+}
+
+// loadTemplates returns the template files merged with the namespace's
+// template notes. Note templates are skipped when the daemon is unreachable.
+func loadTemplates(cmd *cobra.Command) ([]templates.Template, error) {
+	app := getApp(cmd)
+	files, err := templates.LoadDir(templates.Dir(app.Cfg))
+	if err != nil {
+		return nil, err
+	}
+	tag := strings.TrimSpace(app.Cfg.GetString("templates.tag"))
+	if tag == "" {
+		return templates.Merge(files), nil
+	}
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "note.list", Namespace: resolveNamespace(cmd), TagsAll: []string{tag}, IncludeBody: true})
+	if err != nil || !resp.OK {
+		return templates.Merge(files), nil
+	}
+	return templates.Merge(files, templates.FromNotes(resp.Entries)), nil
+}
+
+func findTemplate(cmd *cobra.Command, name string) (templates.Template, error) {
+	ts, err := loadTemplates(cmd)
+	if err != nil {
+		return templates.Template{}, err
+	}
+	t, ok := templates.Find(ts, name)
+	if !ok {
+		return templates.Template{}, fmt.Errorf("template not found: %s", name)
+	}
+	return t, nil
+}
+
+// renderTemplate renders t for namespace ns, asking its prompts in a form
+// when stdin is a terminal; otherwise prompts render empty.
+func renderTemplate(cmd *cobra.Command, t templates.Template, ns string) (templates.Note, error) {
+	host, _ := os.Hostname()
+	v := templates.Vars{
+		Name:      t.Name,
+		Namespace: ns,
+		Hostname:  host,
+		Now:       time.Now(),
+		Stdin:     func() (string, error) { return readStdin(cmd) },
+		Clipboard: clipboard.ReadAll,
+	}
+	qs, err := templates.Prompts(t, v)
+	if err != nil {
+		return templates.Note{}, err
+	}
+	answers := map[string]string{}
+	if len(qs) > 0 && term.IsTerminal(os.Stdin.Fd()) {
+		vals := make([]string, len(qs))
+		fields := make([]huh.Field, len(qs))
+		for i, q := range qs {
+			fields[i] = huh.NewInput().Title(q).Value(&vals[i])
+		}
+		if err := huh.NewForm(huh.NewGroup(fields...)).Run(); err != nil {
+			return templates.Note{}, err
+		}
+		for i, q := range qs {
+			answers[q] = vals[i]
+		}
+	}
+	return templates.Render(t, v, answers)
+}
+
+func completeTemplateArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeTemplates(cmd, args, toComplete)
+}
+
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ts, err := loadTemplates(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var out []string
+	for _, t := range ts {
+		if strings.HasPrefix(strings.ToLower(t.Name), strings.ToLower(toComplete)) {
+			out = append(out, t.Name)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/present/format"
+	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// newViewCmd defines "view", named list filters stored in the database and
+// replicated like notes.
+func newViewCmd() *cobra.Command {
+	var nsFlag string
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Save, list and run named note filters",
+	}
+	cmd.AddCommand(newViewSaveCmd())
+	cmd.AddCommand(newViewListCmd())
+	cmd.AddCommand(newViewRunCmd())
+	cmd.AddCommand(newViewDeleteCmd())
+	cmd.PersistentFlags().StringVarP(&nsFlag, "namespace", "n", "", "override namespace for this command")
+	registerNamespaceCompletion(cmd)
+	return cmd
+}
+
+func newViewSaveCmd() *cobra.Command {
+	var filters FilterOpts
+	var queryExpr string
+	var where []string
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save the given filters as a named view (replaces an existing one)",
+		Example: `  ginkgo-cli view save meetings --tags-all work,meeting --since 7d
+  ginkgo-cli view save open -q 'tag:todo -tag:done'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queryExpr, err := whereQuery(queryExpr, where)
+			if err != nil {
+				return err
+			}
+			v := api.View{
+				Name:    strings.TrimSpace(args[0]),
+				Query:   queryExpr,
+				TagsAny: splitCSV(filters.TagsAny),
+				TagsAll: splitCSV(filters.TagsAll),
+				Since:   strings.TrimSpace(filters.Since),
+				Until:   strings.TrimSpace(filters.Until),
+			}
+			if v.Name == "" {
+				return errors.New("view name is required")
+			}
+			if v.Query == "" && len(v.TagsAny) == 0 && len(v.TagsAll) == 0 && v.Since == "" && v.Until == "" {
+				return errors.New("nothing to save: pass --query or filter flags")
+			}
+			if v.Query != "" {
+				if err := checkQuery(v.Query); err != nil {
+					return err
+				}
+			}
+			// Validate now; the relative form is kept and resolved on each run.
+			if _, _, err := util.NormalizeTimeRange(v.Since, v.Until); err != nil {
+				return err
+			}
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{
+				Name:      "view.save",
+				Namespace: resolveNamespace(cmd),
+				Title:     v.Name,
+				Query:     v.Query,
+				TagsAny:   v.TagsAny,
+				TagsAll:   v.TagsAll,
+				Since:     v.Since,
+				Until:     v.Until,
+			})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return errors.New(resp.Msg)
+			}
+			if len(resp.Views) > 0 {
+				v = resp.Views[0]
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "saved view %s: %s\n", v.Name, format.ViewSummary(v))
+			return nil
+		},
+	}
+	addFilterFlags(cmd, &filters)
+	cmd.Flags().StringVarP(&queryExpr, "query", "q", "", "search query to save, e.g. 'tag:work -tag:draft'")
+	addWhereFlag(cmd, &where)
+	return cmd
+}
+
+func newViewListCmd() *cobra.Command {
+	var outputMode string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved views",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			views, err := fetchViews(cmd, "")
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(outputMode) {
+			case "json":
+				if views == nil {
+					views = []api.View{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(views)
+			case "plain":
+				writeViews(cmd.OutOrStdout(), views)
+				return nil
+			default:
+				return fmt.Errorf("invalid --output: %s", outputMode)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&outputMode, "output", "plain", "output mode: plain|json")
+	_ = cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"plain", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	return cmd
+}
+
+func writeViews(w io.Writer, views []api.View) {
+	if len(views) == 0 {
+		_, _ = fmt.Fprintln(w, "no saved views")
+		return
+	}
+	width := 0
+	for _, v := range views {
+		width = max(width, len(v.Name))
+	}
+	for _, v := range views {
+		_, _ = fmt.Fprintf(w, "%-*s  %s\n", width, v.Name, format.ViewSummary(v))
+	}
+}
+
+func newViewRunCmd() *cobra.Command {
+	var o listOpts
+	cmd := &cobra.Command{
+		Use:               "run <name>",
+		Short:             "List notes matching a saved view (same as note list --view)",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeViews,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			o.view = args[0]
+			return runList(cmd, &o)
+		},
+	}
+	addListFlags(cmd, &o)
+	return cmd
+}
+
+func newViewDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete <name>",
+		Short:             "Delete a saved view",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeViews,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sock, err := ipc.SocketPath()
+			if err != nil {
+				return err
+			}
+			resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "view.delete", Namespace: resolveNamespace(cmd), Title: args[0]})
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return fmt.Errorf("view %s: %s", args[0], resp.Msg)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "deleted view %s\n", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
+
+// fetchViews lists the namespace's views, or fetches one by name.
+func fetchViews(cmd *cobra.Command, name string) ([]api.View, error) {
+	sock, err := ipc.SocketPath()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ipc.Request(cmd.Context(), sock, ipc.Message{Name: "view.list", Namespace: resolveNamespace(cmd), Title: name})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		if name != "" {
+			return nil, fmt.Errorf("view %s: %s", name, resp.Msg)
+		}
+		return nil, errors.New(resp.Msg)
+	}
+	return resp.Views, nil
+}
+
+// applyView fills the list options from the saved view o.view. Flags given
+// on the command line take precedence over the view's fields.
+func applyView(cmd *cobra.Command, o *listOpts) error {
+	views, err := fetchViews(cmd, o.view)
+	if err != nil {
+		return err
+	}
+	if len(views) == 0 {
+		return fmt.Errorf("view %s: not found", o.view)
+	}
+	v := views[0]
+	if o.queryExpr == "" {
+		o.queryExpr = v.Query
+	}
+	if o.filters.TagsAny == "" {
+		o.filters.TagsAny = strings.Join(v.TagsAny, ",")
+	}
+	if o.filters.TagsAll == "" {
+		o.filters.TagsAll = strings.Join(v.TagsAll, ",")
+	}
+	if o.filters.Since == "" {
+		o.filters.Since = v.Since
+	}
+	if o.filters.Until == "" {
+		o.filters.Until = v.Until
+	}
+	o.view = v.Name
+	return nil
+}
+
+// completeViews completes view names for --view and for the single name
+// argument of view run/delete.
+func completeViews(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 && cmd.Flags().Lookup("view") == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	views, err := fetchViews(cmd, "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]string, len(views))
+	for i, v := range views {
+		names[i] = v.Name
+	}
+	return util.ScoreCompletions(toComplete, names, 0), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,131 @@
+// Package compress implements the content codings used by replication
+// requests and responses (zstd, gzip or identity).
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	Zstd     = "zstd"
+	Gzip     = "gzip"
+	Identity = "identity"
+)
+
+// Supported lists the codings this build can encode and decode, in order of preference.
+var Supported = []string{Zstd, Gzip}
+
+// AcceptHeader is the value advertised in Accept-Encoding headers.
+func AcceptHeader() string { return strings.Join(Supported, ", ") }
+
+// Normalize maps a configured or header-provided coding to a known value.
+// Empty and "none" map to Identity; unknown codings are returned lowercased.
+func Normalize(enc string) string {
+	enc = strings.ToLower(strings.TrimSpace(enc))
+	switch enc {
+	case "", "none", Identity:
+		return Identity
+	default:
+		return enc
+	}
+}
+
+// IsSupported reports whether enc can be decoded by this build.
+func IsSupported(enc string) bool {
+	enc = Normalize(enc)
+	if enc == Identity {
+		return true
+	}
+	for _, s := range Supported {
+		if s == enc {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the preferred coding from an Accept-Encoding header value.
+// Quality values are honoured only to exclude codings (q=0).
+func Negotiate(accept string) string {
+	offered := map[string]bool{}
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" {
+			continue
+		}
+		disabled := false
+		for _, p := range fields[1:] {
+			if strings.ReplaceAll(strings.TrimSpace(p), " ", "") == "q=0" {
+				disabled = true
+			}
+		}
+		offered[name] = !disabled
+	}
+	for _, s := range Supported {
+		if offered[s] {
+			return s
+		}
+	}
+	return Identity
+}
+
+// Encode compresses b with the given coding.
+func Encode(enc string, b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter(enc, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(b); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NewWriter wraps w with an encoder for enc. Close must be called to flush.
+func NewWriter(enc string, w io.Writer) (io.WriteCloser, error) {
+	switch Normalize(enc) {
+	case Identity:
+		return nopWriteCloser{w}, nil
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", enc)
+	}
+}
+
+// NewReader wraps r with a decoder for enc. Close releases decoder resources
+// but does not close r.
+func NewReader(enc string, r io.Reader) (io.ReadCloser, error) {
+	switch Normalize(enc) {
+	case Identity:
+		return io.NopCloser(r), nil
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", enc)
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
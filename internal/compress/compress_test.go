@@ -0,0 +1,57 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("ginkgo replication "), 64)
+	for _, enc := range []string{Zstd, Gzip, Identity} {
+		b, err := Encode(enc, payload)
+		if err != nil {
+			t.Fatalf("%s encode: %v", enc, err)
+		}
+		r, err := NewReader(enc, bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("%s reader: %v", enc, err)
+		}
+		got, err := io.ReadAll(r)
+		_ = r.Close()
+		if err != nil {
+			t.Fatalf("%s decode: %v", enc, err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Fatalf("%s round trip mismatch", enc)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"":               Identity,
+		"gzip":           Gzip,
+		"gzip, zstd":     Zstd,
+		"zstd;q=0, gzip": Gzip,
+		"br, deflate":    Identity,
+		" ZSTD ; q=1.0 ": Zstd,
+	}
+	for in, want := range cases {
+		if got := Negotiate(in); got != want {
+			t.Fatalf("Negotiate(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	if IsSupported("br") {
+		t.Fatalf("br should not be supported")
+	}
+	if !IsSupported("none") {
+		t.Fatalf("none should map to identity")
+	}
+	if _, err := NewReader("br", bytes.NewReader(nil)); err == nil {
+		t.Fatalf("expected error for unsupported coding")
+	}
+}
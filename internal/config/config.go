@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -116,14 +117,37 @@ func GetConfigOptions() []ConfigOption {
 		{Key: "http_addr", Default: ":8080", Comment: "HTTP listen address for daemon/replication server"},
 		{Key: "auth.token", Default: "", Comment: "Shared token required by replication server"},
 		{Key: "sync.batch_size", Default: 256, Comment: "Batch size for remote sync operations"},
+		{Key: "sync.compression", Default: "auto", Comment: "Replication body compression: auto|zstd|gzip|none (override per remote with remotes.<name>.compression)"},
+		{Key: "server.max_body_bytes", Default: 32 << 20, Comment: "Replication server cap on push body size in bytes (compressed and decompressed)"},
+		{Key: "server.max_event_bytes", Default: 4 << 20, Comment: "Replication server cap on a single event payload in bytes"},
+		{Key: "server.max_pull_limit", Default: 2000, Comment: "Replication server cap on events per paged pull response (the client's limit is clamped to it)"},
+		{Key: "server.rate_limit.rps", Default: 10.0, Comment: "Replication requests per second allowed per token (0 disables rate limiting)"},
+		{Key: "server.rate_limit.burst", Default: 40, Comment: "Replication request burst allowed per token"},
+		{Key: "server.quota.max_events", Default: 0, Comment: "Default per-namespace stored event cap (0 = unlimited; override with namespaces.<name>.max_events)"},
+		{Key: "server.quota.max_bytes", Default: 0, Comment: "Default per-namespace stored payload bytes cap (0 = unlimited; override with namespaces.<name>.max_bytes)"},
+		{Key: "metrics.enabled", Default: false, Comment: "Expose Prometheus metrics on /metrics (daemon http_addr and replication server)"},
 		{Key: "remotes", Default: map[string]any{}, Comment: "Named remotes: [remotes.<name>] url/token/enabled"},
-		{Key: "namespaces", Default: map[string]any{}, Comment: "Per-namespace settings: [namespaces.<name>] e2ee/key_provider/key_id/read_key/write_key/signer_key_provider/signer_key_id/origin_label/trusted_signers"},
+		{Key: "namespaces", Default: map[string]any{}, Comment: "Per-namespace settings: [namespaces.<name>] e2ee/key_provider/key_id/read_key/write_key/signer_key_provider/signer_key_id/origin_label/trusted_signers/max_events/max_bytes/notify_every_days"},
 		{Key: "export.page_size", Default: 200, Comment: "Batch size for list/search export paging"},
 		{Key: "tui.buffer_ratio", Default: 2.0, Comment: "TUI paging buffer ratio; increases the safe window before refetch (0.4-4)"},
 
-		{Key: "notifications.enabled", Default: false, Comment: "Enable reminder notifications"},
-		{Key: "notifications.every_days", Default: 3, Comment: "Reminder cadence in days"},
-		{Key: "editor.delete_empty", Default: true, Comment: "Delete note if editor exits with no content"},
+		{Key: "notifications.enabled", Default: false, Comment: "Enable nudges for idle namespaces (note reminders fire regardless)"},
+		{Key: "notifications.every_days", Default: 3, Comment: "Nudge when the default namespace has no new note for this many days (per namespace: namespaces.<name>.notify_every_days)"},
+		{Key: "notifications.quiet_hours", Default: "", Comment: "Local time window without notifications, e.g. 22:00-08:00"},
+		{Key: "notifications.sinks", Default: []string{"desktop", "log"}, Comment: "Notification delivery: desktop|log|webhook"},
+		{Key: "notifications.command", Default: "notify-send", Comment: "Desktop notification command; title and body are appended"},
+		{Key: "notifications.webhook_url", Default: "", Comment: "URL receiving notifications as JSON POSTs (webhook sink)"},
+		{Key: "notifications.device", Default: "", Comment: "Device name reminders are fired on (default: namespaces.<name>.origin_label, else the hostname)"},
+
+		{Key: "editor.snapshot_interval", Default: "15s", Comment: "How often the daemon snapshots open editor drafts (0 disables)"},
+		{Key: "editor.format", Default: "headers", Comment: "Editor file format: headers|frontmatter (YAML, as Markdown tools write it)"},
+
+		{Key: "daily.title", Default: "2006-01-02 Monday", Comment: "Daily note title as a Go time layout"},
+		{Key: "daily.prompts", Default: []string{"What did I ship?", "Blockers"}, Comment: "Headings pre-filled in a new daily note"},
+		{Key: "daily.tags", Default: []string{"daily"}, Comment: "Tags of new daily notes"},
+
+		{Key: "templates.dir", Default: "", Comment: "Directory of <name>.md note templates (default: templates/ next to the config file)"},
+		{Key: "templates.tag", Default: "template", Comment: "Notes with this tag are templates named by their title"},
 	}
 }
 
@@ -158,9 +182,45 @@ func CheckConfigValidity(v *viper.Viper) error {
 	if v.GetInt("sync.batch_size") <= 0 {
 		issues = append(issues, "sync.batch_size must be greater than 0")
 	}
+	switch strings.ToLower(strings.TrimSpace(v.GetString("editor.format"))) {
+	case "", "headers", "frontmatter":
+	default:
+		issues = append(issues, fmt.Sprintf("editor.format has unsupported value %q", v.GetString("editor.format")))
+	}
+	switch strings.ToLower(strings.TrimSpace(v.GetString("sync.compression"))) {
+	case "", "auto", "zstd", "gzip", "none":
+	default:
+		issues = append(issues, fmt.Sprintf("sync.compression has unsupported value %q", v.GetString("sync.compression")))
+	}
+	if v.IsSet("server.max_body_bytes") && v.GetInt64("server.max_body_bytes") <= 0 {
+		issues = append(issues, "server.max_body_bytes must be greater than 0")
+	}
+	if v.IsSet("server.max_pull_limit") && v.GetInt("server.max_pull_limit") <= 0 {
+		issues = append(issues, "server.max_pull_limit must be greater than 0")
+	}
+	if v.GetFloat64("server.rate_limit.rps") < 0 {
+		issues = append(issues, "server.rate_limit.rps must not be negative")
+	}
+	if v.GetInt64("server.quota.max_events") < 0 || v.GetInt64("server.quota.max_bytes") < 0 {
+		issues = append(issues, "server.quota limits must not be negative")
+	}
 	if v.GetBool("notifications.enabled") && v.GetInt("notifications.every_days") <= 0 {
 		issues = append(issues, "notifications.every_days must be greater than 0")
 	}
+	if q := strings.TrimSpace(v.GetString("notifications.quiet_hours")); q != "" && !validQuietHours(q) {
+		issues = append(issues, fmt.Sprintf("notifications.quiet_hours %q must look like 22:00-08:00", q))
+	}
+	for _, sink := range v.GetStringSlice("notifications.sinks") {
+		switch strings.ToLower(strings.TrimSpace(sink)) {
+		case "desktop", "log":
+		case "webhook":
+			if strings.TrimSpace(v.GetString("notifications.webhook_url")) == "" {
+				issues = append(issues, "notifications.webhook_url is required for the webhook sink")
+			}
+		default:
+			issues = append(issues, fmt.Sprintf("notifications.sinks has unsupported value %q", sink))
+		}
+	}
 
 	remotes := v.GetStringMap("remotes")
 	for name := range remotes {
@@ -246,6 +306,19 @@ func CheckConfigValidity(v *viper.Viper) error {
 	return fmt.Errorf("config validation failed:\n- %s", strings.Join(issues, "\n- "))
 }
 
+func validQuietHours(value string) bool {
+	from, to, ok := strings.Cut(value, "-")
+	if !ok {
+		return false
+	}
+	for _, part := range []string{from, to} {
+		if _, err := time.Parse("15:04", strings.TrimSpace(part)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func validBase64Key(value string) bool {
 	_, err := base64.StdEncoding.DecodeString(value)
 	return err == nil
@@ -26,8 +26,14 @@ func TestCheckConfigValidityInvalid(t *testing.T) {
 	v.Set("data_dir", "")
 	v.Set("export.page_size", 0)
 	v.Set("sync.batch_size", 0)
+	v.Set("sync.compression", "brotli")
+	v.Set("server.max_body_bytes", -1)
+	v.Set("server.max_pull_limit", 0)
+	v.Set("server.rate_limit.rps", -1)
 	v.Set("notifications.enabled", true)
 	v.Set("notifications.every_days", 0)
+	v.Set("notifications.quiet_hours", "late")
+	v.Set("notifications.sinks", []string{"log", "pager"})
 	v.Set("remotes.origin.url", "not a url")
 	v.Set("remotes.origin.token", "")
 	v.Set("remotes.origin.enabled", true)
@@ -47,7 +53,13 @@ func TestCheckConfigValidityInvalid(t *testing.T) {
 		"data_dir is required",
 		"export.page_size must be greater than 0",
 		"sync.batch_size must be greater than 0",
+		"sync.compression has unsupported value",
+		"server.max_body_bytes must be greater than 0",
+		"server.max_pull_limit must be greater than 0",
+		"server.rate_limit.rps must not be negative",
 		"notifications.every_days must be greater than 0",
+		"notifications.quiet_hours \"late\" must look like 22:00-08:00",
+		"notifications.sinks has unsupported value \"pager\"",
 		"remote origin has invalid url",
 		"remote origin missing token",
 		"namespace work missing write_key",
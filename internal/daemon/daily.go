@@ -0,0 +1,46 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/db"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// maxDailyAttempts bounds the retries of appendDaily when the note changes
+// or appears between reading and writing it.
+const maxDailyAttempts = 3
+
+// appendDaily appends section to the daily note tmpl.ID, creating it from
+// tmpl (title, tags, namespace and, when set, creation time) when missing.
+// It reports whether the note was created.
+func appendDaily(ctx context.Context, store *db.Store, tmpl api.Entry, section string) (api.Entry, bool, error) {
+	var err error
+	for range maxDailyAttempts {
+		now := time.Now().UTC()
+		var cur api.Entry
+		cur, err = store.Entries.GetEntry(ctx, tmpl.ID)
+		if err == db.ErrNotFound {
+			e := tmpl
+			e.Version, e.Body, e.UpdatedAt = 1, section, now
+			if e.CreatedAt.IsZero() {
+				e.CreatedAt = now
+			}
+			if e, err = store.Entries.CreateEntry(ctx, e); err == nil {
+				return e, true, nil
+			}
+		} else if err == nil {
+			ifv := cur.Version
+			cur.Body = api.AppendSection(cur.Body, section)
+			cur.Version, cur.UpdatedAt = cur.Version+1, now
+			if cur, err = store.Entries.UpdateEntryCAS(ctx, cur, ifv); err == nil {
+				return cur, false, nil
+			}
+		}
+		if err != db.ErrConflict {
+			return api.Entry{}, false, err
+		}
+	}
+	return api.Entry{}, false, err
+}
@@ -0,0 +1,56 @@
+package daemon
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/db"
+)
+
+// snapshotDrafts copies the editor files of open drafts into the store
+// every interval, so that an edit survives its file: the runtime directory
+// holding it is gone after a reboot.
+func snapshotDrafts(ctx context.Context, store *db.Store, every time.Duration) {
+	if every <= 0 {
+		return
+	}
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if n := snapshotOnce(ctx, store); n > 0 {
+				log.Printf("snapshotted drafts count=%d", n)
+			}
+		}
+	}
+}
+
+// snapshotOnce stores the content of every draft file that changed since
+// its last snapshot and returns how many did. A missing file keeps the
+// snapshot it had.
+func snapshotOnce(ctx context.Context, store *db.Store) int {
+	ds, err := store.Drafts.ListDrafts(ctx, "")
+	if err != nil {
+		log.Printf("list drafts err=%v", err)
+		return 0
+	}
+	n := 0
+	for _, d := range ds {
+		b, err := os.ReadFile(d.Path)
+		if err != nil || string(b) == d.Content {
+			continue
+		}
+		d.Content, d.UpdatedAt = string(b), time.Now().UTC()
+		if _, err := store.Drafts.SaveDraft(ctx, d); err != nil {
+			log.Printf("snapshot draft id=%s err=%v", d.ID, err)
+			continue
+		}
+		n++
+	}
+	return n
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/mithrel/ginkgo/internal/db"
 	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/internal/ipc/transport"
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/internal/notify"
 	"github.com/mithrel/ginkgo/internal/wire"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
@@ -25,6 +27,9 @@ func Run(ctx context.Context, app *wire.App) error {
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("ok"))
 	})
+	if app.Cfg.GetBool("metrics.enabled") {
+		mux.Handle("/metrics", metrics.Handler())
+	}
 	addr := app.Cfg.GetString("http_addr")
 	if strings.TrimSpace(addr) == "" {
 		addr = ":7465"
@@ -40,8 +45,14 @@ func Run(ctx context.Context, app *wire.App) error {
 	defer cancel()
 	// Start continuous background sync loop
 	go app.Syncer.RunBackground(ctx)
+	notifier, err := notify.New(app.Cfg, app.Store.Entries, app.Store.Reminders)
+	if err != nil {
+		return err
+	}
+	go notifier.Run(ctx)
+	go snapshotDrafts(ctx, app.Store, app.Cfg.GetDuration("editor.snapshot_interval"))
 	// Adapt CLI message handler to protobuf transport
-	handler := ipc.PBHandler(func(m ipc.Message) ipc.Response {
+	handler := ipc.PBHandler(instrumentIPC(func(m ipc.Message) ipc.Response {
 		ns := m.Namespace
 		if ns == "" {
 			ns = app.Cfg.GetString("namespace")
@@ -50,16 +61,52 @@ func Run(ctx context.Context, app *wire.App) error {
 		case "note.add", "note.edit":
 			// Create if no ID, otherwise CAS update.
 			now := time.Now().UTC()
+			fields, err := api.NormalizeFields(m.Fields)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
 			if m.ID == "" {
 				tags := normalizeTags(m.Tags)
-				e := api.Entry{ID: api.NewID(), Version: 1, Title: m.Title, Body: m.Body, Tags: tags, CreatedAt: now, UpdatedAt: now, Namespace: ns}
-				e, err := app.Store.Entries.CreateEntry(ctx, e)
+				e := api.Entry{ID: api.NewID(), Version: 1, Title: m.Title, Body: m.Body, Tags: tags, Fields: fields, CreatedAt: now, UpdatedAt: now, Namespace: ns}
+				// Backdated notes and imports carry their own timestamps.
+				if !m.CreatedAt.IsZero() {
+					e.CreatedAt = m.CreatedAt.UTC()
+				}
+				if !m.UpdatedAt.IsZero() {
+					e.UpdatedAt = m.UpdatedAt.UTC()
+				}
+				dups, err := app.Store.Entries.FindDuplicates(ctx, e)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				if len(dups) > 0 {
+					switch m.Dedupe {
+					case "skip":
+						log.Printf("skipped duplicate of id=%s title=%q", dups[0].Entry.ID, e.Title)
+						return ipc.Response{OK: true, Msg: "duplicate", Entry: &dups[0].Entry, Duplicates: dups}
+					case "merge":
+						cur := dups[0].Entry
+						merged := db.MergeContent(cur, e)
+						merged.Version, merged.UpdatedAt = cur.Version+1, now
+						out, err := app.Store.Entries.UpdateEntryCAS(ctx, merged, cur.Version)
+						if err != nil {
+							return ipc.Response{OK: false, Msg: err.Error()}
+						}
+						log.Printf("merged duplicate into id=%s title=%q", out.ID, out.Title)
+						go app.Syncer.SyncNow(ctx)
+						return ipc.Response{OK: true, Msg: "merged", Entry: &out, Duplicates: dups}
+					case "", "keep":
+					default:
+						return ipc.Response{OK: false, Msg: "invalid dedupe: " + m.Dedupe}
+					}
+				}
+				e, err = app.Store.Entries.CreateEntry(ctx, e)
 				if err != nil {
 					return ipc.Response{OK: false, Msg: err.Error()}
 				}
 				log.Printf("created note id=%s title=%q", e.ID, e.Title)
 				go app.Syncer.SyncNow(ctx)
-				return ipc.Response{OK: true, Entry: &e}
+				return ipc.Response{OK: true, Entry: &e, Duplicates: dups, Links: unresolvedLinks(ctx, app.Store, e.ID)}
 			}
 			// Update path
 			cur, err := app.Store.Entries.GetEntry(ctx, m.ID)
@@ -78,6 +125,12 @@ func Run(ctx context.Context, app *wire.App) error {
 			if m.Tags != nil {
 				cur.Tags = normalizeTags(m.Tags)
 			}
+			if m.SetFields {
+				cur.Fields = fields
+			}
+			if !m.CreatedAt.IsZero() {
+				cur.CreatedAt = m.CreatedAt.UTC()
+			}
 			cur.UpdatedAt = now
 			ifv := m.IfVersion
 			if ifv == 0 {
@@ -95,7 +148,9 @@ func Run(ctx context.Context, app *wire.App) error {
 			}
 			log.Printf("updated note id=%s title=%q", e.ID, e.Title)
 			go app.Syncer.SyncNow(ctx)
-			return ipc.Response{OK: true, Entry: &e}
+			// Best effort: a failed lookup should not fail a saved edit.
+			dups, _ := app.Store.Entries.FindDuplicates(ctx, e)
+			return ipc.Response{OK: true, Entry: &e, Duplicates: dups, Links: unresolvedLinks(ctx, app.Store, e.ID)}
 		case "note.delete":
 			if m.ID == "" {
 				return ipc.Response{OK: false, Msg: "missing id"}
@@ -117,6 +172,148 @@ func Run(ctx context.Context, app *wire.App) error {
 				return ipc.Response{OK: false, Msg: err.Error()}
 			}
 			log.Printf("show note id=%s", m.ID)
+			resp := ipc.Response{OK: true, Entry: &e}
+			// Editors prefill the Remind header from the note's reminder.
+			if r, err := app.Store.Reminders.GetReminder(ctx, e.ID); err == nil {
+				resp.Reminders = []api.Reminder{r}
+			}
+			return resp
+		case "note.links", "note.backlinks":
+			if m.ID == "" {
+				return ipc.Response{OK: false, Msg: "missing id"}
+			}
+			if m.Name == "note.links" {
+				links, err := app.Store.Entries.Links(ctx, m.ID)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				log.Printf("links id=%s count=%d", m.ID, len(links))
+				return ipc.Response{OK: true, Links: links}
+			}
+			entries, err := app.Store.Entries.Backlinks(ctx, m.ID)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("backlinks id=%s count=%d", m.ID, len(entries))
+			return ipc.Response{OK: true, Entries: entries}
+		case "note.remind":
+			if m.ID == "" {
+				return ipc.Response{OK: false, Msg: "missing id"}
+			}
+			e, err := app.Store.Entries.GetEntry(ctx, m.ID)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			if e.Namespace != ns {
+				return ipc.Response{OK: false, Msg: "not found"}
+			}
+			if m.At == "" {
+				if err := app.Store.Reminders.DeleteReminder(ctx, e.ID); err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				log.Printf("cleared reminder id=%s", e.ID)
+				go app.Syncer.SyncNow(ctx)
+				return ipc.Response{OK: true}
+			}
+			at, err := time.Parse(time.RFC3339, m.At)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: "invalid at: " + m.At}
+			}
+			device := m.Device
+			if device == "" {
+				device = notifier.DeviceFor(e.Namespace)
+			}
+			if device == "" {
+				return ipc.Response{OK: false, Msg: "no device to fire the reminder on; set notifications.device"}
+			}
+			r, err := app.Store.Reminders.SetReminder(ctx, api.Reminder{NoteID: e.ID, At: at.UTC(), Device: device})
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			r.Title = e.Title
+			log.Printf("set reminder id=%s at=%s device=%q", e.ID, r.At.Format(time.RFC3339), device)
+			notifier.Wake()
+			go app.Syncer.SyncNow(ctx)
+			return ipc.Response{OK: true, Reminders: []api.Reminder{r}}
+		case "note.reminders":
+			rs, err := app.Store.Reminders.ListReminders(ctx, ns, m.All)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			return ipc.Response{OK: true, Reminders: rs}
+		case "note.daily":
+			day := time.Now()
+			if m.Date != "" {
+				var err error
+				if day, err = time.ParseInLocation("2006-01-02", m.Date, time.Local); err != nil {
+					return ipc.Response{OK: false, Msg: "invalid date: " + m.Date}
+				}
+			}
+			id := api.DailyID(ns, day)
+			if m.Body == "" {
+				e, err := app.Store.Entries.GetEntry(ctx, id)
+				switch err {
+				case nil:
+					return ipc.Response{OK: true, Entry: &e}
+				case db.ErrNotFound:
+					return ipc.Response{OK: true}
+				default:
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+			}
+			title := m.Title
+			if title == "" {
+				title = day.Format("2006-01-02")
+			}
+			// A note for another day is created on that day at the current
+			// time of day, so it sorts with the day it belongs to.
+			now := time.Now()
+			createdAt := time.Date(day.Year(), day.Month(), day.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), time.Local).UTC()
+			e, created, err := appendDaily(ctx, app.Store, api.Entry{ID: id, Title: title, Tags: normalizeTags(m.Tags), Namespace: ns, CreatedAt: createdAt}, m.Body)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("daily note id=%s created=%t", e.ID, created)
+			go app.Syncer.SyncNow(ctx)
+			resp := ipc.Response{OK: true, Entry: &e, Links: unresolvedLinks(ctx, app.Store, e.ID)}
+			if created {
+				resp.Msg = "created"
+			}
+			return resp
+		case "note.related":
+			if m.ID == "" {
+				return ipc.Response{OK: false, Msg: "missing id"}
+			}
+			hits, err := app.Store.Entries.Related(ctx, m.ID, m.Limit)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("related notes id=%s count=%d", m.ID, len(hits))
+			return ipc.Response{OK: true, Hits: hits}
+		case "note.dupes":
+			clusters, err := app.Store.Entries.DuplicateClusters(ctx, ns)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("duplicate clusters ns=%s count=%d", ns, len(clusters))
+			return ipc.Response{OK: true, Clusters: clusters}
+		case "note.merge":
+			if m.ID == "" || len(m.Args) == 0 {
+				return ipc.Response{OK: false, Msg: "missing ids"}
+			}
+			keep, err := app.Store.Entries.GetEntry(ctx, m.ID)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			if keep.Namespace != ns {
+				return ipc.Response{OK: false, Msg: "not found"}
+			}
+			e, err := app.Store.Entries.MergeEntries(ctx, m.ID, m.Args)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("merged notes into id=%s count=%d", e.ID, len(m.Args))
+			go app.Syncer.SyncNow(ctx)
 			return ipc.Response{OK: true, Entry: &e}
 		case "note.list":
 			log.Printf("list notes")
@@ -134,16 +331,36 @@ func Run(ctx context.Context, app *wire.App) error {
 				Cursor:      m.Cursor,
 				Reverse:     m.Reverse,
 				IncludeBody: m.IncludeBody,
+				Query:       m.Query,
+				Sort:        m.Sort,
 			})
 			if err != nil {
 				return ipc.Response{OK: false, Msg: err.Error()}
 			}
 			log.Printf("list notes count=%d", len(entries))
 			return ipc.Response{OK: true, Entries: entries, Page: page}
-		case "note.search.fts":
-			q := strings.ToLower(strings.TrimSpace(m.Title))
+		case "note.search.fts", "note.search.fuzzy":
+			// The query language is parsed by the store; operators such as
+			// OR are case-sensitive, so the query is passed through as typed.
+			q := strings.TrimSpace(m.Title)
 			since, until := parseBounds(m.Since, m.Until)
-			entries, page, err := app.Store.Entries.Search(ctx, api.SearchQuery{
+			switch m.SortBy {
+			case "", api.SortCreated, api.SortRelevance:
+			default:
+				return ipc.Response{OK: false, Msg: fmt.Sprintf("unknown sort %q (want created|relevance)", m.SortBy)}
+			}
+			var corrected string
+			var suggestions []api.TermSuggestion
+			if m.Name == "note.search.fuzzy" {
+				fixed, sugg, err := app.Store.Entries.CorrectQuery(ctx, q)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				if fixed != q {
+					q, corrected, suggestions = fixed, fixed, sugg
+				}
+			}
+			hits, page, err := app.Store.Entries.SearchHits(ctx, api.SearchQuery{
 				Namespace: ns,
 				Query:     q,
 				Regex:     false,
@@ -154,11 +371,12 @@ func Run(ctx context.Context, app *wire.App) error {
 				Limit:     m.Limit,
 				Cursor:    m.Cursor,
 				Reverse:   m.Reverse,
+				SortBy:    m.SortBy,
 			})
 			if err != nil {
 				return ipc.Response{OK: false, Msg: err.Error()}
 			}
-			return ipc.Response{OK: true, Entries: entries, Page: page}
+			return ipc.Response{OK: true, Hits: hits, Page: page, Corrected: corrected, Suggestions: suggestions}
 		case "note.search.regex":
 			pattern := m.Title
 			since, until := parseBounds(m.Since, m.Until)
@@ -199,6 +417,101 @@ func Run(ctx context.Context, app *wire.App) error {
 				out = append(out, r)
 			}
 			return ipc.Response{OK: true, Queue: out}
+		case "sync.status":
+			sts, err := app.Syncer.Status(ctx, m.Remote)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			out := make([]ipc.SyncStatus, 0, len(sts))
+			for _, st := range sts {
+				out = append(out, ipc.SyncStatus{
+					Name:         st.Name,
+					URL:          st.URL,
+					LastAttempt:  st.LastAttempt,
+					LastSuccess:  st.LastSuccess,
+					LastError:    st.LastError,
+					LastErrorAt:  st.LastErrorAt,
+					Failures:     int64(st.Failures),
+					PushedEvents: st.PushedEvents,
+					PulledEvents: st.PulledEvents,
+					PushedBytes:  st.PushedBytes,
+					PulledBytes:  st.PulledBytes,
+					Pending:      int64(st.Pending),
+					BackoffMS:    st.Backoff.Milliseconds(),
+					NextRun:      st.NextRun,
+				})
+			}
+			return ipc.Response{OK: true, SyncStatus: out}
+		case "notify.status", "notify.snooze", "notify.mute", "notify.unmute":
+			// Namespace "" addresses every namespace.
+			var err error
+			switch m.Name {
+			case "notify.snooze":
+				var until time.Time
+				if m.Until != "" {
+					if until, err = time.Parse(time.RFC3339, m.Until); err != nil {
+						return ipc.Response{OK: false, Msg: "invalid until: " + m.Until}
+					}
+				}
+				err = notifier.Snooze(m.Namespace, until)
+			case "notify.mute", "notify.unmute":
+				err = notifier.Mute(m.Namespace, m.Name == "notify.mute")
+			}
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			sts, err := notifier.Status(ctx, time.Now())
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			out := make([]ipc.NotifyStatus, 0, len(sts))
+			for _, st := range sts {
+				out = append(out, ipc.NotifyStatus{
+					Namespace:    st.Namespace,
+					EveryMS:      st.Every.Milliseconds(),
+					LastNote:     st.LastNote,
+					LastSent:     st.LastSent,
+					Due:          st.Due,
+					SnoozedUntil: st.SnoozedUntil,
+					Muted:        st.Muted,
+					Enabled:      notifier.Enabled,
+					QuietHours:   notifier.Quiet.String(),
+				})
+			}
+			return ipc.Response{OK: true, NotifyStatus: out}
+		case "sync.plan":
+			plans, err := app.Syncer.Plan(ctx, m.Remote, m.Limit)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			out := make([]ipc.SyncPlan, 0, len(plans))
+			for _, p := range plans {
+				sp := ipc.SyncPlan{
+					Name:      p.Name,
+					URL:       p.URL,
+					Push:      int64(p.Push),
+					Create:    int64(p.Create),
+					Update:    int64(p.Update),
+					Delete:    int64(p.Delete),
+					Unchanged: int64(p.Unchanged),
+					Errors:    int64(p.Errors),
+				}
+				for _, ev := range p.Events {
+					sp.Events = append(sp.Events, ipc.SyncPlanEvent{Time: ev.Time, Type: ev.Type, ID: ev.ID, Namespace: ev.Namespace, Title: ev.Title, Action: ev.Action, Err: ev.Err})
+				}
+				out = append(out, sp)
+			}
+			return ipc.Response{OK: true, SyncPlan: out}
+		case "sync.replay":
+			from, err := time.Parse(time.RFC3339Nano, m.Since)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: "invalid replay cursor"}
+			}
+			n, err := app.Syncer.Replay(ctx, m.Remote, from)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: fmt.Sprintf("replayed %d events before error: %v", n, err)}
+			}
+			return ipc.Response{OK: true, Msg: fmt.Sprintf("replayed %d events", n)}
 		case "sync.run":
 			if err := app.Syncer.SyncNow(ctx); err != nil {
 				return ipc.Response{OK: false, Msg: err.Error()}
@@ -226,11 +539,123 @@ func Run(ctx context.Context, app *wire.App) error {
 				return ipc.Response{OK: false, Msg: err.Error()}
 			}
 			return ipc.Response{OK: true, Tags: tags}
+		case "tag.describe":
+			if len(m.Tags) != 1 || normalizeTag(m.Tags[0]) == "" {
+				return ipc.Response{OK: false, Msg: "missing tag"}
+			}
+			if err := app.Store.Entries.SetTagDescription(ctx, normalizeTag(m.Tags[0]), strings.TrimSpace(m.Body)); err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("described tag %q", m.Tags[0])
+			return ipc.Response{OK: true}
+		case "tag.rename", "tag.merge", "tag.delete":
+			from := normalizeTags(m.Tags)
+			to := normalizeTag(m.Title)
+			if len(from) == 0 {
+				return ipc.Response{OK: false, Msg: "missing tag"}
+			}
+			if m.Name != "tag.delete" && to == "" {
+				return ipc.Response{OK: false, Msg: "missing target tag"}
+			}
+			for _, t := range from {
+				used, err := tagInUse(ctx, app.Store, ns, t)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				if !used {
+					return ipc.Response{OK: false, Msg: fmt.Sprintf("tag %s not found", t)}
+				}
+			}
+			if m.Name == "tag.rename" {
+				if len(from) != 1 || from[0] == to {
+					return ipc.Response{OK: false, Msg: "rename takes one tag and a new name"}
+				}
+				used, err := tagInUse(ctx, app.Store, ns, to)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				if used {
+					return ipc.Response{OK: false, Msg: fmt.Sprintf("tag %s exists; use tag merge", to)}
+				}
+			}
+			// Rename and merge carry child tags along; delete only when asked.
+			children := m.Name != "tag.delete" || m.Recursive
+			changed := 0
+			for _, t := range from {
+				if t == to {
+					continue
+				}
+				n, err := app.Store.Entries.RenameTag(ctx, ns, t, to, children)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error(), Changed: changed}
+				}
+				changed += n
+			}
+			log.Printf("%s %v -> %q ns=%s changed=%d", m.Name, from, to, ns, changed)
+			if changed > 0 {
+				go app.Syncer.SyncNow(ctx)
+			}
+			return ipc.Response{OK: true, Changed: changed}
+		case "view.save":
+			// Since/Until stay relative ("7d") and are resolved on each run.
+			v, err := app.Store.Views.SaveView(ctx, api.View{
+				Name: m.Title, Namespace: ns, Query: m.Query,
+				TagsAny: normalizeTags(m.TagsAny), TagsAll: normalizeTags(m.TagsAll),
+				Since: m.Since, Until: m.Until,
+			})
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("saved view name=%q namespace=%q", v.Name, ns)
+			go app.Syncer.SyncNow(ctx)
+			return ipc.Response{OK: true, Views: []api.View{v}}
+		case "view.list":
+			if strings.TrimSpace(m.Title) != "" {
+				v, err := app.Store.Views.GetView(ctx, ns, m.Title)
+				if err != nil {
+					return ipc.Response{OK: false, Msg: err.Error()}
+				}
+				return ipc.Response{OK: true, Views: []api.View{v}}
+			}
+			views, err := app.Store.Views.ListViews(ctx, ns)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			return ipc.Response{OK: true, Views: views}
+		case "draft.save":
+			d, err := app.Store.Drafts.SaveDraft(ctx, api.Draft{ID: m.ID, Namespace: ns, Path: m.Path, BaseVersion: m.IfVersion, Content: m.Body})
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("saved draft id=%s namespace=%q base=%d", d.ID, ns, d.BaseVersion)
+			return ipc.Response{OK: true, Drafts: []api.Draft{d}}
+		case "draft.list":
+			if m.All {
+				ns = ""
+			}
+			ds, err := app.Store.Drafts.ListDrafts(ctx, ns)
+			if err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			return ipc.Response{OK: true, Drafts: ds}
+		case "draft.delete":
+			if err := app.Store.Drafts.DeleteDraft(ctx, m.ID); err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("deleted draft id=%s", m.ID)
+			return ipc.Response{OK: true}
+		case "view.delete":
+			if err := app.Store.Views.DeleteView(ctx, ns, m.Title); err != nil {
+				return ipc.Response{OK: false, Msg: err.Error()}
+			}
+			log.Printf("deleted view name=%q namespace=%q", m.Title, ns)
+			go app.Syncer.SyncNow(ctx)
+			return ipc.Response{OK: true}
 		default:
 			log.Printf("unknown IPC cmd=%s", m.Name)
-			return ipc.Response{OK: false, Msg: "unknown command"}
+			return ipc.Response{OK: false, Msg: unknownCommand}
 		}
-	})
+	}))
 	go func() {
 		srv := transport.NewUnixServer(transport.UnixListener{Path: sock})
 		_ = srv.Serve(ctx, handler)
@@ -248,6 +673,22 @@ func Run(ctx context.Context, app *wire.App) error {
 	return err
 }
 
+// unresolvedLinks lists the links of note id that match no note, so the
+// client can warn about them. Lookup errors yield none.
+func unresolvedLinks(ctx context.Context, store *db.Store, id string) []api.Link {
+	links, err := store.Entries.Links(ctx, id)
+	if err != nil {
+		return nil
+	}
+	var out []api.Link
+	for _, l := range links {
+		if l.Entry == nil {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
 // normalizeTags lowercases and trims tags, removing empties and duplicates while
 // preserving first-seen order.
 func normalizeTags(in []string) []string {
@@ -257,7 +698,7 @@ func normalizeTags(in []string) []string {
 	seen := make(map[string]struct{}, len(in))
 	out := make([]string, 0, len(in))
 	for _, t := range in {
-		tt := strings.ToLower(strings.TrimSpace(t))
+		tt := normalizeTag(t)
 		if tt == "" {
 			continue
 		}
@@ -270,6 +711,33 @@ func normalizeTags(in []string) []string {
 	return out
 }
 
+// tagInUse reports whether a note in namespace has tag or a child of it.
+func tagInUse(ctx context.Context, store *db.Store, namespace, tag string) (bool, error) {
+	stats, err := store.Entries.ListTags(ctx, api.TagsQuery{Namespace: namespace, Prefix: tag})
+	if err != nil {
+		return false, err
+	}
+	for _, st := range stats {
+		if st.Tag == tag || strings.HasPrefix(st.Tag, tag+"/") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// normalizeTag lowercases a tag and tidies its hierarchy: "Work / Sync/"
+// becomes "work/sync".
+func normalizeTag(t string) string {
+	parts := strings.Split(strings.ToLower(t), "/")
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, "/")
+}
+
 // Start launches the HTTP server on a provided listener (used by tests or CLI control).
 func Start(ctx context.Context, l net.Listener) error {
 	mux := http.NewServeMux()
@@ -299,3 +767,26 @@ func parseBounds(since, until string) (time.Time, time.Time) {
 	}
 	return s, u
 }
+
+// unknownCommand is the reply to a command the daemon does not handle.
+const unknownCommand = "unknown command"
+
+// instrumentIPC records per-command counts and latency for IPC handlers.
+// Commands the handler rejects as unknown share the "unknown" label, so
+// clients cannot add label values at will.
+func instrumentIPC(h func(ipc.Message) ipc.Response) func(ipc.Message) ipc.Response {
+	return func(m ipc.Message) ipc.Response {
+		start := time.Now()
+		resp := h(m)
+		name, result := m.Name, "ok"
+		if !resp.OK {
+			result = "error"
+			if resp.Msg == unknownCommand {
+				name = "unknown"
+			}
+		}
+		metrics.IPCCommands.WithLabelValues(name, result).Inc()
+		metrics.IPCDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		return resp
+	}
+}
@@ -1,14 +1,25 @@
 package daemon
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/mithrel/ginkgo/internal/db"
+	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
 )
 
 func TestNormalizeTags(t *testing.T) {
-	in := []string{" A ", "a", "B", "", "b ", "C", "a"}
+	in := []string{" A ", "a", "B", "", "b ", "C", "a", " Work / Sync/", "/", "work/sync"}
 	got := normalizeTags(in)
-	want := []string{"a", "b", "c"}
+	want := []string{"a", "b", "c", "work/sync"}
 	if len(got) != len(want) {
 		t.Fatalf("len mismatch: got %v want %v", got, want)
 	}
@@ -34,3 +45,60 @@ func TestParseBounds(t *testing.T) {
 		t.Fatalf("expected zero values for invalid inputs, got %v %v", zs, zu)
 	}
 }
+
+func TestSnapshotDrafts(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := db.Open(ctx, "sqlite://"+filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	path := filepath.Join(dir, "work.n1.ginkgo.md")
+	if _, err := store.Drafts.SaveDraft(ctx, api.Draft{ID: "n1", Namespace: "work", Path: path, Content: "Title: "}); err != nil {
+		t.Fatal(err)
+	}
+	if n := snapshotOnce(ctx, store); n != 0 {
+		t.Fatalf("snapshot of a missing file=%d", n)
+	}
+	if err := os.WriteFile(path, []byte("Title: typed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if n := snapshotOnce(ctx, store); n != 1 {
+		t.Fatalf("snapshot count=%d", n)
+	}
+	if n := snapshotOnce(ctx, store); n != 0 {
+		t.Fatalf("unchanged snapshot count=%d", n)
+	}
+	// The file going away leaves the last snapshot.
+	_ = os.Remove(path)
+	snapshotOnce(ctx, store)
+	d, err := store.Drafts.GetDraft(ctx, "n1")
+	if err != nil || d.Content != "Title: typed" {
+		t.Fatalf("draft=%+v err=%v", d, err)
+	}
+}
+
+func TestInstrumentIPCLabelsUnknownCommands(t *testing.T) {
+	h := instrumentIPC(func(m ipc.Message) ipc.Response {
+		if m.Name == "note.show" {
+			return ipc.Response{OK: true}
+		}
+		return ipc.Response{OK: false, Msg: unknownCommand}
+	})
+	h(ipc.Message{Name: "note.show"})
+	h(ipc.Message{Name: "bogus-1"})
+	h(ipc.Message{Name: "bogus-2"})
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+	for _, want := range []string{`command="note.show",result="ok"`, `command="unknown",result="error"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("metrics missing %s", want)
+		}
+	}
+	if strings.Contains(out, "bogus") {
+		t.Fatal("an unknown command got its own label")
+	}
+}
@@ -140,6 +140,28 @@ func TestDaemonNoteLifecycle(t *testing.T) {
 		t.Fatalf("edit failed: %+v", edit)
 	}
 
+	// Reminders are scoped to the note's namespace.
+	at := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	for _, m := range []ipc.Message{
+		{Name: "note.remind", ID: id, At: at, Namespace: "other"},
+		{Name: "note.remind", ID: id, Namespace: "other"},
+	} {
+		r, err := ipc.Request(ctx, sock, m)
+		if err != nil {
+			t.Fatalf("remind request: %v", err)
+		}
+		if r.OK || r.Msg != "not found" {
+			t.Fatalf("remind from another namespace: %+v", r)
+		}
+	}
+	remind, err := ipc.Request(ctx, sock, ipc.Message{Name: "note.remind", ID: id, At: at, Namespace: "test"})
+	if err != nil {
+		t.Fatalf("remind request: %v", err)
+	}
+	if !remind.OK || len(remind.Reminders) != 1 {
+		t.Fatalf("remind failed: %+v", remind)
+	}
+
 	// Search FTS
 	search, err := ipc.Request(ctx, sock, ipc.Message{Name: "note.search.fts", Title: "Updated", Namespace: "test"})
 	if err != nil {
@@ -14,6 +14,18 @@ type EventLog interface {
 	List(ctx context.Context, cur api.Cursor, limit int) ([]api.Event, api.Cursor, error)
 }
 
+// Usage is the stored event volume for a namespace.
+type Usage struct {
+	Events int64
+	Bytes  int64
+}
+
+// UsageReporter is implemented by event logs that can report per-namespace
+// storage usage (used for server quotas).
+type UsageReporter interface {
+	NamespaceUsage(ctx context.Context, namespace string) (Usage, error)
+}
+
 // Materialized entries
 type EntryRepo interface {
 	GetEntry(ctx context.Context, id string) (api.Entry, error)
@@ -23,13 +35,65 @@ type EntryRepo interface {
 	DeleteNamespace(ctx context.Context, namespace string) (int64, error)
 	ListEntries(ctx context.Context, q api.ListQuery) ([]api.Entry, api.Page, error)
 	Search(ctx context.Context, q api.SearchQuery) ([]api.Entry, api.Page, error)
+	SearchHits(ctx context.Context, q api.SearchQuery) ([]api.SearchHit, api.Page, error)
+	CorrectQuery(ctx context.Context, q string) (string, []api.TermSuggestion, error)
 	ListTags(ctx context.Context, q api.TagsQuery) ([]api.TagStat, error)
 	ListNamespaces(ctx context.Context) ([]string, error)
+	// Related ranks the other notes of id's namespace by TF-IDF cosine
+	// similarity to it.
+	Related(ctx context.Context, id string, limit int) ([]api.SearchHit, error)
+	// FindDuplicates lists the notes in e's namespace with the same or
+	// nearly the same content as e; DuplicateClusters groups them for a
+	// whole namespace and MergeEntries folds ids into keepID.
+	FindDuplicates(ctx context.Context, e api.Entry) ([]api.Duplicate, error)
+	DuplicateClusters(ctx context.Context, namespace string) ([]api.DupeCluster, error)
+	MergeEntries(ctx context.Context, keepID string, ids []string) (api.Entry, error)
+	// Links resolves the [[target]] links of note id; Backlinks lists the
+	// notes linking to it.
+	Links(ctx context.Context, id string) ([]api.Link, error)
+	Backlinks(ctx context.Context, id string) ([]api.Entry, error)
+	// SetTagDescription documents a tag; RenameTag rewrites, merges or
+	// removes a tag on a namespace's notes as ordinary edits.
+	SetTagDescription(ctx context.Context, tag, description string) error
+	RenameTag(ctx context.Context, namespace, from, to string, children bool) (int, error)
+}
+
+// Saved views (named list filters), unique per namespace by name
+// regardless of case.
+type ViewRepo interface {
+	SaveView(ctx context.Context, v api.View) (api.View, error)
+	GetView(ctx context.Context, namespace, name string) (api.View, error)
+	ListViews(ctx context.Context, namespace string) ([]api.View, error)
+	DeleteView(ctx context.Context, namespace, name string) error
+}
+
+// Note reminders, one per note.
+type ReminderRepo interface {
+	SetReminder(ctx context.Context, r api.Reminder) (api.Reminder, error)
+	GetReminder(ctx context.Context, noteID string) (api.Reminder, error)
+	// ListReminders returns the reminders of namespace ("" for all) by
+	// time, only those not yet fired unless includeFired.
+	ListReminders(ctx context.Context, namespace string, includeFired bool) ([]api.Reminder, error)
+	DeleteReminder(ctx context.Context, noteID string) error
+}
+
+// Editor drafts, one per note ID. They are local to this store and never
+// written to the event log.
+type DraftRepo interface {
+	// SaveDraft creates or replaces draft d.ID, keeping its CreatedAt.
+	SaveDraft(ctx context.Context, d api.Draft) (api.Draft, error)
+	GetDraft(ctx context.Context, id string) (api.Draft, error)
+	// ListDrafts returns the drafts of namespace ("" for all), newest first.
+	ListDrafts(ctx context.Context, namespace string) ([]api.Draft, error)
+	DeleteDraft(ctx context.Context, id string) error
 }
 
 type Store struct {
-	Events  EventLog
-	Entries EntryRepo
+	Events    EventLog
+	Entries   EntryRepo
+	Views     ViewRepo
+	Reminders ReminderRepo
+	Drafts    DraftRepo
 	io.Closer
 }
 
@@ -102,6 +166,50 @@ func (s *Store) ApplyReplication(ctx context.Context, ev api.Event) error {
 			return err
 		}
 		return nil
+	case api.EventViewUpsert:
+		if ev.View == nil {
+			return nil
+		}
+		// Last writer wins; older edits arriving late are dropped.
+		cur, err := s.Views.GetView(ctx, ev.View.Namespace, ev.View.Name)
+		if err == nil && cur.UpdatedAt.After(ev.View.UpdatedAt) {
+			return nil
+		} else if err != nil && err != ErrNotFound {
+			return err
+		}
+		_, err = s.Views.SaveView(ctx, *ev.View)
+		return err
+	case api.EventViewDelete:
+		if err := s.Views.DeleteView(ctx, ev.Namespace, ev.ID); err != nil && err != ErrNotFound {
+			return err
+		}
+		return nil
+	case api.EventReminderUpsert:
+		if ev.Reminder == nil {
+			return nil
+		}
+		// Last writer wins, as for views.
+		cur, err := s.Reminders.GetReminder(ctx, ev.Reminder.NoteID)
+		if err == nil && cur.UpdatedAt.After(ev.Reminder.UpdatedAt) {
+			return nil
+		} else if err != nil && err != ErrNotFound {
+			return err
+		}
+		// A reminder without a device fires on the device that set it.
+		r := *ev.Reminder
+		if r.Device == "" {
+			r.Device = ev.OriginLabel
+		}
+		// A reminder for a note deleted since is dropped.
+		if _, err := s.Reminders.SetReminder(ctx, r); err != nil && err != ErrNotFound {
+			return err
+		}
+		return nil
+	case api.EventReminderDelete:
+		if err := s.Reminders.DeleteReminder(ctx, ev.ID); err != nil && err != ErrNotFound {
+			return err
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+const draftColumns = `id, namespace, path, base_version, content, created_at, updated_at`
+
+// SaveDraft creates or replaces draft d.ID. A zero UpdatedAt is set to now
+// and CreatedAt is kept from the first save.
+func (s *sqliteStore) SaveDraft(ctx context.Context, d api.Draft) (api.Draft, error) {
+	defer metrics.ObserveDB("save_draft", time.Now())
+	d.ID = strings.TrimSpace(d.ID)
+	if d.ID == "" {
+		return api.Draft{}, fmt.Errorf("draft id is required")
+	}
+	if d.UpdatedAt.IsZero() {
+		d.UpdatedAt = time.Now().UTC()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = d.UpdatedAt
+	}
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.Draft{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO drafts(`+draftColumns+`) VALUES(?,?,?,?,?,?,?)
+ON CONFLICT(id) DO UPDATE SET namespace=excluded.namespace, path=excluded.path, base_version=excluded.base_version, content=excluded.content, updated_at=excluded.updated_at`,
+		d.ID, d.Namespace, d.Path, d.BaseVersion, d.Content, d.CreatedAt.UTC(), d.UpdatedAt.UTC()); err != nil {
+		return api.Draft{}, err
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT created_at FROM drafts WHERE id=?`, d.ID).Scan(&d.CreatedAt); err != nil {
+		return api.Draft{}, err
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return api.Draft{}, err
+		}
+	}
+	return d, nil
+}
+
+func (s *sqliteStore) GetDraft(ctx context.Context, id string) (api.Draft, error) {
+	defer metrics.ObserveDB("get_draft", time.Now())
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.Draft{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	var d api.Draft
+	if err := tx.QueryRowContext(ctx, `SELECT `+draftColumns+` FROM drafts WHERE id=?`, id).Scan(
+		&d.ID, &d.Namespace, &d.Path, &d.BaseVersion, &d.Content, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return api.Draft{}, ErrNotFound
+		}
+		return api.Draft{}, err
+	}
+	return d, nil
+}
+
+// ListDrafts returns drafts by last snapshot, newest first.
+func (s *sqliteStore) ListDrafts(ctx context.Context, namespace string) ([]api.Draft, error) {
+	defer metrics.ObserveDB("list_drafts", time.Now())
+	q := `SELECT ` + draftColumns + ` FROM drafts`
+	var args []any
+	if namespace != "" {
+		q += ` WHERE namespace=?`
+		args = append(args, namespace)
+	}
+	rows, err := s.db.QueryContext(ctx, q+` ORDER BY updated_at DESC, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []api.Draft
+	for rows.Next() {
+		var d api.Draft
+		if err := rows.Scan(&d.ID, &d.Namespace, &d.Path, &d.BaseVersion, &d.Content, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqliteStore) DeleteDraft(ctx context.Context, id string) error {
+	defer metrics.ObserveDB("delete_draft", time.Now())
+	res, err := s.db.ExecContext(ctx, `DELETE FROM drafts WHERE id=?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftsCRUD(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	_, err := store.Drafts.SaveDraft(ctx, api.Draft{})
+	require.Error(t, err)
+	a, err := store.Drafts.SaveDraft(ctx, api.Draft{ID: "a", Namespace: "test", Path: "/tmp/a", BaseVersion: 3, UpdatedAt: now})
+	require.NoError(t, err)
+	require.True(t, a.CreatedAt.Equal(now))
+	_, err = store.Drafts.SaveDraft(ctx, api.Draft{ID: "b", Namespace: "other", Path: "/tmp/b", UpdatedAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+
+	// A snapshot replaces the content and keeps the creation time.
+	a.Content, a.CreatedAt, a.UpdatedAt = "Title: A", time.Time{}, now.Add(2*time.Minute)
+	_, err = store.Drafts.SaveDraft(ctx, a)
+	require.NoError(t, err)
+	got, err := store.Drafts.GetDraft(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "Title: A", got.Content)
+	require.Equal(t, int64(3), got.BaseVersion)
+	require.True(t, got.CreatedAt.Equal(now))
+
+	list, err := store.Drafts.ListDrafts(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "a", list[0].ID)
+	list, err = store.Drafts.ListDrafts(ctx, "other")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	// Drafts never reach the event log.
+	evs, _, err := store.Events.List(ctx, api.Cursor{}, 10)
+	require.NoError(t, err)
+	require.Empty(t, evs)
+
+	require.NoError(t, store.Drafts.DeleteDraft(ctx, "a"))
+	require.ErrorIs(t, store.Drafts.DeleteDraft(ctx, "a"), ErrNotFound)
+	_, err = store.Drafts.GetDraft(ctx, "a")
+	require.ErrorIs(t, err, ErrNotFound)
+}
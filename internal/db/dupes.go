@@ -0,0 +1,418 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Duplicate detection.
+//
+// entry_fingerprints stores, per note, api.Entry.ContentHash for exact
+// copies and a 64-bit SimHash of its words and word pairs for near copies.
+// Two notes are near duplicates when their SimHashes differ in at most
+// maxSimHashDistance bits. The hash is also stored as four 16-bit bands:
+// by pigeonhole, near duplicates agree on at least one band, so indexed
+// band lookups find every candidate.
+
+const (
+	// maxSimHashDistance is the largest Hamming distance between near
+	// duplicates.
+	maxSimHashDistance = 3
+	// minSimHashFeatures is the fewest words a note needs for a SimHash;
+	// shorter notes are only matched exactly.
+	minSimHashFeatures = 6
+)
+
+type fingerprint struct {
+	hash    string
+	simhash uint64
+	// loose is false when the note is too short for near matching.
+	loose bool
+}
+
+func (f fingerprint) bands() [4]any {
+	var out [4]any
+	if !f.loose {
+		return out
+	}
+	for i := range out {
+		out[i] = int64(f.simhash >> (16 * i) & 0xffff)
+	}
+	return out
+}
+
+// emptyContent reports whether e has neither title nor body; such notes
+// (e.g. editor placeholders) never count as duplicates.
+func emptyContent(e api.Entry) bool {
+	return strings.TrimSpace(e.Title) == "" && strings.TrimSpace(e.Body) == ""
+}
+
+func fingerprintOf(e api.Entry) fingerprint {
+	words := strings.FieldsFunc(strings.ToLower(e.Title+"\n"+e.Body), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	fp := fingerprint{hash: e.ContentHash(), loose: len(words) >= minSimHashFeatures}
+	if !fp.loose {
+		return fp
+	}
+	var acc [64]int
+	add := func(feature string) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		v := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if v&(1<<i) != 0 {
+				acc[i]++
+			} else {
+				acc[i]--
+			}
+		}
+	}
+	for i, w := range words {
+		add(w)
+		if i > 0 {
+			add(words[i-1] + " " + w)
+		}
+	}
+	for i, c := range acc {
+		if c > 0 {
+			fp.simhash |= 1 << i
+		}
+	}
+	return fp
+}
+
+// ensureFingerprintIndex creates the duplicate index, filling it from
+// existing entries the first time.
+func ensureFingerprintIndex(ctx context.Context, db *sql.DB) error {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type='table' AND name='entry_fingerprints'`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `
+CREATE TABLE entry_fingerprints (
+  entry_id TEXT PRIMARY KEY,
+  namespace TEXT NOT NULL,
+  content_hash TEXT NOT NULL,
+  -- simhash and its bands are NULL for notes too short to compare loosely.
+  simhash INTEGER,
+  band0 INTEGER, band1 INTEGER, band2 INTEGER, band3 INTEGER
+);
+CREATE INDEX idx_fingerprints_hash ON entry_fingerprints(namespace, content_hash);
+CREATE INDEX idx_fingerprints_band0 ON entry_fingerprints(namespace, band0);
+CREATE INDEX idx_fingerprints_band1 ON entry_fingerprints(namespace, band1);
+CREATE INDEX idx_fingerprints_band2 ON entry_fingerprints(namespace, band2);
+CREATE INDEX idx_fingerprints_band3 ON entry_fingerprints(namespace, band3);
+`); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, title, body, namespace FROM entries`)
+	if err != nil {
+		return err
+	}
+	var all []api.Entry
+	for rows.Next() {
+		var e api.Entry
+		if err := rows.Scan(&e.ID, &e.Title, &e.Body, &e.Namespace); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		all = append(all, e)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+	for _, e := range all {
+		if err := indexFingerprint(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// indexFingerprint replaces the duplicate index row for e. Empty notes are
+// left out.
+func indexFingerprint(ctx context.Context, tx *sql.Tx, e api.Entry) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM entry_fingerprints WHERE entry_id=?`, e.ID); err != nil {
+		return err
+	}
+	if emptyContent(e) {
+		return nil
+	}
+	fp := fingerprintOf(e)
+	var sim any
+	if fp.loose {
+		sim = int64(fp.simhash)
+	}
+	b := fp.bands()
+	_, err := tx.ExecContext(ctx, `INSERT INTO entry_fingerprints(entry_id, namespace, content_hash, simhash, band0, band1, band2, band3) VALUES(?,?,?,?,?,?,?,?)`,
+		e.ID, e.Namespace, fp.hash, sim, b[0], b[1], b[2], b[3])
+	return err
+}
+
+// FindDuplicates returns the notes in e.Namespace, other than e itself,
+// that duplicate e's content: exact copies first, then by distance.
+func (s *sqliteStore) FindDuplicates(ctx context.Context, e api.Entry) ([]api.Duplicate, error) {
+	defer metrics.ObserveDB("find_duplicates", time.Now())
+	if emptyContent(e) {
+		return nil, nil
+	}
+	fp := fingerprintOf(e)
+	b := fp.bands()
+	rows, err := s.db.QueryContext(ctx, `SELECT entry_id, content_hash, simhash FROM entry_fingerprints
+WHERE namespace=? AND entry_id<>? AND (content_hash=? OR band0=? OR band1=? OR band2=? OR band3=?)`,
+		e.Namespace, e.ID, fp.hash, b[0], b[1], b[2], b[3])
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]api.Duplicate{}
+	var ids []string
+	for rows.Next() {
+		var id, hash string
+		var sim sql.NullInt64
+		if err := rows.Scan(&id, &hash, &sim); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		d := api.Duplicate{Exact: hash == fp.hash}
+		if !d.Exact {
+			if !fp.loose || !sim.Valid {
+				continue
+			}
+			if d.Distance = bits.OnesCount64(fp.simhash ^ uint64(sim.Int64)); d.Distance > maxSimHashDistance {
+				continue
+			}
+		}
+		found[id] = d
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+	entries, _, err := s.fetchEntriesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]api.Duplicate, 0, len(entries))
+	for _, de := range entries {
+		d := found[de.ID]
+		d.Entry = de
+		out = append(out, d)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Exact != out[j].Exact {
+			return out[i].Exact
+		}
+		if out[i].Distance != out[j].Distance {
+			return out[i].Distance < out[j].Distance
+		}
+		return out[i].Entry.CreatedAt.Before(out[j].Entry.CreatedAt)
+	})
+	return out, nil
+}
+
+// DuplicateClusters groups the namespace's duplicate notes. Notes join a
+// cluster when they duplicate any member, so near duplicates chain.
+func (s *sqliteStore) DuplicateClusters(ctx context.Context, namespace string) ([]api.DupeCluster, error) {
+	defer metrics.ObserveDB("duplicate_clusters", time.Now())
+	rows, err := s.db.QueryContext(ctx, `SELECT entry_id, content_hash, simhash FROM entry_fingerprints WHERE namespace=?`, namespace)
+	if err != nil {
+		return nil, err
+	}
+	type row struct {
+		id, hash string
+		sim      uint64
+		loose    bool
+	}
+	var all []row
+	for rows.Next() {
+		var r row
+		var sim sql.NullInt64
+		if err := rows.Scan(&r.id, &r.hash, &sim); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		r.sim, r.loose = uint64(sim.Int64), sim.Valid
+		all = append(all, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+
+	parent := make([]int, len(all))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) { parent[find(a)] = find(b) }
+
+	byHash := map[string]int{}
+	var bands [4]map[uint64][]int
+	for i := range bands {
+		bands[i] = map[uint64][]int{}
+	}
+	for i, r := range all {
+		if j, ok := byHash[r.hash]; ok {
+			union(i, j)
+		} else {
+			byHash[r.hash] = i
+		}
+		if !r.loose {
+			continue
+		}
+		for b := range bands {
+			key := r.sim >> (16 * b) & 0xffff
+			for _, j := range bands[b][key] {
+				if find(i) != find(j) && bits.OnesCount64(r.sim^all[j].sim) <= maxSimHashDistance {
+					union(i, j)
+				}
+			}
+			bands[b][key] = append(bands[b][key], i)
+		}
+	}
+
+	groups := map[int][]int{}
+	for i := range all {
+		groups[find(i)] = append(groups[find(i)], i)
+	}
+	var out []api.DupeCluster
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		ids := make([]string, 0, len(members))
+		exact := true
+		for _, i := range members {
+			ids = append(ids, all[i].id)
+			exact = exact && all[i].hash == all[members[0]].hash
+		}
+		entries, _, err := s.fetchEntriesByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		sortOldestFirst(entries)
+		out = append(out, api.DupeCluster{Entries: entries, Exact: exact})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Entries[0].CreatedAt.Before(out[j].Entries[0].CreatedAt)
+	})
+	return out, nil
+}
+
+func sortOldestFirst(entries []api.Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+// MergeContent folds src into dst: tags are unioned and src's body is
+// appended unless one body already contains the other, and src's fields
+// fill in those dst lacks. dst keeps its ID, title, namespace and
+// timestamps.
+func MergeContent(dst, src api.Entry) api.Entry {
+	seen := map[string]bool{}
+	tags := make([]string, 0, len(dst.Tags)+len(src.Tags))
+	for _, t := range append(append([]string{}, dst.Tags...), src.Tags...) {
+		if k := strings.ToLower(t); !seen[k] {
+			seen[k] = true
+			tags = append(tags, t)
+		}
+	}
+	dst.Tags = tags
+	if len(src.Fields) > 0 {
+		fields := make(map[string]any, len(dst.Fields)+len(src.Fields))
+		for k, v := range src.Fields {
+			fields[k] = v
+		}
+		for k, v := range dst.Fields {
+			fields[k] = v
+		}
+		dst.Fields = fields
+	}
+	a, b := strings.TrimSpace(dst.Body), strings.TrimSpace(src.Body)
+	switch {
+	case b == "" || strings.Contains(a, b):
+	case a == "" || strings.Contains(b, a):
+		dst.Body = src.Body
+	default:
+		dst.Body = a + "\n\n" + b
+	}
+	return dst
+}
+
+// MergeEntries folds the notes ids into keepID with MergeContent and
+// deletes them, in one transaction. All notes must share a namespace.
+func (s *sqliteStore) MergeEntries(ctx context.Context, keepID string, ids []string) (api.Entry, error) {
+	defer metrics.ObserveDB("merge_entries", time.Now())
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.Entry{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	txCtx := WithTx(ctx, tx)
+	keep, err := s.GetEntry(txCtx, keepID)
+	if err != nil {
+		return api.Entry{}, err
+	}
+	merged := keep
+	for _, id := range ids {
+		if id == keepID {
+			continue
+		}
+		other, err := s.GetEntry(txCtx, id)
+		if err != nil {
+			return api.Entry{}, err
+		}
+		if other.Namespace != keep.Namespace {
+			return api.Entry{}, fmt.Errorf("note %s is in namespace %s, not %s", id, other.Namespace, keep.Namespace)
+		}
+		merged = MergeContent(merged, other)
+		if err := s.DeleteEntry(txCtx, id); err != nil {
+			return api.Entry{}, err
+		}
+	}
+	merged.Version = keep.Version + 1
+	merged.UpdatedAt = time.Now().UTC()
+	out, err := s.UpdateEntryCAS(txCtx, merged, keep.Version)
+	if err != nil {
+		return api.Entry{}, err
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return api.Entry{}, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	base := time.Now().UTC().Truncate(time.Second)
+	n := 0
+	mk := func(id, ns, title, body string, tags ...string) {
+		n++
+		at := base.Add(time.Duration(n) * time.Minute)
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: title, Body: body, Tags: tags, Namespace: ns, CreatedAt: at, UpdatedAt: at})
+		require.NoError(t, err)
+	}
+	long := "Rotate the staging database credentials every quarter and record the new secret in the vault before the old one expires so deploys keep working"
+	mk("orig", "test", "Credentials", long)
+	mk("copy", "test", "credentials", "  "+long+"\n", "ops")
+	mk("near", "test", "Credentials", long+" today")
+	mk("short", "test", "", "buy milk")
+	mk("other", "test", "Lunch", "Pizza on friday with the whole team at the usual place downtown")
+	mk("elsewhere", "home", "Credentials", long)
+	mk("blank", "test", "", "")
+
+	dupes, err := store.Entries.FindDuplicates(ctx, api.Entry{ID: "new", Namespace: "test", Title: "CREDENTIALS", Body: long})
+	require.NoError(t, err)
+	var ids []string
+	for _, d := range dupes {
+		ids = append(ids, d.Entry.ID)
+	}
+	require.Equal(t, []string{"orig", "copy", "near"}, ids)
+	require.True(t, dupes[0].Exact)
+	require.False(t, dupes[2].Exact)
+	require.LessOrEqual(t, dupes[2].Distance, maxSimHashDistance)
+
+	// Short notes only match exactly; empty notes never match.
+	dupes, err = store.Entries.FindDuplicates(ctx, api.Entry{Namespace: "test", Body: "Buy milk"})
+	require.NoError(t, err)
+	require.Len(t, dupes, 1)
+	require.Equal(t, "short", dupes[0].Entry.ID)
+	dupes, err = store.Entries.FindDuplicates(ctx, api.Entry{Namespace: "test", Body: "buy milk today"})
+	require.NoError(t, err)
+	require.Empty(t, dupes)
+	dupes, err = store.Entries.FindDuplicates(ctx, api.Entry{ID: "x", Namespace: "test"})
+	require.NoError(t, err)
+	require.Empty(t, dupes)
+
+	clusters, err := store.Entries.DuplicateClusters(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.False(t, clusters[0].Exact)
+	ids = ids[:0]
+	for _, e := range clusters[0].Entries {
+		ids = append(ids, e.ID)
+	}
+	require.Equal(t, []string{"orig", "copy", "near"}, ids)
+
+	// Edits and deletes keep the index current.
+	cur, err := store.Entries.GetEntry(ctx, "near")
+	require.NoError(t, err)
+	cur.Body, cur.Version = "Something else entirely, nothing to do with secrets or vaults at all", cur.Version+1
+	_, err = store.Entries.UpdateEntryCAS(ctx, cur, cur.Version-1)
+	require.NoError(t, err)
+	require.NoError(t, store.Entries.DeleteEntry(ctx, "copy"))
+	clusters, err = store.Entries.DuplicateClusters(ctx, "test")
+	require.NoError(t, err)
+	require.Empty(t, clusters)
+}
+
+func TestMergeEntries(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	mk := func(id, ns, body string, tags ...string) {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: "Plan", Body: body, Tags: tags, Namespace: ns, CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	mk("keep", "test", "first draft", "work")
+	mk("longer", "test", "first draft\nwith more detail", "Work", "plan")
+	mk("extra", "test", "unrelated aside", "misc")
+	mk("home", "home", "first draft")
+
+	_, err := store.Entries.MergeEntries(ctx, "keep", []string{"home"})
+	require.Error(t, err)
+
+	got, err := store.Entries.MergeEntries(ctx, "keep", []string{"keep", "longer", "extra"})
+	require.NoError(t, err)
+	require.Equal(t, "keep", got.ID)
+	require.Equal(t, int64(2), got.Version)
+	require.Equal(t, "Plan", got.Title)
+	require.Equal(t, "first draft\nwith more detail\n\nunrelated aside", got.Body)
+	require.Equal(t, []string{"work", "plan", "misc"}, got.Tags)
+	for _, id := range []string{"longer", "extra"} {
+		_, err := store.Entries.GetEntry(ctx, id)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	_, err = store.Entries.GetEntry(ctx, "home")
+	require.NoError(t, err)
+}
+
+func TestFingerprintIndexBackfill(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	for _, id := range []string{"a", "b"} {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: "Same", Body: "same text", Namespace: "test", CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	dbh := store.Entries.(*sqliteStore).db
+	_, err := dbh.ExecContext(ctx, `DROP TABLE entry_fingerprints`)
+	require.NoError(t, err)
+	require.NoError(t, migrate(ctx, dbh))
+
+	clusters, err := store.Entries.DuplicateClusters(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	require.True(t, clusters[0].Exact)
+}
@@ -25,7 +25,7 @@ func setupTestDB(t *testing.T) (*Store, context.Context, context.CancelFunc) {
 		os.RemoveAll(tmpDir)
 	})
 
-	return &Store{Events: store.Events, Entries: store.Entries}, ctx, cancel
+	return &Store{Events: store.Events, Entries: store.Entries, Views: store.Views, Reminders: store.Reminders, Drafts: store.Drafts}, ctx, cancel
 }
 
 func TestUpdateEntryCAS(t *testing.T) {
@@ -79,6 +79,22 @@ func TestUpdateEntryCAS(t *testing.T) {
 		assert.Equal(t, "Sync Update", updated.Title)
 	})
 
+	t.Run("UpdateEntryCAS moves created_at unless zero", func(t *testing.T) {
+		cur, err := repo.GetEntry(ctx, initial.ID)
+		require.NoError(t, err)
+
+		earlier := now.Add(-48 * time.Hour)
+		cur.CreatedAt = earlier
+		updated, err := repo.UpdateEntryCAS(ctx, cur, cur.Version)
+		require.NoError(t, err)
+		assert.True(t, updated.CreatedAt.Equal(earlier))
+
+		cur.CreatedAt = time.Time{}
+		updated, err = repo.UpdateEntryCAS(ctx, cur, cur.Version)
+		require.NoError(t, err)
+		assert.True(t, updated.CreatedAt.Equal(earlier))
+	})
+
 	t.Run("UpdateEntryCAS fails on version mismatch (Conflict)", func(t *testing.T) {
 		cur, err := repo.GetEntry(ctx, initial.ID)
 		require.NoError(t, err)
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mithrel/ginkgo/internal/query"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Custom fields are stored as JSON in entries.fields and projected into
+// note_fields, one row per note and field, with the value as text and, when
+// it reads as one (see api.FieldNumber), as a number for filtering and
+// sorting.
+
+// ensureFieldIndex adds the fields column to entries created before it and
+// the note_fields projection. Older notes have no fields, so there is
+// nothing to fill in.
+func ensureFieldIndex(ctx context.Context, db *sql.DB) error {
+	rows, err := db.QueryContext(ctx, `PRAGMA table_info(entries)`)
+	if err != nil {
+		return err
+	}
+	found := false
+	for rows.Next() {
+		var cid, notnull, pk int
+		var name, ctype string
+		var dflt any
+		if err := rows.Scan(&cid, &name, &ctype, &notnull, &dflt, &pk); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		found = found || name == "fields"
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		if _, err := db.ExecContext(ctx, `ALTER TABLE entries ADD COLUMN fields TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	_, err = db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS note_fields (
+  note_id TEXT NOT NULL,
+  namespace TEXT NOT NULL,
+  name TEXT NOT NULL,
+  text TEXT NOT NULL,
+  num REAL,
+  PRIMARY KEY(note_id, name)
+) WITHOUT ROWID;
+CREATE INDEX IF NOT EXISTS idx_note_fields_num ON note_fields(name, num);
+CREATE INDEX IF NOT EXISTS idx_note_fields_text ON note_fields(name, text COLLATE NOCASE);
+`)
+	return err
+}
+
+// upsertNoteFields replaces the note_fields rows of e.
+func upsertNoteFields(ctx context.Context, tx *sql.Tx, e api.Entry) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM note_fields WHERE note_id=?`, e.ID); err != nil {
+		return err
+	}
+	for _, name := range api.FieldNames(e.Fields) {
+		v := e.Fields[name]
+		var num any
+		if n, ok := api.FieldNumber(v); ok {
+			num = n
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO note_fields(note_id, namespace, name, text, num) VALUES(?,?,?,?,?)`, e.ID, e.Namespace, name, api.FormatField(v), num); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeFields is the entries.fields value of fields: JSON, or "" for none.
+func encodeFields(fields map[string]any) (string, error) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("fields: %w", err)
+	}
+	return string(b), nil
+}
+
+func decodeFields(s string) map[string]any {
+	if s == "" {
+		return nil
+	}
+	var out map[string]any
+	_ = json.Unmarshal([]byte(s), &out)
+	return out
+}
+
+// fieldExpr renders a field comparison as a predicate over entries e.
+// Values that read as numbers (or durations) compare numerically, others as
+// case-insensitive text.
+func fieldExpr(f *query.Field) (string, []any) {
+	const has = "EXISTS (SELECT 1 FROM note_fields qf WHERE qf.note_id = e.id AND qf.name = ?"
+	if f.Op == "" {
+		return has + ")", []any{f.Name}
+	}
+	op := string(f.Op)
+	if f.Op == query.OpNe {
+		op = "<>"
+	}
+	if n, ok := api.FieldNumber(f.Value); ok {
+		return has + " AND qf.num " + op + " ?)", []any{f.Name, n}
+	}
+	return has + " AND qf.text " + op + " ? COLLATE NOCASE)", []any{f.Name, f.Value}
+}
+
+// fieldOrder parses a ListQuery.Sort ("mood", or "-mood" for descending)
+// into a join of the sort field as sf and an ORDER BY clause. Notes without
+// the field come last, numbers before text.
+func fieldOrder(sort string) (join, order string, args []any, err error) {
+	name, dir := strings.ToLower(strings.TrimSpace(sort)), "ASC"
+	if strings.HasPrefix(name, "-") {
+		name, dir = name[1:], "DESC"
+	}
+	if !api.ValidFieldName(name) {
+		return "", "", nil, fmt.Errorf("invalid sort field %q", sort)
+	}
+	join = "LEFT JOIN note_fields sf ON sf.note_id = e.id AND sf.name = ?"
+	order = "ORDER BY sf.note_id IS NULL, sf.num IS NULL, sf.num " + dir + ", sf.text COLLATE NOCASE " + dir + ", f.c_at DESC, e.id DESC"
+	return join, order, []any{name}, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldFiltersAndSort(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	mk := func(id string, i int, fields map[string]any) api.Entry {
+		at := now.Add(time.Duration(i) * time.Minute)
+		e, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: id, Body: "b", Namespace: "test", CreatedAt: at, UpdatedAt: at, Fields: fields})
+		require.NoError(t, err)
+		return e
+	}
+	mk("happy", 0, map[string]any{"mood": 5.0, "project": "Ginkgo", "duration": "45m"})
+	mk("fine", 1, map[string]any{"mood": 4.0, "project": "home", "duration": "2h"})
+	mk("low", 2, map[string]any{"mood": 2.0, "done": true})
+	mk("plain", 3, nil)
+
+	got, err := store.Entries.GetEntry(ctx, "happy")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"mood": 5.0, "project": "Ginkgo", "duration": "45m"}, got.Fields)
+
+	list := func(q api.ListQuery) []string {
+		q.Namespace = "test"
+		got, _, err := store.Entries.ListEntries(ctx, q)
+		require.NoError(t, err)
+		var ids []string
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+	require.ElementsMatch(t, []string{"happy", "fine"}, list(api.ListQuery{Query: "field:mood>=4"}))
+	require.ElementsMatch(t, []string{"happy"}, list(api.ListQuery{Query: "field:project=ginkgo"}))
+	require.ElementsMatch(t, []string{"fine", "low"}, list(api.ListQuery{Query: "field:mood!=5"}))
+	require.ElementsMatch(t, []string{"happy"}, list(api.ListQuery{Query: "field:duration<1h"}))
+	require.ElementsMatch(t, []string{"low"}, list(api.ListQuery{Query: "field:done=true"}))
+	require.ElementsMatch(t, []string{"happy", "fine", "low"}, list(api.ListQuery{Query: "field:mood"}))
+	require.ElementsMatch(t, []string{"plain"}, list(api.ListQuery{Query: "-field:mood"}))
+
+	require.Equal(t, []string{"low", "fine", "happy", "plain"}, list(api.ListQuery{Sort: "mood"}))
+	require.Equal(t, []string{"happy", "fine", "low", "plain"}, list(api.ListQuery{Sort: "-mood"}))
+	require.Equal(t, []string{"fine", "happy", "plain", "low"}, list(api.ListQuery{Sort: "-project"}))
+
+	first, page, err := store.Entries.ListEntries(ctx, api.ListQuery{Namespace: "test", Sort: "-mood", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	require.NotEmpty(t, page.Next)
+	second, page2, err := store.Entries.ListEntries(ctx, api.ListQuery{Namespace: "test", Sort: "-mood", Limit: 2, Cursor: page.Next})
+	require.NoError(t, err)
+	require.Equal(t, "low", second[0].ID)
+	require.Equal(t, "plain", second[1].ID)
+	require.Empty(t, page2.Next)
+
+	_, _, err = store.Entries.ListEntries(ctx, api.ListQuery{Namespace: "test", Sort: "title"})
+	require.Error(t, err)
+
+	// A value JSON cannot hold fails the write instead of dropping fields.
+	_, err = store.Entries.CreateEntry(ctx, api.Entry{ID: "nan", Version: 1, Title: "nan", Namespace: "test", CreatedAt: now, UpdatedAt: now, Fields: map[string]any{"x": math.NaN()}})
+	require.ErrorContains(t, err, "fields")
+
+	t.Run("update and delete", func(t *testing.T) {
+		e, err := store.Entries.GetEntry(ctx, "fine")
+		require.NoError(t, err)
+		e.Version++
+		e.Fields = map[string]any{"mood": 1.0}
+		out, err := store.Entries.UpdateEntryCAS(ctx, e, e.Version-1)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"mood": 1.0}, out.Fields)
+		require.Empty(t, list(api.ListQuery{Query: "field:project=home"}))
+		require.ElementsMatch(t, []string{"happy"}, list(api.ListQuery{Query: "field:mood>=4"}))
+
+		require.NoError(t, store.Entries.DeleteEntry(ctx, "happy"))
+		require.Empty(t, list(api.ListQuery{Query: "field:mood>=4"}))
+	})
+}
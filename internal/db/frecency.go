@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Tag frecency.
+//
+// tag_usage records when each tag was used: once per note and tag each time
+// a note carrying it is created or edited, at the note's update time, so
+// replicated and imported notes count when they were written rather than
+// when they arrived. Repeated saves of one note within tagUseCooldown count
+// once, and only the latest maxTagUses uses of a tag are kept. A tag's
+// frecency is the sum of its uses weighted by age (see frecencyScore).
+
+const (
+	maxTagUses     = 10
+	tagUseCooldown = time.Hour
+)
+
+// frecencyScore is a SQL expression for the frecency of tag column col in
+// namespace (all namespaces when empty); its arguments are now, namespace
+// twice.
+func frecencyScore(col string) string {
+	return `(SELECT COALESCE(SUM(CASE WHEN age < 4 THEN 100 WHEN age < 14 THEN 70 WHEN age < 31 THEN 50 WHEN age < 90 THEN 30 ELSE 10 END), 0)
+FROM (SELECT (? - used_at) / 86400 AS age FROM tag_usage WHERE tag = ` + col + ` AND (? = '' OR namespace = ?)))`
+}
+
+// ensureTagUsage creates the tag usage log, seeding it with one use per
+// note and tag at the note's update time the first time.
+func ensureTagUsage(ctx context.Context, db *sql.DB) error {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type='table' AND name='tag_usage'`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `
+CREATE TABLE tag_usage (
+  namespace TEXT NOT NULL,
+  tag TEXT NOT NULL COLLATE NOCASE,
+  note_id TEXT NOT NULL,
+  used_at INTEGER NOT NULL
+);
+CREATE INDEX idx_tag_usage_tag ON tag_usage(tag, namespace, used_at);
+`); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, tags, updated_at, namespace FROM entries`)
+	if err != nil {
+		return err
+	}
+	var all []api.Entry
+	for rows.Next() {
+		var e api.Entry
+		var tagsJSON string
+		if err := rows.Scan(&e.ID, &tagsJSON, &e.UpdatedAt, &e.Namespace); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		_ = json.Unmarshal([]byte(tagsJSON), &e.Tags)
+		all = append(all, e)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+	for _, e := range all {
+		if err := recordTagUse(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// recordTagUse logs a use of each tag of e at e.UpdatedAt.
+func recordTagUse(ctx context.Context, tx *sql.Tx, e api.Entry) error {
+	at := e.UpdatedAt.Unix()
+	for _, t := range e.Tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		var recent int
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM tag_usage WHERE tag=? AND namespace=? AND note_id=? AND used_at > ? AND used_at <= ?`,
+			t, e.Namespace, e.ID, at-int64(tagUseCooldown/time.Second), at).Scan(&recent); err != nil {
+			return err
+		}
+		if recent > 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tag_usage(namespace, tag, note_id, used_at) VALUES(?,?,?,?)`, e.Namespace, t, e.ID, at); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tag_usage WHERE tag=? AND namespace=? AND rowid NOT IN (
+SELECT rowid FROM tag_usage WHERE tag=? AND namespace=? ORDER BY used_at DESC LIMIT ?)`, t, e.Namespace, t, e.Namespace, maxTagUses); err != nil {
+			return err
+		}
+	}
+	return nil
+}
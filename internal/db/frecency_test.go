@@ -0,0 +1,51 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagFrecency(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	old := now.AddDate(0, -6, 0)
+	mk := func(id string, at time.Time, tags ...string) {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: id, Body: "b", Tags: tags, Namespace: "test", CreatedAt: at, UpdatedAt: at})
+		require.NoError(t, err)
+	}
+	// archive is on more notes, but only long ago.
+	mk("a1", old, "archive")
+	mk("a2", old, "archive")
+	mk("a3", old, "archive", "daily")
+	mk("d1", now, "daily")
+
+	stats := func(sortBy string) []api.TagStat {
+		got, err := store.Entries.ListTags(ctx, api.TagsQuery{Namespace: "test", SortBy: sortBy})
+		require.NoError(t, err)
+		return got
+	}
+	byCount := stats("")
+	require.Equal(t, "archive", byCount[0].Tag)
+	require.Equal(t, 30, byCount[0].Frecency)
+	require.Equal(t, 110, byCount[1].Frecency)
+	require.Equal(t, "daily", stats("frecency")[0].Tag)
+
+	// Saving the same note again within the hour counts once.
+	e, err := store.Entries.GetEntry(ctx, "d1")
+	require.NoError(t, err)
+	e.Version, e.UpdatedAt = 2, now.Add(time.Minute)
+	_, err = store.Entries.UpdateEntryCAS(ctx, e, 1)
+	require.NoError(t, err)
+	require.Equal(t, 110, stats("frecency")[0].Frecency)
+
+	// Only the latest uses are kept.
+	for i := 0; i < maxTagUses+5; i++ {
+		e.Version, e.UpdatedAt = e.Version+1, e.UpdatedAt.Add(2*tagUseCooldown)
+		_, err = store.Entries.UpdateEntryCAS(ctx, e, e.Version-1)
+		require.NoError(t, err)
+	}
+	require.Equal(t, maxTagUses*100, stats("frecency")[0].Frecency)
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/internal/query"
+	"github.com/mithrel/ginkgo/internal/util"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// maxSuggestions is how many alternatives are reported per unknown term.
+const maxSuggestions = 5
+
+// CorrectQuery rewrites text terms of src that occur in no note to the
+// closest indexed term (see util.ClosestTerms) and returns the rewritten
+// query with the alternatives found for each rewritten term. Negated and
+// prefix terms, and words shorter than three letters, are left alone. The
+// term index spans all namespaces. A query with nothing to correct is
+// returned unchanged with no suggestions.
+func (s *sqliteStore) CorrectQuery(ctx context.Context, src string) (string, []api.TermSuggestion, error) {
+	defer metrics.ObserveDB("correct_query", time.Now())
+	if strings.TrimSpace(src) == "" {
+		return src, nil, nil
+	}
+	n, err := query.Parse(src)
+	if err != nil {
+		return "", nil, err
+	}
+	var out []api.TermSuggestion
+	for _, t := range query.Terms(n) {
+		if t.Prefix {
+			continue
+		}
+		words := strings.Fields(t.Text)
+		changed := false
+		for i, w := range words {
+			if !isIndexWord(w) {
+				continue
+			}
+			known, err := s.termIndexed(ctx, w, t.Field)
+			if err != nil {
+				return "", nil, err
+			}
+			if known {
+				continue
+			}
+			lw := strings.ToLower(w)
+			edits := maxEdits(lw)
+			terms, err := s.loadVocab(ctx, t.Field, utf8.RuneCountInString(lw), edits)
+			if err != nil {
+				return "", nil, err
+			}
+			alts := util.ClosestTerms(lw, terms, edits, maxSuggestions)
+			if len(alts) == 0 {
+				continue
+			}
+			out = append(out, api.TermSuggestion{Term: w, Suggestions: alts})
+			words[i] = alts[0]
+			changed = true
+		}
+		if changed {
+			t.Text = strings.Join(words, " ")
+		}
+	}
+	if len(out) == 0 {
+		return src, nil, nil
+	}
+	return n.String(), out, nil
+}
+
+// isIndexWord reports whether w is a single unicode61 token worth
+// correcting.
+func isIndexWord(w string) bool {
+	if utf8.RuneCountInString(w) < 3 {
+		return false
+	}
+	for _, r := range w {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxEdits allows one typo in short words and two in longer ones.
+func maxEdits(w string) int {
+	if utf8.RuneCountInString(w) <= 4 {
+		return 1
+	}
+	return 2
+}
+
+// termIndexed asks FTS itself whether w occurs in field, so the tokenizer's
+// case and diacritic folding apply.
+func (s *sqliteStore) termIndexed(ctx context.Context, w, field string) (bool, error) {
+	col := ftsColumns
+	if field != "" {
+		col = field
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT 1 FROM entries_fts WHERE entries_fts MATCH ? LIMIT 1`, col+` : "`+w+`"`)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	return rows.Next(), rows.Err()
+}
+
+// loadVocab returns the indexed terms of field ("" for title, body and tags)
+// that are within edits of n letters long, the only ones that can be within
+// edits of a word of that length, with the number of notes containing each.
+func (s *sqliteStore) loadVocab(ctx context.Context, field string, n, edits int) (map[string]int, error) {
+	cols := []any{"title", "body", "tags"}
+	if field != "" {
+		cols = []any{field}
+	}
+	args := append(cols, n-edits, n+edits)
+	rows, err := s.db.QueryContext(ctx, `SELECT term, sum(doc) FROM entries_vocab WHERE col IN (?`+strings.Repeat(",?", len(cols)-1)+`) AND length(term) BETWEEN ? AND ? GROUP BY term`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string]int{}
+	for rows.Next() {
+		var term string
+		var docs int
+		if err := rows.Scan(&term, &docs); err != nil {
+			return nil, err
+		}
+		out[term] = docs
+	}
+	return out, rows.Err()
+}
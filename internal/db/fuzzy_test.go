@@ -0,0 +1,64 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrectQuery(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	for _, e := range []api.Entry{
+		{ID: "k8s", Title: "Cluster upgrade", Body: "Upgraded kubernetes to 1.31"},
+		{ID: "k8s-2", Title: "Kubernetes notes", Body: "kubelet restarts"},
+		{ID: "cafe", Title: "Café", Body: "coffee with the team"},
+	} {
+		e.Version, e.Namespace, e.CreatedAt, e.UpdatedAt = 1, "test", now, now
+		_, err := store.Entries.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+
+	cases := []struct {
+		in, want string
+		terms    []string
+	}{
+		{`kubernets upgrade`, `kubernetes upgrade`, []string{"kubernets"}},
+		{`Kuberentes tag:ops`, `kubernetes tag:ops`, []string{"Kuberentes"}},
+		{`"cluster upgarde"`, `"cluster upgrade"`, []string{"upgarde"}},
+		{`title:upgrde`, `title:upgrade`, []string{"upgrde"}},
+		{`title:kubelet`, `title:kubelet`, nil},
+		{`body:kubelet`, `body:kubelet`, nil},
+		{`cafe OR café`, `cafe OR café`, nil},
+		{`-kubernets kuber*`, `-kubernets kuber*`, nil},
+		{`xyzzy`, `xyzzy`, nil},
+	}
+	for _, c := range cases {
+		got, sugg, err := store.Entries.CorrectQuery(ctx, c.in)
+		require.NoError(t, err, c.in)
+		require.Equal(t, c.want, got, c.in)
+		var terms []string
+		for _, s := range sugg {
+			terms = append(terms, s.Term)
+		}
+		require.Equal(t, c.terms, terms, c.in)
+	}
+
+	_, sugg, err := store.Entries.CorrectQuery(ctx, `kubelat`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"kubelet"}, sugg[0].Suggestions)
+
+	// Only terms whose length allows a close enough match are loaded.
+	vocab, err := store.Entries.(*sqliteStore).loadVocab(ctx, "", 7, 1)
+	require.NoError(t, err)
+	require.Contains(t, vocab, "kubelet")
+	require.Contains(t, vocab, "upgrade")
+	require.NotContains(t, vocab, "kubernetes")
+	require.NotContains(t, vocab, "team")
+
+	hits, _, err := store.Entries.SearchHits(ctx, api.SearchQuery{Namespace: "test", Query: `kubernetes upgrade`})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+}
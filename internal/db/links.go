@@ -0,0 +1,204 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Note links.
+//
+// note_links is a projection of the [[target]] links in note bodies,
+// maintained alongside note_tags. Targets are stored as written and
+// resolved when read, within the linking note's namespace: a note ID wins,
+// otherwise the oldest note with that title (ignoring ASCII case). Links
+// therefore follow renames and start resolving once the target is created.
+
+// ensureLinkIndex creates the links projection, filling it from existing
+// entries the first time.
+func ensureLinkIndex(ctx context.Context, db *sql.DB) error {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type='table' AND name='note_links'`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `
+CREATE TABLE note_links (
+  note_id TEXT NOT NULL,
+  namespace TEXT NOT NULL,
+  pos INTEGER NOT NULL,
+  target TEXT NOT NULL COLLATE NOCASE,
+  PRIMARY KEY(note_id, pos)
+) WITHOUT ROWID;
+CREATE INDEX idx_note_links_target ON note_links(namespace, target);
+CREATE INDEX IF NOT EXISTS idx_entries_ns_title_nocase ON entries(namespace, title COLLATE NOCASE);
+`); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, body, namespace FROM entries`)
+	if err != nil {
+		return err
+	}
+	var all []api.Entry
+	for rows.Next() {
+		var e api.Entry
+		if err := rows.Scan(&e.ID, &e.Body, &e.Namespace); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		all = append(all, e)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+	for _, e := range all {
+		if err := upsertNoteLinks(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// upsertNoteLinks replaces the links projection of e.
+func upsertNoteLinks(ctx context.Context, tx *sql.Tx, e api.Entry) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM note_links WHERE note_id=?`, e.ID); err != nil {
+		return err
+	}
+	for i, target := range api.LinkTargets(e.Body) {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO note_links(note_id, namespace, pos, target) VALUES(?,?,?,?)`, e.ID, e.Namespace, i, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLink returns the ID of the note target refers to in namespace, or
+// "" when there is none.
+func (s *sqliteStore) resolveLink(ctx context.Context, namespace, target string) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `SELECT id FROM entries WHERE namespace=? AND (id=? OR title=? COLLATE NOCASE)
+ORDER BY id=? DESC, created_at, id LIMIT 1`, namespace, target, target, target).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return id, err
+}
+
+// resolveTargets resolves link targets within namespace.
+func (s *sqliteStore) resolveTargets(ctx context.Context, namespace string, targets []string) ([]api.Link, error) {
+	out := make([]api.Link, 0, len(targets))
+	for _, t := range targets {
+		l := api.Link{Target: t}
+		id, err := s.resolveLink(ctx, namespace, t)
+		if err != nil {
+			return nil, err
+		}
+		if id != "" {
+			e, err := s.GetEntry(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			l.Entry = &e
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// Links returns the links of note id in body order, resolved.
+func (s *sqliteStore) Links(ctx context.Context, id string) ([]api.Link, error) {
+	defer metrics.ObserveDB("links", time.Now())
+	var ns string
+	if err := s.db.QueryRowContext(ctx, `SELECT namespace FROM entries WHERE id=?`, id).Scan(&ns); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT target FROM note_links WHERE note_id=? ORDER BY pos`, id)
+	if err != nil {
+		return nil, err
+	}
+	var targets []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+	return s.resolveTargets(ctx, ns, targets)
+}
+
+// Backlinks returns the notes whose links resolve to note id, most recently
+// updated first.
+func (s *sqliteStore) Backlinks(ctx context.Context, id string) ([]api.Entry, error) {
+	defer metrics.ObserveDB("backlinks", time.Now())
+	var ns, title string
+	if err := s.db.QueryRowContext(ctx, `SELECT namespace, title FROM entries WHERE id=?`, id).Scan(&ns, &title); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	// Links by title count only when the title resolves to this note and
+	// not to an older namesake.
+	targets := []any{id}
+	if title != "" {
+		owner, err := s.resolveLink(ctx, ns, title)
+		if err != nil {
+			return nil, err
+		}
+		if owner == id {
+			targets = append(targets, title)
+		}
+	}
+	args := append([]any{ns, id}, targets...)
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT note_id FROM note_links
+WHERE namespace=? AND note_id<>? AND target IN (?`+strings.Repeat(",?", len(targets)-1)+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for rows.Next() {
+		var nid string
+		if err := rows.Scan(&nid); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		ids = append(ids, nid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+	entries, _, err := s.fetchEntriesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].UpdatedAt.Equal(entries[j].UpdatedAt) {
+			return entries[i].UpdatedAt.After(entries[j].UpdatedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinksAndBacklinks(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	base := time.Now().UTC().Truncate(time.Second)
+	n := 0
+	mk := func(id, ns, title, body string) {
+		n++
+		at := base.Add(time.Duration(n) * time.Minute)
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: title, Body: body, Namespace: ns, CreatedAt: at, UpdatedAt: at})
+		require.NoError(t, err)
+	}
+	mk("plan", "test", "Deploy plan", "steps")
+	mk("dup", "test", "Deploy Plan", "a later namesake")
+	mk("a", "test", "Standup", "Discussed [[deploy plan]] and [[Missing]].")
+	mk("b", "test", "Retro", "Follow-up to [[plan|the plan]] and [[a]].")
+	mk("c", "home", "Home", "[[Deploy plan]] is another namespace")
+
+	links, err := store.Entries.Links(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.Equal(t, "deploy plan", links[0].Target)
+	require.NotNil(t, links[0].Entry)
+	require.Equal(t, "plan", links[0].Entry.ID)
+	require.Equal(t, "Missing", links[1].Target)
+	require.Nil(t, links[1].Entry)
+
+	ids := func(entries []api.Entry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.ID)
+		}
+		return out
+	}
+	back, err := store.Entries.Backlinks(ctx, "plan")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, ids(back))
+	back, err = store.Entries.Backlinks(ctx, "dup")
+	require.NoError(t, err)
+	require.Empty(t, back)
+
+	// A link starts resolving once its target exists, and edits and
+	// deletes update the projection.
+	mk("m", "test", "missing", "now here")
+	back, err = store.Entries.Backlinks(ctx, "m")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, ids(back))
+	cur, err := store.Entries.GetEntry(ctx, "b")
+	require.NoError(t, err)
+	cur.Body, cur.Version = "no links now", cur.Version+1
+	_, err = store.Entries.UpdateEntryCAS(ctx, cur, cur.Version-1)
+	require.NoError(t, err)
+	require.NoError(t, store.Entries.DeleteEntry(ctx, "a"))
+	back, err = store.Entries.Backlinks(ctx, "plan")
+	require.NoError(t, err)
+	require.Empty(t, back)
+
+	_, err = store.Entries.Links(ctx, "nope")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLinkIndexBackfill(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	for _, e := range []api.Entry{
+		{ID: "t", Title: "Target", Body: "x"},
+		{ID: "s", Title: "Source", Body: "see [[target]]"},
+	} {
+		e.Version, e.Namespace, e.CreatedAt, e.UpdatedAt = 1, "test", now, now
+		_, err := store.Entries.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+	dbh := store.Entries.(*sqliteStore).db
+	_, err := dbh.ExecContext(ctx, `DROP TABLE note_links`)
+	require.NoError(t, err)
+	require.NoError(t, migrate(ctx, dbh))
+
+	back, err := store.Entries.Backlinks(ctx, "t")
+	require.NoError(t, err)
+	require.Len(t, back, 1)
+}
@@ -12,10 +12,10 @@ import (
 	"strconv"
 	"strings"
 	"time"
-	"unicode"
 
 	_ "modernc.org/sqlite"
 
+	"github.com/mithrel/ginkgo/internal/metrics"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
@@ -44,6 +44,9 @@ type filter struct {
 	Any       []string
 	All       []string
 	Limit     int
+	// Where is an extra predicate over entries e, e.g. a compiled query.
+	Where     string
+	WhereArgs []any
 }
 
 type prefilter struct {
@@ -95,30 +98,29 @@ func buildPrefilter(f filter) prefilter {
 		conds = append(conds, "e.created_at <= ?")
 		args = append(args, f.Until.UTC())
 	}
+	if f.Where != "" {
+		conds = append(conds, f.Where)
+		args = append(args, f.WhereArgs...)
+	}
 	if len(conds) > 0 {
 		sql += "\n  WHERE " + strings.Join(conds, " AND ")
 	}
 	sql += "\n  GROUP BY e.id"
+	// Tags match hierarchically (see tags.go): work matches work/ginkgo.
 	hav := []string{}
-	if l := len(all); l > 0 {
-		ph := make([]string, 0, l)
-		for range all {
-			ph = append(ph, "?")
-		}
-		hav = append(hav, "SUM(CASE WHEN nt.tag IN ("+strings.Join(ph, ",")+") THEN 1 ELSE 0 END) = "+itoa(l))
-		for _, t := range all {
-			args = append(args, t)
-		}
+	for _, t := range all {
+		m, a := tagMatch("nt.tag", t)
+		hav = append(hav, "MAX(CASE WHEN "+m+" THEN 1 ELSE 0 END) = 1")
+		args = append(args, a...)
 	}
-	if l := len(any); l > 0 {
-		ph := make([]string, 0, l)
-		for range any {
-			ph = append(ph, "?")
-		}
-		hav = append(hav, "SUM(CASE WHEN nt.tag IN ("+strings.Join(ph, ",")+") THEN 1 ELSE 0 END) >= 1")
+	if len(any) > 0 {
+		ms := make([]string, 0, len(any))
 		for _, t := range any {
-			args = append(args, t)
+			m, a := tagMatch("nt.tag", t)
+			ms = append(ms, m)
+			args = append(args, a...)
 		}
+		hav = append(hav, "MAX(CASE WHEN "+strings.Join(ms, " OR ")+" THEN 1 ELSE 0 END) = 1")
 	}
 	if len(hav) > 0 {
 		sql += "\n  HAVING " + strings.Join(hav, " AND ")
@@ -129,6 +131,7 @@ func buildPrefilter(f filter) prefilter {
 
 // EventLog
 func (s *sqliteStore) Append(ctx context.Context, ev api.Event) error {
+	defer metrics.ObserveDB("append_event", time.Now())
 	tx, owned, err := s.txFor(ctx)
 	if err != nil {
 		return err
@@ -145,7 +148,10 @@ func (s *sqliteStore) Append(ctx context.Context, ev api.Event) error {
 	return nil
 }
 
+// List returns events after the cursor in time order. A non-positive limit
+// returns every remaining event.
 func (s *sqliteStore) List(ctx context.Context, cur api.Cursor, limit int) ([]api.Event, api.Cursor, error) {
+	defer metrics.ObserveDB("list_events", time.Now())
 	// Apply simple cursor and limit.
 	q := `SELECT time, type, id, namespace, payload_type, payload, origin_label, signer_id, sig FROM events`
 	args := []any{}
@@ -154,7 +160,6 @@ func (s *sqliteStore) List(ctx context.Context, cur api.Cursor, limit int) ([]ap
 		args = append(args, cur.After.UTC())
 	}
 	q += ` ORDER BY time ASC`
-	limit = 2000
 	if limit > 0 {
 		q += ` LIMIT ?`
 		args = append(args, limit)
@@ -197,9 +202,21 @@ func (s *sqliteStore) List(ctx context.Context, cur api.Cursor, limit int) ([]ap
 	return out, next, nil
 }
 
+// NamespaceUsage counts stored events and payload bytes for a namespace.
+func (s *sqliteStore) NamespaceUsage(ctx context.Context, namespace string) (Usage, error) {
+	defer metrics.ObserveDB("namespace_usage", time.Now())
+	var u Usage
+	row := s.db.QueryRowContext(ctx, `SELECT COUNT(*), COALESCE(SUM(LENGTH(payload)), 0) FROM events WHERE namespace = ?`, namespace)
+	if err := row.Scan(&u.Events, &u.Bytes); err != nil {
+		return Usage{}, err
+	}
+	return u, nil
+}
+
 func (s *sqliteStore) GetEntry(ctx context.Context, id string) (api.Entry, error) {
+	defer metrics.ObserveDB("get_entry", time.Now())
 	var e api.Entry
-	var tagsJSON string
+	var tagsJSON, fieldsJSON string
 	tx, owned, err := s.txFor(ctx)
 	if err != nil {
 		return api.Entry{}, err
@@ -207,14 +224,15 @@ func (s *sqliteStore) GetEntry(ctx context.Context, id string) (api.Entry, error
 	if owned {
 		defer tx.Rollback()
 	}
-	row := tx.QueryRowContext(ctx, `SELECT id, version, title, body, tags, created_at, updated_at, namespace FROM entries WHERE id=?`, id)
-	if err := row.Scan(&e.ID, &e.Version, &e.Title, &e.Body, &tagsJSON, &e.CreatedAt, &e.UpdatedAt, &e.Namespace); err != nil {
+	row := tx.QueryRowContext(ctx, `SELECT id, version, title, body, tags, created_at, updated_at, namespace, fields FROM entries WHERE id=?`, id)
+	if err := row.Scan(&e.ID, &e.Version, &e.Title, &e.Body, &tagsJSON, &e.CreatedAt, &e.UpdatedAt, &e.Namespace, &fieldsJSON); err != nil {
 		if err == sql.ErrNoRows {
 			return api.Entry{}, ErrNotFound
 		}
 		return api.Entry{}, err
 	}
 	_ = json.Unmarshal([]byte(tagsJSON), &e.Tags)
+	e.Fields = decodeFields(fieldsJSON)
 	if owned {
 		if err := tx.Commit(); err != nil {
 			return api.Entry{}, err
@@ -224,6 +242,7 @@ func (s *sqliteStore) GetEntry(ctx context.Context, id string) (api.Entry, error
 }
 
 func (s *sqliteStore) CreateEntry(ctx context.Context, e api.Entry) (api.Entry, error) {
+	defer metrics.ObserveDB("create_entry", time.Now())
 	if e.ID == "" {
 		return api.Entry{}, ErrConflict
 	}
@@ -232,6 +251,10 @@ func (s *sqliteStore) CreateEntry(ctx context.Context, e api.Entry) (api.Entry,
 	}
 	tagsJSON, _ := json.Marshal(e.Tags)
 	tagsTokens := strings.Join(e.Tags, " ")
+	fieldsText, err := encodeFields(e.Fields)
+	if err != nil {
+		return api.Entry{}, err
+	}
 	tx, owned, err := s.txFor(ctx)
 	if err != nil {
 		return api.Entry{}, err
@@ -240,8 +263,8 @@ func (s *sqliteStore) CreateEntry(ctx context.Context, e api.Entry) (api.Entry,
 		defer tx.Rollback()
 	}
 
-	if _, err = tx.ExecContext(ctx, `INSERT INTO entries(id, version, title, body, tags, created_at, updated_at, namespace) VALUES(?,?,?,?,?,?,?,?)`,
-		e.ID, e.Version, e.Title, e.Body, string(tagsJSON), e.CreatedAt.UTC(), e.UpdatedAt.UTC(), e.Namespace); err != nil {
+	if _, err = tx.ExecContext(ctx, `INSERT INTO entries(id, version, title, body, tags, created_at, updated_at, namespace, fields) VALUES(?,?,?,?,?,?,?,?,?)`,
+		e.ID, e.Version, e.Title, e.Body, string(tagsJSON), e.CreatedAt.UTC(), e.UpdatedAt.UTC(), e.Namespace, fieldsText); err != nil {
 		if strings.Contains(err.Error(), "UNIQUE") {
 			err = ErrConflict
 		}
@@ -251,6 +274,15 @@ func (s *sqliteStore) CreateEntry(ctx context.Context, e api.Entry) (api.Entry,
 	if err = upsertNoteTags(ctx, tx, e.ID, e.Tags); err != nil {
 		return api.Entry{}, err
 	}
+	if err = upsertNoteLinks(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
+	if err = upsertNoteFields(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
+	if err = recordTagUse(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
 	// Event
 	if shouldLog(ctx) {
 		if err = appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventUpsert, ID: e.ID, Entry: &e}); err != nil {
@@ -261,6 +293,15 @@ func (s *sqliteStore) CreateEntry(ctx context.Context, e api.Entry) (api.Entry,
 	if _, err = tx.ExecContext(ctx, `INSERT INTO entries_fts(rowid, title, body, tags, namespace, id) VALUES((SELECT rowid FROM entries WHERE id=?), ?, ?, ?, ?, ?)`, e.ID, e.Title, e.Body, tagsTokens, e.Namespace, e.ID); err != nil {
 		return api.Entry{}, err
 	}
+	if err = indexTrigrams(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
+	if err = indexTerms(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
+	if err = indexFingerprint(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
 	if owned {
 		if err := tx.Commit(); err != nil {
 			return api.Entry{}, err
@@ -270,8 +311,13 @@ func (s *sqliteStore) CreateEntry(ctx context.Context, e api.Entry) (api.Entry,
 }
 
 func (s *sqliteStore) UpdateEntryCAS(ctx context.Context, e api.Entry, ifVersion int64) (api.Entry, error) {
+	defer metrics.ObserveDB("update_entry", time.Now())
 	tagsJSON, _ := json.Marshal(e.Tags)
 	tagsTokens := strings.Join(e.Tags, " ")
+	fieldsText, err := encodeFields(e.Fields)
+	if err != nil {
+		return api.Entry{}, err
+	}
 	tx, owned, err := s.txFor(ctx)
 	if err != nil {
 		return api.Entry{}, err
@@ -280,13 +326,22 @@ func (s *sqliteStore) UpdateEntryCAS(ctx context.Context, e api.Entry, ifVersion
 		defer tx.Rollback()
 	}
 
-	// Update using explicit version from 'e'
-	res, err := tx.ExecContext(ctx, `UPDATE entries SET version=?, title=?, body=?, tags=?, updated_at=?, namespace=? WHERE id=? AND version=?`,
-		e.Version, e.Title, e.Body, string(tagsJSON), e.UpdatedAt.UTC(), e.Namespace, e.ID, ifVersion)
+	// Update using explicit version from 'e'; a zero CreatedAt keeps the stored one.
+	var createdAt any
+	if !e.CreatedAt.IsZero() {
+		createdAt = e.CreatedAt.UTC()
+	}
+	res, err := tx.ExecContext(ctx, `UPDATE entries SET version=?, title=?, body=?, tags=?, created_at=COALESCE(?, created_at), updated_at=?, namespace=?, fields=? WHERE id=? AND version=?`,
+		e.Version, e.Title, e.Body, string(tagsJSON), createdAt, e.UpdatedAt.UTC(), e.Namespace, fieldsText, e.ID, ifVersion)
 	if err != nil {
 		return api.Entry{}, err
 	}
 	if n, _ := res.RowsAffected(); n == 0 {
+		source := "edit"
+		if !shouldLog(ctx) {
+			source = "replication"
+		}
+		metrics.Conflicts.WithLabelValues(source).Inc()
 		return api.Entry{}, ErrConflict
 	}
 
@@ -297,15 +352,25 @@ func (s *sqliteStore) UpdateEntryCAS(ctx context.Context, e api.Entry, ifVersion
 	if err = upsertNoteTags(ctx, tx, e.ID, e.Tags); err != nil {
 		return api.Entry{}, err
 	}
+	if err = upsertNoteLinks(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
+	if err = upsertNoteFields(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
+	if err = recordTagUse(ctx, tx, e); err != nil {
+		return api.Entry{}, err
+	}
 
 	// Read back current entry
 	var ne api.Entry
-	var tagsJSONBack string
-	row := tx.QueryRowContext(ctx, `SELECT id, version, title, body, tags, created_at, updated_at, namespace FROM entries WHERE id=?`, e.ID)
-	if err = row.Scan(&ne.ID, &ne.Version, &ne.Title, &ne.Body, &tagsJSONBack, &ne.CreatedAt, &ne.UpdatedAt, &ne.Namespace); err != nil {
+	var tagsJSONBack, fieldsJSON string
+	row := tx.QueryRowContext(ctx, `SELECT id, version, title, body, tags, created_at, updated_at, namespace, fields FROM entries WHERE id=?`, e.ID)
+	if err = row.Scan(&ne.ID, &ne.Version, &ne.Title, &ne.Body, &tagsJSONBack, &ne.CreatedAt, &ne.UpdatedAt, &ne.Namespace, &fieldsJSON); err != nil {
 		return api.Entry{}, err
 	}
 	_ = json.Unmarshal([]byte(tagsJSONBack), &ne.Tags)
+	ne.Fields = decodeFields(fieldsJSON)
 
 	// Refresh FTS
 	if _, err = tx.ExecContext(ctx, `DELETE FROM entries_fts WHERE id=?`, ne.ID); err != nil {
@@ -314,6 +379,15 @@ func (s *sqliteStore) UpdateEntryCAS(ctx context.Context, e api.Entry, ifVersion
 	if _, err = tx.ExecContext(ctx, `INSERT INTO entries_fts(rowid, title, body, tags, namespace, id) VALUES((SELECT rowid FROM entries WHERE id=?), ?, ?, ?, ?, ?)`, ne.ID, ne.Title, ne.Body, tagsTokens, ne.Namespace, ne.ID); err != nil {
 		return api.Entry{}, err
 	}
+	if err = indexTrigrams(ctx, tx, ne); err != nil {
+		return api.Entry{}, err
+	}
+	if err = indexTerms(ctx, tx, ne); err != nil {
+		return api.Entry{}, err
+	}
+	if err = indexFingerprint(ctx, tx, ne); err != nil {
+		return api.Entry{}, err
+	}
 	// Append event
 	if shouldLog(ctx) {
 		if err = appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventUpsert, ID: ne.ID, Entry: &ne}); err != nil {
@@ -329,6 +403,7 @@ func (s *sqliteStore) UpdateEntryCAS(ctx context.Context, e api.Entry, ifVersion
 }
 
 func (s *sqliteStore) DeleteEntry(ctx context.Context, id string) error {
+	defer metrics.ObserveDB("delete_entry", time.Now())
 	tx, owned, err := s.txFor(ctx)
 	if err != nil {
 		return err
@@ -343,6 +418,15 @@ func (s *sqliteStore) DeleteEntry(ctx context.Context, id string) error {
 		}
 		return err
 	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM entries_trigram WHERE rowid=(SELECT rowid FROM entries WHERE id=?)`, id); err != nil {
+		return err
+	}
+	if err = unindexTerms(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM entry_fingerprints WHERE entry_id=?`, id); err != nil {
+		return err
+	}
 	res, err := tx.ExecContext(ctx, `DELETE FROM entries WHERE id=?`, id)
 	if err != nil {
 		return err
@@ -356,6 +440,16 @@ func (s *sqliteStore) DeleteEntry(ctx context.Context, id string) error {
 	if _, err = tx.ExecContext(ctx, `DELETE FROM note_tags WHERE note_id=?`, id); err != nil {
 		return err
 	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM note_links WHERE note_id=?`, id); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `DELETE FROM note_fields WHERE note_id=?`, id); err != nil {
+		return err
+	}
+	// The note's delete event removes its reminder on other devices too.
+	if _, err = tx.ExecContext(ctx, `DELETE FROM reminders WHERE note_id=?`, id); err != nil {
+		return err
+	}
 	if shouldLog(ctx) {
 		if err = appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventDelete, ID: id, Namespace: ns}); err != nil {
 			return err
@@ -368,6 +462,7 @@ func (s *sqliteStore) DeleteEntry(ctx context.Context, id string) error {
 }
 
 func (s *sqliteStore) DeleteNamespace(ctx context.Context, namespace string) (int64, error) {
+	defer metrics.ObserveDB("delete_namespace", time.Now())
 	if strings.TrimSpace(namespace) == "" {
 		return 0, fmt.Errorf("namespace is required")
 	}
@@ -400,6 +495,36 @@ func (s *sqliteStore) DeleteNamespace(ctx context.Context, namespace string) (in
 	if _, err := tx.ExecContext(ctx, `DELETE FROM entries_fts WHERE namespace=?`, namespace); err != nil {
 		return 0, err
 	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM entries_trigram WHERE rowid IN (SELECT rowid FROM entries WHERE namespace=?)`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM entry_terms WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM term_df WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM entry_fingerprints WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tag_usage WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM note_links WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM note_fields WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM views WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reminders WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM drafts WHERE namespace=?`, namespace); err != nil {
+		return 0, err
+	}
 	res, err := tx.ExecContext(ctx, `DELETE FROM entries WHERE namespace=?`, namespace)
 	if err != nil {
 		return 0, err
@@ -425,6 +550,7 @@ func (s *sqliteStore) DeleteNamespace(ctx context.Context, namespace string) (in
 // ListEntries retrieves entries based on provided filters, including namespace, time ranges, and tags.
 // Note: By default, this summary listing does not load the entry body; set IncludeBody to include it.
 func (s *sqliteStore) ListEntries(ctx context.Context, q api.ListQuery) ([]api.Entry, api.Page, error) {
+	defer metrics.ObserveDB("list_entries", time.Now())
 	limit := q.Limit
 	if limit <= 0 {
 		limit = 1000
@@ -433,25 +559,48 @@ func (s *sqliteStore) ListEntries(ctx context.Context, q api.ListQuery) ([]api.E
 	if q.IncludeBody {
 		bodySelect = "e.body"
 	}
+	cq, err := compileQuery(q.Query)
+	if err != nil {
+		return nil, api.Page{}, err
+	}
+	where, whereArgs := cq.predicate()
 	pf := buildPrefilter(filter{
 		Namespace: q.Namespace,
 		Since:     q.Since,
 		Until:     q.Until,
 		Any:       q.Any,
 		All:       q.All,
+		Where:     where,
+		WhereArgs: whereArgs,
 	})
 	cursor, hasCursor := parseCursorToken(q.Cursor)
 	cursorClause, cursorArgs := cursorWhereClause(cursor, hasCursor, q.Reverse)
 	orderClause := orderByClause(q.Reverse)
+	// Sorting by a field pages with offset cursors, like relevance search.
+	var sortJoin string
+	var sortArgs []any
+	offset := 0
+	if q.Sort != "" {
+		if q.Reverse {
+			return nil, api.Page{}, fmt.Errorf("a list sorted by field cannot page backwards")
+		}
+		if sortJoin, orderClause, sortArgs, err = fieldOrder(q.Sort); err != nil {
+			return nil, api.Page{}, err
+		}
+		cursorClause, cursorArgs = "", nil
+		offset = parseOffsetCursor(q.Cursor)
+	}
 	pageLimit := limit + 1
-	sqlq := pf.CTE + `SELECT e.id, e.version, e.title, ` + bodySelect + `, e.tags, e.created_at, e.updated_at, e.namespace
+	sqlq := pf.CTE + `SELECT e.id, e.version, e.title, ` + bodySelect + `, e.tags, e.created_at, e.updated_at, e.namespace, e.fields
 FROM filtered f
 JOIN entries e ON e.id = f.id
+` + sortJoin + `
 ` + cursorClause + `
 ` + orderClause + `
-LIMIT ?`
-	args := append(pf.Args, cursorArgs...)
-	args = append(args, pageLimit)
+LIMIT ? OFFSET ?`
+	args := append(pf.Args, sortArgs...)
+	args = append(args, cursorArgs...)
+	args = append(args, pageLimit, offset)
 
 	rows, err := s.db.QueryContext(ctx, sqlq, args...)
 	if err != nil {
@@ -462,17 +611,21 @@ LIMIT ?`
 	var out []api.Entry
 	for rows.Next() {
 		var e api.Entry
-		var tagsJSON string
-		if err := rows.Scan(&e.ID, &e.Version, &e.Title, &e.Body, &tagsJSON, &e.CreatedAt, &e.UpdatedAt, &e.Namespace); err != nil {
+		var tagsJSON, fieldsJSON string
+		if err := rows.Scan(&e.ID, &e.Version, &e.Title, &e.Body, &tagsJSON, &e.CreatedAt, &e.UpdatedAt, &e.Namespace, &fieldsJSON); err != nil {
 			return nil, api.Page{}, err
 		}
 		_ = json.Unmarshal([]byte(tagsJSON), &e.Tags)
+		e.Fields = decodeFields(fieldsJSON)
 		out = append(out, e)
 	}
 	hasMore := len(out) > limit
 	if hasMore {
 		out = out[:limit]
 	}
+	if q.Sort != "" {
+		return out, rankedPage(offset, len(out), limit, hasMore), nil
+	}
 	if q.Reverse {
 		reverseEntries(out)
 	}
@@ -480,140 +633,177 @@ LIMIT ?`
 	return out, page, nil
 }
 func (s *sqliteStore) Search(ctx context.Context, q api.SearchQuery) ([]api.Entry, api.Page, error) {
+	defer metrics.ObserveDB("search", time.Now())
+	entries, _, page, err := s.search(ctx, q)
+	return entries, page, err
+}
+
+// SearchHits runs a search like Search and returns each match with its
+// BM25 score and highlighted snippets (regex searches carry neither).
+func (s *sqliteStore) SearchHits(ctx context.Context, q api.SearchQuery) ([]api.SearchHit, api.Page, error) {
+	defer metrics.ObserveDB("search_hits", time.Now())
+	entries, matches, page, err := s.search(ctx, q)
+	if err != nil {
+		return nil, api.Page{}, err
+	}
+	byID := make(map[string]ftsMatch, len(matches))
+	for _, m := range matches {
+		byID[m.id] = m
+	}
+	hits := make([]api.SearchHit, 0, len(entries))
+	for _, e := range entries {
+		m := byID[e.ID]
+		hits = append(hits, api.SearchHit{Entry: e, Score: m.score, Snippets: m.snippets()})
+	}
+	return hits, page, nil
+}
+
+func (s *sqliteStore) search(ctx context.Context, q api.SearchQuery) ([]api.Entry, []ftsMatch, api.Page, error) {
 	limit := q.Limit
 	if limit <= 0 {
 		limit = 500
 	}
 	var ids []string
-	var hasMore bool
+	var res ftsResult
 	var err error
+	start := time.Now()
 	if q.Regex {
-		ids, hasMore, err = s.searchRegex(ctx, q, limit)
+		ids, res.hasMore, err = s.searchRegex(ctx, q, limit)
+		metrics.SearchDuration.WithLabelValues("regex").Observe(time.Since(start).Seconds())
 	} else {
-		ids, hasMore, err = s.searchFTS(ctx, q, limit)
+		res, err = s.searchFTS(ctx, q, limit)
+		metrics.SearchDuration.WithLabelValues("fts").Observe(time.Since(start).Seconds())
+		for _, m := range res.matches {
+			ids = append(ids, m.id)
+		}
 	}
 	if err != nil {
-		return nil, api.Page{}, err
+		return nil, nil, api.Page{}, err
 	}
 	entries, _, err := s.fetchEntriesByIDs(ctx, ids)
 	if err != nil {
-		return nil, api.Page{}, err
+		return nil, nil, api.Page{}, err
+	}
+	if res.ranked {
+		return entries, res.matches, rankedPage(res.offset, len(ids), limit, res.hasMore), nil
 	}
 	if q.Reverse {
 		reverseEntries(entries)
 	}
-	page := buildPage(entries, hasMore, q.Reverse, q.Cursor != "")
-	return entries, page, nil
+	page := buildPage(entries, res.hasMore, q.Reverse, q.Cursor != "")
+	return entries, res.matches, page, nil
 }
 
-func (s *sqliteStore) searchFTS(ctx context.Context, q api.SearchQuery, limit int) ([]string, bool, error) {
-	pf := buildPrefilter(filter{Namespace: q.Namespace, Since: q.Since, Until: q.Until, Any: q.Any, All: q.All})
-	cursor, hasCursor := parseCursorToken(q.Cursor)
-	cursorClause, cursorArgs := cursorWhereClause(cursor, hasCursor, q.Reverse)
-	orderClause := orderByClause(q.Reverse)
+// searchFTS runs a query-language search. Top-level text terms go to the
+// entries_fts MATCH; tags, dates and other predicates go to the prefilter.
+// With text terms present, rows carry a BM25 score and marked fragments, and
+// SortBy relevance orders by score with offset cursors.
+func (s *sqliteStore) searchFTS(ctx context.Context, q api.SearchQuery, limit int) (ftsResult, error) {
+	cq, err := compileQuery(q.Query)
+	if err != nil {
+		return ftsResult{}, err
+	}
+	pf := buildPrefilter(filter{Namespace: q.Namespace, Since: q.Since, Until: q.Until, Any: q.Any, All: q.All, Where: cq.Where, WhereArgs: cq.Args})
+	res := ftsResult{ranked: q.SortBy == api.SortRelevance && cq.FTS != ""}
+	var cursorClause, orderClause string
+	var cursorArgs []any
+	if res.ranked {
+		res.offset = parseOffsetCursor(q.Cursor)
+		orderClause = "ORDER BY score DESC, e.id ASC"
+	} else {
+		cursor, hasCursor := parseCursorToken(q.Cursor)
+		cursorClause, cursorArgs = cursorWhereClause(cursor, hasCursor, q.Reverse)
+		orderClause = orderByClause(q.Reverse)
+	}
 	pageLimit := limit + 1
-	sqlq := pf.CTE + `SELECT e.id
+	sqlq := pf.CTE + "SELECT e.id, 0.0 AS score, '', '', ''\nFROM filtered f\nJOIN entries e ON e.id = f.id\n"
+	if cq.FTS != "" {
+		sqlq = pf.CTE + `SELECT e.id, -bm25(x.entries_fts, ` + bm25Weights + `) AS score,
+  highlight(x.entries_fts, 0, char(2), char(3)),
+  snippet(x.entries_fts, 1, char(2), char(3), '…', ` + itoa(snippetTokens) + `),
+  highlight(x.entries_fts, 2, char(2), char(3))
 FROM filtered f
 JOIN entries e ON e.id = f.id
 JOIN entries_fts x ON x.id = e.id
 `
-	// If tag constraints exist, enforce precise Any/All via HAVING over a fresh tag join.
-	needTags := len(q.All) > 0 || len(q.Any) > 0
-	if needTags {
-		sqlq += "JOIN note_tags nt2 ON nt2.note_id = e.id\n"
 	}
-	sqlq += "WHERE x.entries_fts MATCH ?\n"
+	// Tag Any/All constraints are enforced by the prefilter CTE.
 	args := append([]any{}, pf.Args...)
-	args = append(args, q.Query)
+	conds := []string{}
+	if cq.FTS != "" {
+		conds = append(conds, "x.entries_fts MATCH ?")
+		args = append(args, cq.FTS)
+	}
 	if cursorClause != "" {
-		sqlq += "AND " + strings.TrimPrefix(cursorClause, "WHERE ") + "\n"
+		conds = append(conds, strings.TrimPrefix(cursorClause, "WHERE "))
 		args = append(args, cursorArgs...)
 	}
-	if needTags {
-		sqlq += "GROUP BY e.id\nHAVING "
-		hav := []string{}
-		if l := len(q.All); l > 0 {
-			ph := make([]string, 0, l)
-			for range q.All {
-				ph = append(ph, "?")
-			}
-			hav = append(hav, "SUM(CASE WHEN nt2.tag IN ("+strings.Join(ph, ",")+") THEN 1 ELSE 0 END) = "+itoa(l))
-			for _, t := range q.All {
-				args = append(args, t)
-			}
-		}
-		if l := len(q.Any); l > 0 {
-			ph := make([]string, 0, l)
-			for range q.Any {
-				ph = append(ph, "?")
-			}
-			hav = append(hav, "SUM(CASE WHEN nt2.tag IN ("+strings.Join(ph, ",")+") THEN 1 ELSE 0 END) >= 1")
-			for _, t := range q.Any {
-				args = append(args, t)
-			}
-		}
-		sqlq += strings.Join(hav, " AND ") + "\n"
+	if len(conds) > 0 {
+		sqlq += "WHERE " + strings.Join(conds, " AND ") + "\n"
 	}
 	sqlq += orderClause + "\nLIMIT ?"
 	args = append(args, pageLimit)
+	if res.ranked {
+		sqlq += " OFFSET ?"
+		args = append(args, res.offset)
+	}
 	rows, err := s.db.QueryContext(ctx, sqlq, args...)
 	if err != nil {
-		return nil, false, err
+		return ftsResult{}, err
 	}
 	defer rows.Close()
-	var ids []string
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, false, err
+		var m ftsMatch
+		if err := rows.Scan(&m.id, &m.score, &m.title, &m.body, &m.tags); err != nil {
+			return ftsResult{}, err
 		}
-		ids = append(ids, id)
+		res.matches = append(res.matches, m)
 	}
-	hasMore := len(ids) > limit
-	if hasMore {
-		ids = ids[:limit]
+	if err := rows.Err(); err != nil {
+		return ftsResult{}, err
 	}
-	return ids, hasMore, nil
+	res.hasMore = len(res.matches) > limit
+	if res.hasMore {
+		res.matches = res.matches[:limit]
+	}
+	return res, nil
 }
 
+// searchRegex matches the pattern against candidates in page order until
+// limit+1 matches are found. Candidates are narrowed by the trigram index to
+// notes containing the literal text every match requires; patterns without
+// such text scan the filtered notes.
 func (s *sqliteStore) searchRegex(ctx context.Context, q api.SearchQuery, limit int) ([]string, bool, error) {
-	token := longestWord(q.Query)
-	pf := buildPrefilter(filter{Namespace: q.Namespace, Since: q.Since, Until: q.Until, Any: q.Any, All: q.All})
-	// Candidate cap to keep resource usage bounded
-	cand := limit * 20
-	if cand < limit {
-		cand = limit
+	re, err := compileRegex(q.Query)
+	if err != nil {
+		return nil, false, err
 	}
+	trigrams, err := regexTrigrams(q.Query)
+	if err != nil {
+		return nil, false, err
+	}
+	f := filter{Namespace: q.Namespace, Since: q.Since, Until: q.Until, Any: q.Any, All: q.All}
+	if trigrams != "" {
+		f.Where = "e.rowid IN (SELECT rowid FROM entries_trigram WHERE entries_trigram MATCH ?)"
+		f.WhereArgs = []any{trigrams}
+	}
+	pf := buildPrefilter(f)
 	cursor, hasCursor := parseCursorToken(q.Cursor)
 	cursorClause, cursorArgs := cursorWhereClause(cursor, hasCursor, q.Reverse)
-	orderClause := orderByClause(q.Reverse)
 	sqlq := pf.CTE + `SELECT e.id, e.title, e.body, e.tags
 FROM filtered f
 JOIN entries e ON e.id = f.id`
 	args := append([]any{}, pf.Args...)
-	if token != "" {
-		sqlq += "\nJOIN entries_fts x ON x.id = e.id\nWHERE x.entries_fts MATCH ?"
-		args = append(args, token)
-	} else if cursorClause != "" {
+	if cursorClause != "" {
 		sqlq += "\n" + cursorClause
-	}
-	if token != "" && cursorClause != "" {
-		sqlq += "\nAND " + strings.TrimPrefix(cursorClause, "WHERE ")
-		args = append(args, cursorArgs...)
-	} else if cursorClause != "" {
 		args = append(args, cursorArgs...)
 	}
-	sqlq += "\n" + orderClause + "\nLIMIT ?"
-	args = append(args, cand)
+	sqlq += "\n" + orderByClause(q.Reverse)
 	rows, err := s.db.QueryContext(ctx, sqlq, args...)
 	if err != nil {
 		return nil, false, err
 	}
 	defer rows.Close()
-	re, err := compileRegex(q.Query)
-	if err != nil {
-		return nil, false, err
-	}
 	out := make([]string, 0, limit+1)
 	for rows.Next() {
 		var id, title, body, tagsJSON string
@@ -622,14 +812,16 @@ JOIN entries e ON e.id = f.id`
 		}
 		var tags []string
 		_ = json.Unmarshal([]byte(tagsJSON), &tags)
-		hay := title + "\n" + body + "\n" + strings.Join(tags, ",")
-		if re.MatchString(hay) {
+		if re.MatchString(regexHaystack(title, body, tags)) {
 			out = append(out, id)
 			if len(out) >= limit+1 {
 				break
 			}
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
 	hasMore := len(out) > limit
 	if hasMore {
 		out = out[:limit]
@@ -637,10 +829,12 @@ JOIN entries e ON e.id = f.id`
 	return out, hasMore, nil
 }
 
-// ListTags returns tag counts (per namespace if provided) with optional prefix filter.
+// ListTags returns tag counts and frecency (per namespace if provided) with
+// optional prefix filter, by count unless q.SortBy is "frecency".
 func (s *sqliteStore) ListTags(ctx context.Context, q api.TagsQuery) ([]api.TagStat, error) {
-	var args []any
-	sqlq := `SELECT nt.tag, COUNT(DISTINCT nt.note_id) as cnt, COALESCE(t.description, '')
+	defer metrics.ObserveDB("list_tags", time.Now())
+	args := []any{time.Now().Unix(), q.Namespace, q.Namespace}
+	sqlq := `SELECT nt.tag, COUNT(DISTINCT nt.note_id) as cnt, COALESCE(t.description, ''), ` + frecencyScore("nt.tag") + ` as frecency
              FROM note_tags nt
              JOIN entries e ON e.id = nt.note_id
              LEFT JOIN tags t ON t.tag = nt.tag`
@@ -656,7 +850,11 @@ func (s *sqliteStore) ListTags(ctx context.Context, q api.TagsQuery) ([]api.TagS
 	if len(conds) > 0 {
 		sqlq += " WHERE " + strings.Join(conds, " AND ")
 	}
-	sqlq += " GROUP BY nt.tag ORDER BY cnt DESC, nt.tag ASC"
+	if q.SortBy == "frecency" {
+		sqlq += " GROUP BY nt.tag ORDER BY frecency DESC, cnt DESC, nt.tag ASC"
+	} else {
+		sqlq += " GROUP BY nt.tag ORDER BY cnt DESC, nt.tag ASC"
+	}
 	if q.Limit > 0 {
 		sqlq += " LIMIT ?"
 		args = append(args, q.Limit)
@@ -669,7 +867,7 @@ func (s *sqliteStore) ListTags(ctx context.Context, q api.TagsQuery) ([]api.TagS
 	var out []api.TagStat
 	for rows.Next() {
 		var t api.TagStat
-		if err := rows.Scan(&t.Tag, &t.Count, &t.Description); err != nil {
+		if err := rows.Scan(&t.Tag, &t.Count, &t.Description, &t.Frecency); err != nil {
 			return nil, err
 		}
 		out = append(out, t)
@@ -678,6 +876,7 @@ func (s *sqliteStore) ListTags(ctx context.Context, q api.TagsQuery) ([]api.TagS
 }
 
 func (s *sqliteStore) ListNamespaces(ctx context.Context) ([]string, error) {
+	defer metrics.ObserveDB("list_namespaces", time.Now())
 	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT namespace FROM entries ORDER BY namespace ASC`)
 	if err != nil {
 		return nil, err
@@ -778,31 +977,6 @@ func buildPage(entries []api.Entry, hasMore bool, reverse bool, hasCursor bool)
 	return page
 }
 
-func longestWord(s string) string {
-	s = strings.ToLower(s)
-	best := ""
-	run := make([]rune, 0, 32)
-
-	flush := func() {
-		if len(run) >= 3 {
-			if len(run) > len(best) {
-				best = string(run)
-			}
-		}
-		run = run[:0]
-	}
-
-	for _, r := range s {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			run = append(run, r)
-		} else {
-			flush()
-		}
-	}
-	flush()
-	return best
-}
-
 func compileRegex(p string) (*regexp.Regexp, error) { return regexp.Compile(p) }
 
 // openSQLite connects to a SQLite database using modernc.org/sqlite driver and ensures schema exists.
@@ -835,7 +1009,7 @@ func openSQLite(ctx context.Context, dsn string) (*Store, io.Closer, error) {
 		return nil, nil, err
 	}
 	s := &sqliteStore{db: dbh}
-	st := &Store{Events: s, Entries: s}
+	st := &Store{Events: s, Entries: s, Views: s, Reminders: s, Drafts: s}
 	return st, dbh, nil
 }
 
@@ -849,7 +1023,8 @@ CREATE TABLE IF NOT EXISTS entries (
   tags TEXT NOT NULL,
   created_at TIMESTAMP NOT NULL,
   updated_at TIMESTAMP NOT NULL,
-  namespace TEXT NOT NULL
+  namespace TEXT NOT NULL,
+  fields TEXT NOT NULL DEFAULT ''
 );
 CREATE INDEX IF NOT EXISTS idx_entries_ns_created_id ON entries(namespace, created_at DESC, id);
 CREATE INDEX IF NOT EXISTS idx_entries_title ON entries(title);
@@ -876,16 +1051,109 @@ CREATE TABLE IF NOT EXISTS tags (
   tag TEXT PRIMARY KEY COLLATE NOCASE,
   description TEXT DEFAULT ''
 );
+-- Saved views; spec is the api.View as JSON.
+CREATE TABLE IF NOT EXISTS views (
+  namespace TEXT NOT NULL,
+  name TEXT NOT NULL COLLATE NOCASE,
+  spec TEXT NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  PRIMARY KEY(namespace, name)
+);
+-- Note reminders; spec is the api.Reminder as JSON, at its time in Unix
+-- seconds.
+CREATE TABLE IF NOT EXISTS reminders (
+  note_id TEXT PRIMARY KEY,
+  namespace TEXT NOT NULL,
+  at INTEGER NOT NULL,
+  fired INTEGER NOT NULL DEFAULT 0,
+  spec TEXT NOT NULL,
+  updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_reminders_pending ON reminders(fired, at);
+-- Editor drafts of this device; content is the latest snapshot of path.
+CREATE TABLE IF NOT EXISTS drafts (
+  id TEXT PRIMARY KEY,
+  namespace TEXT NOT NULL,
+  path TEXT NOT NULL,
+  base_version INTEGER NOT NULL DEFAULT 0,
+  content TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL
+);
 CREATE VIRTUAL TABLE IF NOT EXISTS entries_fts USING fts5(
   title, body, tags,
   namespace UNINDEXED, id UNINDEXED,
   tokenize='unicode61'
 );
+-- Indexed terms per column, for query spelling correction.
+CREATE VIRTUAL TABLE IF NOT EXISTS entries_vocab USING fts5vocab(entries_fts, 'col');
 `)
 	if err != nil {
 		return err
 	}
-	return ensureEventColumns(ctx, db)
+	if err := ensureEventColumns(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureTrigramIndex(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureTermIndex(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureFingerprintIndex(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureLinkIndex(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureFieldIndex(ctx, db); err != nil {
+		return err
+	}
+	if err := ensureTagUsage(ctx, db); err != nil {
+		return err
+	}
+	// Created after ensureEventColumns so older logs have the column.
+	_, err = db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS idx_events_namespace ON events(namespace)`)
+	return err
+}
+
+// ensureTrigramIndex creates the regex search index (see trigram.go),
+// filling it from existing entries the first time.
+func ensureTrigramIndex(ctx context.Context, db *sql.DB) error {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type='table' AND name='entries_trigram'`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `
+CREATE VIRTUAL TABLE entries_trigram USING fts5(
+  hay,
+  tokenize='trigram', content='', contentless_delete=1
+);
+INSERT INTO entries_trigram(rowid, hay)
+SELECT e.rowid, e.title || char(10) || e.body || char(10) ||
+  COALESCE((SELECT group_concat(j.value, ',') FROM json_each(e.tags) j), '')
+FROM entries e;
+`); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// indexTrigrams replaces the regex search index row for e.
+func indexTrigrams(ctx context.Context, tx *sql.Tx, e api.Entry) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM entries_trigram WHERE rowid=(SELECT rowid FROM entries WHERE id=?)`, e.ID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO entries_trigram(rowid, hay) VALUES((SELECT rowid FROM entries WHERE id=?), ?)`, e.ID, regexHaystack(e.Title, e.Body, e.Tags))
+	return err
 }
 
 func ensureEventColumns(ctx context.Context, db *sql.DB) error {
@@ -952,7 +1220,23 @@ func appendEventTx(ctx context.Context, tx *sql.Tx, ev api.Event) error {
 					return err
 				}
 			}
-		case api.EventDelete:
+		case api.EventViewUpsert:
+			if ev.View != nil {
+				payloadType = "plain_v1"
+				payload, err = json.Marshal(ev.View)
+				if err != nil {
+					return err
+				}
+			}
+		case api.EventReminderUpsert:
+			if ev.Reminder != nil {
+				payloadType = "plain_v1"
+				payload, err = json.Marshal(ev.Reminder)
+				if err != nil {
+					return err
+				}
+			}
+		case api.EventDelete, api.EventViewDelete, api.EventReminderDelete:
 			payloadType = "plain_v1"
 			dp := struct {
 				ID        string `json:"id"`
@@ -15,24 +15,3 @@ func TestUniqueStrings(t *testing.T) {
 		}
 	}
 }
-
-func TestLongestWord(t *testing.T) {
-	tests := []struct {
-		in   string
-		want string
-	}{
-		{"", ""},
-		{"hi", ""},
-		{"a bc de", ""},
-		{"foo bar-baz", "foo"},
-		{"Hello, world!!! 1234 12", "hello"},
-		{"über-cool naïve café123", "café123"},
-		{"abc😊def ghi", "abc"},
-		{"go123go4567", "go123go4567"},
-	}
-	for i, tc := range tests {
-		if got := longestWord(tc.in); got != tc.want {
-			t.Fatalf("case %d: got %q want %q", i, got, tc.want)
-		}
-	}
-}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mithrel/ginkgo/internal/query"
+)
+
+// ftsColumns limits unfielded terms to note content, leaving out the id and
+// namespace columns of entries_fts.
+const ftsColumns = "{title body tags}"
+
+// compiledQuery is a search expression lowered to SQLite. FTS is a MATCH
+// expression for the top-level text terms; Where (with Args) is a predicate
+// over entries e for tags, dates, negations and mixed OR groups, and is
+// added to the prefilter CTE.
+type compiledQuery struct {
+	FTS   string
+	Where string
+	Args  []any
+}
+
+// compileQuery parses src with the query language. An empty src compiles to
+// an empty query; parse failures are returned as *query.SyntaxError.
+func compileQuery(src string) (compiledQuery, error) {
+	if strings.TrimSpace(src) == "" {
+		return compiledQuery{}, nil
+	}
+	n, err := query.Parse(src)
+	if err != nil {
+		return compiledQuery{}, err
+	}
+	var cq compiledQuery
+	var fts, where []string
+	for _, c := range query.Conjuncts(n) {
+		if isText(c) {
+			fts = append(fts, ftsExpr(c))
+			continue
+		}
+		sql, args := sqlExpr(c)
+		where = append(where, sql)
+		cq.Args = append(cq.Args, args...)
+	}
+	cq.FTS = strings.Join(fts, " AND ")
+	cq.Where = strings.Join(where, " AND ")
+	return cq, nil
+}
+
+// predicate folds the FTS expression into Where for queries that do not
+// join entries_fts themselves.
+func (c compiledQuery) predicate() (string, []any) {
+	if c.FTS == "" {
+		return c.Where, c.Args
+	}
+	sql := ftsMember(true)
+	if c.Where == "" {
+		return sql, []any{c.FTS}
+	}
+	return sql + " AND " + c.Where, append([]any{c.FTS}, c.Args...)
+}
+
+// isText reports whether n can be expressed as a single FTS5 expression.
+// FTS5 has no unary NOT, so negations are handled in SQL.
+func isText(n query.Node) bool {
+	switch x := n.(type) {
+	case *query.Term:
+		return true
+	case *query.And:
+		return allText(x.Nodes)
+	case *query.Or:
+		return allText(x.Nodes)
+	}
+	return false
+}
+
+func allText(nodes []query.Node) bool {
+	for _, c := range nodes {
+		if !isText(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ftsExpr renders a text-only node in FTS5 syntax. Every term is quoted, so
+// user input cannot inject FTS operators.
+func ftsExpr(n query.Node) string {
+	switch x := n.(type) {
+	case *query.Term:
+		s := `"` + strings.ReplaceAll(x.Text, `"`, `""`) + `"`
+		if x.Prefix {
+			s += " *"
+		}
+		col := ftsColumns
+		if x.Field != "" {
+			col = x.Field
+		}
+		return col + " : " + s
+	case *query.And:
+		return "(" + joinFTS(x.Nodes, " AND ") + ")"
+	case *query.Or:
+		return "(" + joinFTS(x.Nodes, " OR ") + ")"
+	}
+	return ""
+}
+
+func joinFTS(nodes []query.Node, sep string) string {
+	parts := make([]string, 0, len(nodes))
+	for _, c := range nodes {
+		parts = append(parts, ftsExpr(c))
+	}
+	return strings.Join(parts, sep)
+}
+
+func ftsMember(in bool) string {
+	op := "IN"
+	if !in {
+		op = "NOT IN"
+	}
+	return "e.rowid " + op + " (SELECT rowid FROM entries_fts WHERE entries_fts MATCH ?)"
+}
+
+// sqlExpr renders n as a predicate over entries e.
+func sqlExpr(n query.Node) (string, []any) {
+	if isText(n) {
+		return ftsMember(true), []any{ftsExpr(n)}
+	}
+	switch x := n.(type) {
+	case *query.Tag:
+		if x.Prefix {
+			return `EXISTS (SELECT 1 FROM note_tags qt WHERE qt.note_id = e.id AND qt.tag LIKE ? ESCAPE '\')`, []any{escapeLike(x.Name) + "%"}
+		}
+		m, args := tagMatch("qt.tag", x.Name)
+		return "EXISTS (SELECT 1 FROM note_tags qt WHERE qt.note_id = e.id AND " + m + ")", args
+	case *query.Date:
+		return dateExpr(x)
+	case *query.Field:
+		return fieldExpr(x)
+	case *query.Not:
+		if isText(x.Node) {
+			return ftsMember(false), []any{ftsExpr(x.Node)}
+		}
+		sql, args := sqlExpr(x.Node)
+		return "NOT (" + sql + ")", args
+	case *query.And:
+		return joinSQL(x.Nodes, " AND ")
+	case *query.Or:
+		return joinSQL(x.Nodes, " OR ")
+	}
+	return "1", nil
+}
+
+func joinSQL(nodes []query.Node, sep string) (string, []any) {
+	parts := make([]string, 0, len(nodes))
+	var args []any
+	for _, c := range nodes {
+		sql, a := sqlExpr(c)
+		parts = append(parts, sql)
+		args = append(args, a...)
+	}
+	return "(" + strings.Join(parts, sep) + ")", args
+}
+
+// dateExpr compares a timestamp column with the period [From, To). For an
+// instant From equals To and the comparisons are exact.
+func dateExpr(d *query.Date) (string, []any) {
+	col := "e.created_at"
+	if d.Field == "updated" {
+		col = "e.updated_at"
+	}
+	from, to := d.From.UTC(), d.To.UTC()
+	instant := from.Equal(to)
+	switch d.Op {
+	case query.OpGt:
+		if instant {
+			return col + " > ?", []any{from}
+		}
+		return col + " >= ?", []any{to}
+	case query.OpGe:
+		return col + " >= ?", []any{from}
+	case query.OpLt:
+		return col + " < ?", []any{from}
+	case query.OpLe:
+		if instant {
+			return col + " <= ?", []any{from}
+		}
+		return col + " < ?", []any{to}
+	}
+	if instant {
+		return col + " = ?", []any{from}
+	}
+	return fmt.Sprintf("(%s >= ? AND %s < ?)", col, col), []any{from, to}
+}
+
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
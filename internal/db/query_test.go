@@ -0,0 +1,65 @@
+package db
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/query"
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchQueryLanguage(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	jan := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+	mar := time.Date(2025, 3, 2, 10, 0, 0, 0, time.Local)
+	for _, e := range []api.Entry{
+		{ID: "standup", Title: "Daily standup", Body: "deployed the api", Tags: []string{"work"}, CreatedAt: mar},
+		{ID: "draft", Title: "Standup notes", Body: "half written", Tags: []string{"work", "draft"}, CreatedAt: mar},
+		{ID: "old", Title: "Planning", Body: "deployment plan for the exact phrase", Tags: []string{"work"}, CreatedAt: jan},
+		{ID: "home", Title: "Groceries", Body: "milk", Tags: []string{"home/errands"}, CreatedAt: mar},
+	} {
+		e.Version, e.Namespace, e.UpdatedAt = 1, "test", e.CreatedAt
+		_, err := store.Entries.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+
+	ids := func(entries []api.Entry) []string {
+		out := make([]string, 0, len(entries))
+		for _, e := range entries {
+			out = append(out, e.ID)
+		}
+		sort.Strings(out)
+		return out
+	}
+	cases := []struct {
+		q    string
+		want []string
+	}{
+		{`title:standup tag:work -tag:draft`, []string{"standup"}},
+		{`standup`, []string{"draft", "standup"}},
+		{`deploy*`, []string{"old", "standup"}},
+		{`"exact phrase" OR milk`, []string{"home", "old"}},
+		{`tag:work created:>2025-01`, []string{"draft", "standup"}},
+		{`created:2025-01`, []string{"old"}},
+		{`tag:home/*`, []string{"home"}},
+		{`tag:draft OR body:milk`, []string{"draft", "home"}},
+		{`-standup -milk`, []string{"old"}},
+		{`work`, []string{"draft", "old", "standup"}},
+	}
+	for _, c := range cases {
+		got, _, err := store.Entries.Search(ctx, api.SearchQuery{Namespace: "test", Query: c.q})
+		require.NoError(t, err, c.q)
+		require.Equal(t, c.want, ids(got), c.q)
+
+		listed, _, err := store.Entries.ListEntries(ctx, api.ListQuery{Namespace: "test", Query: c.q})
+		require.NoError(t, err, c.q)
+		require.Equal(t, c.want, ids(listed), "list "+c.q)
+	}
+
+	_, _, err := store.Entries.Search(ctx, api.SearchQuery{Namespace: "test", Query: `title:(x`})
+	var se *query.SyntaxError
+	require.True(t, errors.As(err, &se), "got %v", err)
+}
@@ -0,0 +1,323 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Related notes.
+//
+// entry_terms holds a term-frequency vector per note and term_df the number
+// of notes in a namespace containing each term. Both are maintained in the
+// same transactions as the FTS index, so replicated edits are covered too.
+// Related weighs terms by TF-IDF (1+ln tf times ln(1+N/df)) at query time,
+// so weights follow the corpus without reindexing, and ranks other notes by
+// cosine similarity to the source note.
+
+const (
+	// maxRelatedTerms is how many of the source note's highest weighted
+	// terms are looked up; the tail rarely changes the ranking.
+	maxRelatedTerms = 50
+	// maxRelatedCandidates bounds how many notes (best partial dot product
+	// first) get an exact cosine score.
+	maxRelatedCandidates = 200
+	// Title words and tags count this many times a body word.
+	titleTermBoost = 2
+	tagTermBoost   = 2
+)
+
+// stopwords are frequent English words that carry no topic.
+var stopwords = func() map[string]bool {
+	m := map[string]bool{}
+	for _, w := range strings.Fields(`a about after all also an and any are as at be been before but by can
+could did do does for from had has have he her his how i if in into is it its just me more my no not
+of on or our out she so some than that the their them then there these they this to too up us was we
+were what when which who will with would you your`) {
+		m[w] = true
+	}
+	return m
+}()
+
+// noteTerms counts the indexed terms of e. Tags are kept whole with a "#"
+// prefix so they never collide with words.
+func noteTerms(e api.Entry) map[string]int {
+	out := map[string]int{}
+	add := func(text string, weight int) {
+		for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len([]rune(w)) < 2 || stopwords[w] || strings.IndexFunc(w, unicode.IsLetter) < 0 {
+				continue
+			}
+			out[w] += weight
+		}
+	}
+	add(e.Title, titleTermBoost)
+	add(e.Body, 1)
+	for _, t := range e.Tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			out["#"+t] += tagTermBoost
+		}
+	}
+	return out
+}
+
+func termWeight(tf int, df, n int) float64 {
+	if tf <= 0 || df <= 0 {
+		return 0
+	}
+	return (1 + math.Log(float64(tf))) * math.Log(1+float64(n)/float64(df))
+}
+
+// ensureTermIndex creates the related-notes index, filling it from existing
+// entries the first time.
+func ensureTermIndex(ctx context.Context, db *sql.DB) error {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type='table' AND name='entry_terms'`).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `
+CREATE TABLE entry_terms (
+  entry_id TEXT NOT NULL,
+  namespace TEXT NOT NULL,
+  term TEXT NOT NULL,
+  tf INTEGER NOT NULL,
+  PRIMARY KEY(entry_id, term)
+) WITHOUT ROWID;
+CREATE INDEX idx_entry_terms_ns_term ON entry_terms(namespace, term);
+CREATE TABLE term_df (
+  namespace TEXT NOT NULL,
+  term TEXT NOT NULL,
+  df INTEGER NOT NULL,
+  PRIMARY KEY(namespace, term)
+) WITHOUT ROWID;
+`); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, title, body, tags, namespace FROM entries`)
+	if err != nil {
+		return err
+	}
+	var all []api.Entry
+	for rows.Next() {
+		var e api.Entry
+		var tags string
+		if err := rows.Scan(&e.ID, &e.Title, &e.Body, &tags, &e.Namespace); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		_ = json.Unmarshal([]byte(tags), &e.Tags)
+		all = append(all, e)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+	for _, e := range all {
+		if err := indexTerms(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// indexTerms replaces the term vector of e and adjusts document
+// frequencies, including when e moved between namespaces.
+func indexTerms(ctx context.Context, tx *sql.Tx, e api.Entry) error {
+	if err := unindexTerms(ctx, tx, e.ID); err != nil {
+		return err
+	}
+	for term, tf := range noteTerms(e) {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO entry_terms(entry_id, namespace, term, tf) VALUES(?,?,?,?)`, e.ID, e.Namespace, term, tf); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO term_df(namespace, term, df) VALUES(?,?,1)
+ON CONFLICT(namespace, term) DO UPDATE SET df=df+1`, e.Namespace, term); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexTerms drops the term vector of a note.
+func unindexTerms(ctx context.Context, tx *sql.Tx, id string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE term_df SET df=df-1
+WHERE (namespace, term) IN (SELECT namespace, term FROM entry_terms WHERE entry_id=?)`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM term_df
+WHERE df<=0 AND (namespace, term) IN (SELECT namespace, term FROM entry_terms WHERE entry_id=?)`, id); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM entry_terms WHERE entry_id=?`, id)
+	return err
+}
+
+type weightedTerm struct {
+	term   string
+	weight float64
+}
+
+// Related returns up to limit notes from the same namespace as id, most
+// similar first. Score is the cosine similarity in (0, 1].
+func (s *sqliteStore) Related(ctx context.Context, id string, limit int) ([]api.SearchHit, error) {
+	defer metrics.ObserveDB("related", time.Now())
+	if limit <= 0 {
+		limit = 10
+	}
+	var ns string
+	if err := s.db.QueryRowContext(ctx, `SELECT namespace FROM entries WHERE id=?`, id).Scan(&ns); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	var n int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM entries WHERE namespace=?`, ns).Scan(&n); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT t.term, t.tf, d.df FROM entry_terms t
+JOIN term_df d ON d.namespace=t.namespace AND d.term=t.term
+WHERE t.entry_id=?`, id)
+	if err != nil {
+		return nil, err
+	}
+	var source []weightedTerm
+	var sourceNorm float64
+	for rows.Next() {
+		var term string
+		var tf, df int
+		if err := rows.Scan(&term, &tf, &df); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		w := termWeight(tf, df, n)
+		sourceNorm += w * w
+		source = append(source, weightedTerm{term, w})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+	if len(source) == 0 || sourceNorm == 0 {
+		return nil, nil
+	}
+	sort.Slice(source, func(i, j int) bool {
+		if source[i].weight != source[j].weight {
+			return source[i].weight > source[j].weight
+		}
+		return source[i].term < source[j].term
+	})
+	if len(source) > maxRelatedTerms {
+		source = source[:maxRelatedTerms]
+	}
+
+	// Dot products over the shared terms.
+	args := []any{ns, id}
+	weights := make(map[string]float64, len(source))
+	for _, t := range source {
+		args = append(args, t.term)
+		weights[t.term] = t.weight
+	}
+	rows, err = s.db.QueryContext(ctx, `SELECT t.entry_id, t.term, t.tf, d.df FROM entry_terms t
+JOIN term_df d ON d.namespace=t.namespace AND d.term=t.term
+WHERE t.namespace=? AND t.entry_id<>? AND t.term IN (?`+strings.Repeat(",?", len(source)-1)+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	dots := map[string]float64{}
+	for rows.Next() {
+		var eid, term string
+		var tf, df int
+		if err := rows.Scan(&eid, &term, &tf, &df); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		dots[eid] += weights[term] * termWeight(tf, df, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+	if len(dots) == 0 {
+		return nil, nil
+	}
+	cands := make([]string, 0, len(dots))
+	for eid := range dots {
+		cands = append(cands, eid)
+	}
+	sort.Slice(cands, func(i, j int) bool {
+		if dots[cands[i]] != dots[cands[j]] {
+			return dots[cands[i]] > dots[cands[j]]
+		}
+		return cands[i] < cands[j]
+	})
+	if len(cands) > maxRelatedCandidates {
+		cands = cands[:maxRelatedCandidates]
+	}
+
+	// Candidate norms over all of their terms.
+	args = args[:0]
+	for _, c := range cands {
+		args = append(args, c)
+	}
+	rows, err = s.db.QueryContext(ctx, `SELECT t.entry_id, t.tf, d.df FROM entry_terms t
+JOIN term_df d ON d.namespace=t.namespace AND d.term=t.term
+WHERE t.entry_id IN (?`+strings.Repeat(",?", len(cands)-1)+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	norms := map[string]float64{}
+	for rows.Next() {
+		var eid string
+		var tf, df int
+		if err := rows.Scan(&eid, &tf, &df); err != nil {
+			_ = rows.Close()
+			return nil, err
+		}
+		w := termWeight(tf, df, n)
+		norms[eid] += w * w
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close()
+
+	scores := make(map[string]float64, len(cands))
+	for _, c := range cands {
+		if norms[c] > 0 {
+			scores[c] = dots[c] / math.Sqrt(sourceNorm*norms[c])
+		}
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return scores[cands[i]] > scores[cands[j]] })
+	if len(cands) > limit {
+		cands = cands[:limit]
+	}
+	entries, _, err := s.fetchEntriesByIDs(ctx, cands)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]api.SearchHit, 0, len(entries))
+	for _, e := range entries {
+		hits = append(hits, api.SearchHit{Entry: e, Score: scores[e.ID]})
+	}
+	return hits, nil
+}
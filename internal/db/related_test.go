@@ -0,0 +1,85 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelated(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	mk := func(id, ns, title, body string, tags ...string) {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: title, Body: body, Tags: tags, Namespace: ns, CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	mk("src", "test", "Postgres failover", "We decided to use streaming replication and patroni for postgres failover.", "db")
+	mk("close", "test", "Patroni setup", "Patroni manages postgres replication and failover.", "db")
+	mk("loose", "test", "Database backups", "Nightly postgres dumps to object storage.")
+	mk("other", "test", "Team lunch", "Pizza on friday.")
+	mk("elsewhere", "home", "Postgres failover at home", "patroni replication failover postgres")
+
+	ids := func(hits []api.SearchHit) []string {
+		var out []string
+		for _, h := range hits {
+			out = append(out, h.Entry.ID)
+		}
+		return out
+	}
+	hits, err := store.Entries.Related(ctx, "src", 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"close", "loose"}, ids(hits))
+	require.Greater(t, hits[0].Score, hits[1].Score)
+	require.LessOrEqual(t, hits[0].Score, 1.0)
+
+	// Edits (including replicated ones) reindex the note.
+	cur, err := store.Entries.GetEntry(ctx, "other")
+	require.NoError(t, err)
+	cur.Body, cur.Version = "Lunch talk: patroni failover drills for postgres replication.", cur.Version+1
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventUpsert, ID: cur.ID, Entry: &cur}))
+	hits, err = store.Entries.Related(ctx, "src", 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"close"}, ids(hits))
+	hits, err = store.Entries.Related(ctx, "src", 10)
+	require.NoError(t, err)
+	require.Contains(t, ids(hits), "other")
+
+	require.NoError(t, store.Entries.DeleteEntry(ctx, "close"))
+	hits, err = store.Entries.Related(ctx, "src", 10)
+	require.NoError(t, err)
+	require.NotContains(t, ids(hits), "close")
+
+	var stale int
+	dbh := store.Entries.(*sqliteStore).db
+	require.NoError(t, dbh.QueryRowContext(ctx, `SELECT count(*) FROM entry_terms WHERE entry_id='close'`).Scan(&stale))
+	require.Zero(t, stale)
+	require.NoError(t, dbh.QueryRowContext(ctx, `SELECT count(*) FROM term_df WHERE df<=0`).Scan(&stale))
+	require.Zero(t, stale)
+
+	_, err = store.Entries.Related(ctx, "missing", 10)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTermIndexBackfill(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	for _, e := range []api.Entry{
+		{ID: "a", Title: "Kafka retention", Body: "retention policy for kafka topics"},
+		{ID: "b", Title: "Kafka partitions", Body: "partition count for kafka topics"},
+	} {
+		e.Version, e.Namespace, e.CreatedAt, e.UpdatedAt = 1, "test", now, now
+		_, err := store.Entries.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+	dbh := store.Entries.(*sqliteStore).db
+	_, err := dbh.ExecContext(ctx, `DROP TABLE entry_terms; DROP TABLE term_df`)
+	require.NoError(t, err)
+	require.NoError(t, migrate(ctx, dbh))
+
+	hits, err := store.Entries.Related(ctx, "a", 10)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "b", hits[0].Entry.ID)
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// SetReminder creates or replaces the reminder of note r.NoteID, which must
+// exist; r.Namespace is taken from the note. A zero UpdatedAt is set to now;
+// replicated reminders keep the time they carry.
+func (s *sqliteStore) SetReminder(ctx context.Context, r api.Reminder) (api.Reminder, error) {
+	defer metrics.ObserveDB("set_reminder", time.Now())
+	r.NoteID = strings.TrimSpace(r.NoteID)
+	if r.NoteID == "" {
+		return api.Reminder{}, fmt.Errorf("note id is required")
+	}
+	if r.At.IsZero() {
+		return api.Reminder{}, fmt.Errorf("reminder time is required")
+	}
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = time.Now().UTC()
+	}
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.Reminder{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT namespace FROM entries WHERE id=?`, r.NoteID).Scan(&r.Namespace); err != nil {
+		if err == sql.ErrNoRows {
+			return api.Reminder{}, ErrNotFound
+		}
+		return api.Reminder{}, err
+	}
+	r.Title = ""
+	spec, err := json.Marshal(r)
+	if err != nil {
+		return api.Reminder{}, err
+	}
+	fired := 0
+	if !r.FiredAt.IsZero() {
+		fired = 1
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO reminders(note_id, namespace, at, fired, spec, updated_at) VALUES(?,?,?,?,?,?)
+ON CONFLICT(note_id) DO UPDATE SET namespace=excluded.namespace, at=excluded.at, fired=excluded.fired, spec=excluded.spec, updated_at=excluded.updated_at`,
+		r.NoteID, r.Namespace, r.At.Unix(), fired, string(spec), r.UpdatedAt.UTC()); err != nil {
+		return api.Reminder{}, err
+	}
+	if shouldLog(ctx) {
+		if err := appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventReminderUpsert, ID: r.NoteID, Namespace: r.Namespace, Reminder: &r}); err != nil {
+			return api.Reminder{}, err
+		}
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return api.Reminder{}, err
+		}
+	}
+	return r, nil
+}
+
+func (s *sqliteStore) GetReminder(ctx context.Context, noteID string) (api.Reminder, error) {
+	defer metrics.ObserveDB("get_reminder", time.Now())
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.Reminder{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	var spec, title string
+	if err := tx.QueryRowContext(ctx, `SELECT r.spec, COALESCE(e.title, '') FROM reminders r LEFT JOIN entries e ON e.id = r.note_id
+WHERE r.note_id=?`, noteID).Scan(&spec, &title); err != nil {
+		if err == sql.ErrNoRows {
+			return api.Reminder{}, ErrNotFound
+		}
+		return api.Reminder{}, err
+	}
+	var r api.Reminder
+	if err := json.Unmarshal([]byte(spec), &r); err != nil {
+		return api.Reminder{}, err
+	}
+	r.Title = title
+	return r, nil
+}
+
+// ListReminders returns reminders ordered by time, then note ID.
+func (s *sqliteStore) ListReminders(ctx context.Context, namespace string, includeFired bool) ([]api.Reminder, error) {
+	defer metrics.ObserveDB("list_reminders", time.Now())
+	q := `SELECT r.spec, COALESCE(e.title, '') FROM reminders r LEFT JOIN entries e ON e.id = r.note_id WHERE 1=1`
+	var args []any
+	if namespace != "" {
+		q += ` AND r.namespace=?`
+		args = append(args, namespace)
+	}
+	if !includeFired {
+		q += ` AND r.fired=0`
+	}
+	rows, err := s.db.QueryContext(ctx, q+` ORDER BY r.at, r.note_id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []api.Reminder
+	for rows.Next() {
+		var spec, title string
+		if err := rows.Scan(&spec, &title); err != nil {
+			return nil, err
+		}
+		var r api.Reminder
+		if err := json.Unmarshal([]byte(spec), &r); err != nil {
+			return nil, err
+		}
+		r.Title = title
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqliteStore) DeleteReminder(ctx context.Context, noteID string) error {
+	defer metrics.ObserveDB("delete_reminder", time.Now())
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	var ns string
+	if err := tx.QueryRowContext(ctx, `SELECT namespace FROM reminders WHERE note_id=?`, noteID).Scan(&ns); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reminders WHERE note_id=?`, noteID); err != nil {
+		return err
+	}
+	if shouldLog(ctx) {
+		if err := appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventReminderDelete, ID: noteID, Namespace: ns}); err != nil {
+			return err
+		}
+	}
+	if owned {
+		return tx.Commit()
+	}
+	return nil
+}
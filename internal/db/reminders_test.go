@@ -0,0 +1,73 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemindersCRUDAndReplication(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, id := range []string{"a", "b"} {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: "note " + id, Body: "b", Namespace: "test", CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+
+	_, err := store.Reminders.SetReminder(ctx, api.Reminder{NoteID: "missing", At: now})
+	require.ErrorIs(t, err, ErrNotFound)
+	ra, err := store.Reminders.SetReminder(ctx, api.Reminder{NoteID: "a", At: now.Add(2 * time.Hour), Device: "laptop"})
+	require.NoError(t, err)
+	require.Equal(t, "test", ra.Namespace)
+	_, err = store.Reminders.SetReminder(ctx, api.Reminder{NoteID: "b", At: now.Add(time.Hour), Device: "laptop"})
+	require.NoError(t, err)
+
+	list, err := store.Reminders.ListReminders(ctx, "test", false)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "b", list[0].NoteID)
+	require.Equal(t, "note b", list[0].Title)
+
+	// Fired reminders are listed only on request.
+	ra.FiredAt, ra.UpdatedAt = now, now.Add(time.Second)
+	_, err = store.Reminders.SetReminder(ctx, ra)
+	require.NoError(t, err)
+	list, err = store.Reminders.ListReminders(ctx, "", false)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	list, err = store.Reminders.ListReminders(ctx, "", true)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	evs, _, err := store.Events.List(ctx, api.Cursor{}, 10)
+	require.NoError(t, err)
+	require.Equal(t, api.EventReminderUpsert, evs[len(evs)-1].Type)
+	require.Equal(t, "a", evs[len(evs)-1].ID)
+
+	// Replication is last writer wins and drops reminders of unknown notes.
+	stale := ra
+	stale.FiredAt, stale.UpdatedAt = time.Time{}, now.Add(-time.Minute)
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventReminderUpsert, ID: "a", Namespace: "test", Reminder: &stale}))
+	got, err := store.Reminders.GetReminder(ctx, "a")
+	require.NoError(t, err)
+	require.False(t, got.FiredAt.IsZero())
+	// A reminder without a device belongs to the device that set it.
+	anon := api.Reminder{NoteID: "a", At: now, UpdatedAt: now.Add(time.Minute)}
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventReminderUpsert, ID: "a", Namespace: "test", Reminder: &anon, OriginLabel: "phone"}))
+	got, err = store.Reminders.GetReminder(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "phone", got.Device)
+	orphan := api.Reminder{NoteID: "gone", At: now, UpdatedAt: now}
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventReminderUpsert, ID: "gone", Namespace: "test", Reminder: &orphan}))
+
+	// Deleting a note deletes its reminder.
+	require.NoError(t, store.Entries.DeleteEntry(ctx, "b"))
+	_, err = store.Reminders.GetReminder(ctx, "b")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventReminderDelete, ID: "a", Namespace: "test"}))
+	_, err = store.Reminders.GetReminder(ctx, "a")
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// bm25Weights ranks entries_fts columns (title, body, tags, namespace, id):
+// a title hit outweighs a tag hit, which outweighs a body hit.
+const bm25Weights = "10.0, 1.0, 5.0, 0.0, 0.0"
+
+// snippetTokens is the size of body fragments returned by snippet().
+const snippetTokens = 16
+
+// Match markers passed to highlight()/snippet(); control characters do not
+// occur in note text, so they can be stripped back out into offsets.
+const (
+	markOpen  = '\x02'
+	markClose = '\x03'
+)
+
+// ftsMatch is one searchFTS row: the entry id, its score (higher is better),
+// and title/body/tags fragments with match markers.
+type ftsMatch struct {
+	id    string
+	score float64
+	title string
+	body  string
+	tags  string
+}
+
+// ftsResult is a page of searchFTS rows. Ranked pages are ordered by score
+// and addressed by offset rather than by created_at cursor.
+type ftsResult struct {
+	matches []ftsMatch
+	hasMore bool
+	ranked  bool
+	offset  int
+}
+
+// snippets converts the marked fragments into api.Snippets, keeping only
+// fields with at least one match.
+func (m ftsMatch) snippets() []api.Snippet {
+	var out []api.Snippet
+	for _, f := range []struct{ field, text string }{{"title", m.title}, {"body", m.body}, {"tags", m.tags}} {
+		if sn, ok := parseMarked(f.field, f.text); ok {
+			out = append(out, sn)
+		}
+	}
+	return out
+}
+
+// parseMarked strips match markers from s, recording their byte ranges.
+func parseMarked(field, s string) (api.Snippet, bool) {
+	if !strings.ContainsRune(s, markOpen) {
+		return api.Snippet{}, false
+	}
+	var b strings.Builder
+	var matches []api.TextRange
+	start := -1
+	for _, r := range s {
+		switch r {
+		case markOpen:
+			start = b.Len()
+		case markClose:
+			if start >= 0 && b.Len() > start {
+				matches = append(matches, api.TextRange{Start: start, End: b.Len()})
+			}
+			start = -1
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if len(matches) == 0 {
+		return api.Snippet{}, false
+	}
+	return api.Snippet{Field: field, Text: b.String(), Matches: matches}, true
+}
+
+// Offset cursors page relevance-ordered results: "@<offset>".
+func parseOffsetCursor(s string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+	if err != nil || n < 0 || !strings.HasPrefix(strings.TrimSpace(s), "@") {
+		return 0
+	}
+	return n
+}
+
+func encodeOffsetCursor(n int) string { return "@" + strconv.Itoa(n) }
+
+// rankedPage builds offset cursors for a relevance-ordered page.
+func rankedPage(offset, n, limit int, hasMore bool) api.Page {
+	var page api.Page
+	if hasMore {
+		page.Next = encodeOffsetCursor(offset + n)
+	}
+	if offset > 0 {
+		page.Prev = encodeOffsetCursor(max(0, offset-limit))
+	}
+	return page
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchHitsRelevanceAndSnippets(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, e := range []api.Entry{
+		{ID: "body", Title: "Friday", Body: "We finally shipped the deploy after lunch."},
+		{ID: "title", Title: "Deploy checklist", Body: "steps"},
+		{ID: "tag", Title: "Release", Body: "notes", Tags: []string{"deploy"}},
+		{ID: "none", Title: "Groceries", Body: "milk"},
+	} {
+		e.Version, e.Namespace = 1, "test"
+		e.CreatedAt = now.Add(time.Duration(i) * time.Minute)
+		e.UpdatedAt = e.CreatedAt
+		_, err := store.Entries.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+
+	hits, page, err := store.Entries.SearchHits(ctx, api.SearchQuery{Namespace: "test", Query: "deploy", SortBy: api.SortRelevance, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, hits, 2)
+	require.Equal(t, "title", hits[0].Entry.ID)
+	require.Equal(t, "tag", hits[1].Entry.ID)
+	require.Greater(t, hits[0].Score, hits[1].Score)
+	require.Equal(t, "@2", page.Next)
+
+	sn := hits[0].Snippets
+	require.Len(t, sn, 1)
+	require.Equal(t, "title", sn[0].Field)
+	require.Equal(t, "Deploy checklist", sn[0].Text)
+	require.Equal(t, []api.TextRange{{Start: 0, End: 6}}, sn[0].Matches)
+
+	rest, page, err := store.Entries.SearchHits(ctx, api.SearchQuery{Namespace: "test", Query: "deploy", SortBy: api.SortRelevance, Limit: 2, Cursor: page.Next})
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.Equal(t, "body", rest[0].Entry.ID)
+	require.Empty(t, page.Next)
+	require.Equal(t, "@0", page.Prev)
+	body := rest[0].Snippets[0]
+	require.Equal(t, "body", body.Field)
+	m := body.Matches[0]
+	require.Equal(t, "deploy", body.Text[m.Start:m.End])
+
+	// Default order stays newest first.
+	byDate, _, err := store.Entries.SearchHits(ctx, api.SearchQuery{Namespace: "test", Query: "deploy"})
+	require.NoError(t, err)
+	require.Equal(t, "tag", byDate[0].Entry.ID)
+	require.Equal(t, "body", byDate[2].Entry.ID)
+}
+
+func TestParseMarked(t *testing.T) {
+	sn, ok := parseMarked("body", "…the \x02déploy\x03 and \x02ship\x03")
+	require.True(t, ok)
+	require.Equal(t, "…the déploy and ship", sn.Text)
+	require.Equal(t, "déploy", sn.Text[sn.Matches[0].Start:sn.Matches[0].End])
+	require.Equal(t, "ship", sn.Text[sn.Matches[1].Start:sn.Matches[1].End])
+
+	_, ok = parseMarked("title", "no match")
+	require.False(t, ok)
+}
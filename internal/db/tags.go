@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+)
+
+// Hierarchical tags.
+//
+// A tag may name a path such as work/ginkgo/sync. Filtering on a tag
+// matches the tag itself and every tag below it, so work matches
+// work/ginkgo/sync but not workshop.
+
+// tagMatch returns a predicate matching col against tag and its children.
+func tagMatch(col, tag string) (string, []any) {
+	return "(" + col + " = ? OR " + col + ` LIKE ? ESCAPE '\')`, []any{tag, escapeLike(tag) + "/%"}
+}
+
+// isTagOrChild reports whether t is tag or below it.
+func isTagOrChild(t, tag string) bool {
+	t, tag = strings.ToLower(t), strings.ToLower(tag)
+	return t == tag || strings.HasPrefix(t, tag+"/")
+}
+
+// SetTagDescription sets the description of tag; an empty description
+// clears it.
+func (s *sqliteStore) SetTagDescription(ctx context.Context, tag, description string) error {
+	defer metrics.ObserveDB("set_tag_description", time.Now())
+	_, err := s.db.ExecContext(ctx, `INSERT INTO tags(tag, description) VALUES(?, ?)
+ON CONFLICT(tag) DO UPDATE SET description=excluded.description`, strings.ToLower(tag), description)
+	return err
+}
+
+// RenameTag replaces tag from, and with children every tag below it, by to
+// on the notes of namespace: from/x becomes to/x. Notes already tagged to
+// are merged; an empty to removes the tags. Notes are rewritten with
+// UpdateEntryCAS in one transaction, so the change replicates like an
+// edit. It returns the number of notes changed.
+func (s *sqliteStore) RenameTag(ctx context.Context, namespace, from, to string, children bool) (int, error) {
+	defer metrics.ObserveDB("rename_tag", time.Now())
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	txCtx := WithTx(ctx, tx)
+
+	match, args := "nt.tag = ?", []any{from}
+	if children {
+		match, args = tagMatch("nt.tag", from)
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT DISTINCT nt.note_id FROM note_tags nt
+JOIN entries e ON e.id = nt.note_id
+WHERE e.namespace = ? AND `+match, append([]any{namespace}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	_ = rows.Close()
+
+	now := time.Now().UTC()
+	changed := 0
+	for _, id := range ids {
+		e, err := s.GetEntry(txCtx, id)
+		if err != nil {
+			return 0, err
+		}
+		tags := make([]string, 0, len(e.Tags))
+		seen := map[string]bool{}
+		for _, t := range e.Tags {
+			lt := strings.ToLower(t)
+			switch {
+			case lt == from:
+				t = to
+			case children && isTagOrChild(lt, from):
+				if to == "" {
+					t = ""
+				} else {
+					t = to + lt[len(from):]
+				}
+			}
+			if t != "" && !seen[strings.ToLower(t)] {
+				seen[strings.ToLower(t)] = true
+				tags = append(tags, t)
+			}
+		}
+		prev := e.Version
+		e.Tags, e.Version, e.UpdatedAt = tags, prev+1, now
+		if _, err := s.UpdateEntryCAS(txCtx, e, prev); err != nil {
+			return 0, err
+		}
+		changed++
+	}
+
+	// Carry the description over unless the target has its own.
+	if to != "" {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tags(tag, description)
+SELECT ?, description FROM tags WHERE tag = ? AND description <> ''
+ON CONFLICT(tag) DO UPDATE SET description=excluded.description WHERE tags.description = ''`, to, from); err != nil {
+			return 0, err
+		}
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+	return changed, nil
+}
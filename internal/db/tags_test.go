@@ -0,0 +1,99 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHierarchicalTagFilters(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	mk := func(id string, tags ...string) {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: id, Body: "b", Tags: tags, Namespace: "test", CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	mk("root", "work")
+	mk("deep", "work/ginkgo/sync")
+	mk("both", "work/a", "work/b", "home")
+	mk("near", "workshop")
+
+	list := func(q api.ListQuery) []string {
+		q.Namespace = "test"
+		got, _, err := store.Entries.ListEntries(ctx, q)
+		require.NoError(t, err)
+		var ids []string
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+	require.ElementsMatch(t, []string{"root", "deep", "both"}, list(api.ListQuery{All: []string{"work"}}))
+	require.ElementsMatch(t, []string{"deep"}, list(api.ListQuery{Any: []string{"work/ginkgo"}}))
+	require.ElementsMatch(t, []string{"both"}, list(api.ListQuery{All: []string{"work", "home"}}))
+	require.ElementsMatch(t, []string{"root", "deep", "both", "near"}, list(api.ListQuery{Any: []string{"work", "workshop"}}))
+
+	got, _, err := store.Entries.Search(ctx, api.SearchQuery{Namespace: "test", Query: "tag:work/ginkgo"})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "deep", got[0].ID)
+}
+
+func TestRenameTag(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	mk := func(id, ns string, tags ...string) {
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: id, Body: "b", Tags: tags, Namespace: ns, CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	mk("a", "test", "proj", "x")
+	mk("b", "test", "proj/api", "proj")
+	mk("c", "test", "project")
+	mk("d", "home", "proj")
+	require.NoError(t, store.Entries.SetTagDescription(ctx, "proj", "the project"))
+
+	tags := func(id string) []string {
+		e, err := store.Entries.GetEntry(ctx, id)
+		require.NoError(t, err)
+		return e.Tags
+	}
+	n, err := store.Entries.RenameTag(ctx, "test", "proj", "work/ginkgo", true)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []string{"work/ginkgo", "x"}, tags("a"))
+	require.Equal(t, []string{"work/ginkgo/api", "work/ginkgo"}, tags("b"))
+	require.Equal(t, []string{"project"}, tags("c"))
+	require.Equal(t, []string{"proj"}, tags("d"))
+	e, err := store.Entries.GetEntry(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), e.Version)
+
+	stats, err := store.Entries.ListTags(ctx, api.TagsQuery{Namespace: "test", Prefix: "work/ginkgo"})
+	require.NoError(t, err)
+	require.Equal(t, "work/ginkgo", stats[0].Tag)
+	require.Equal(t, "the project", stats[0].Description)
+
+	// Merging drops the duplicate; deleting without children keeps them.
+	n, err = store.Entries.RenameTag(ctx, "test", "x", "work/ginkgo", true)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{"work/ginkgo"}, tags("a"))
+	n, err = store.Entries.RenameTag(ctx, "test", "work/ginkgo", "", false)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Empty(t, tags("a"))
+	require.Equal(t, []string{"work/ginkgo/api"}, tags("b"))
+
+	// Rewrites are logged like edits so they replicate.
+	evs, _, err := store.Events.List(ctx, api.Cursor{}, 100)
+	require.NoError(t, err)
+	upserts := 0
+	for _, ev := range evs {
+		if ev.Type == api.EventUpsert {
+			upserts++
+		}
+	}
+	require.Equal(t, 4+2+1+2, upserts)
+}
@@ -0,0 +1,356 @@
+package db
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Regex search prefilter.
+//
+// Every note is indexed in entries_trigram, a contentless FTS5 table using
+// the trigram tokenizer over the same haystack the regex is matched against.
+// A phrase of three or more characters in that table matches any note that
+// contains it as a substring (case-insensitively). regexTrigrams walks the
+// RE2 syntax tree and derives a boolean formula of such substrings that every
+// match must contain, following Russ Cox's "Regular Expression Matching with
+// a Trigram Index". The formula only ever over-approximates, so filtering by
+// it never drops a real match.
+
+const (
+	// maxExactSet bounds how many alternative exact strings are tracked for
+	// a subexpression before falling back to prefixes and suffixes.
+	maxExactSet = 16
+	// maxAffixSet bounds the prefix and suffix sets; larger sets are
+	// replaced by "no information".
+	maxAffixSet = 32
+	// maxClassSize is the largest character class expanded into exact
+	// single-character strings.
+	maxClassSize = 4
+)
+
+// regexHaystack is the text a regex search is matched against and the
+// content of entries_trigram.
+func regexHaystack(title, body string, tags []string) string {
+	return title + "\n" + body + "\n" + strings.Join(tags, ",")
+}
+
+// regexTrigrams returns an FTS5 expression for entries_trigram that every
+// note matching pattern satisfies, or "" when the pattern requires no
+// substring of three or more characters.
+func regexTrigrams(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	return analyzeRegex(re.Simplify()).query().String(), nil
+}
+
+type trigramOp int
+
+const (
+	tqAll trigramOp = iota // matches everything
+	tqLit                  // note contains lit
+	tqAnd
+	tqOr
+)
+
+// trigramQuery is a boolean formula over required substrings. The zero
+// value matches every note.
+type trigramQuery struct {
+	op  trigramOp
+	lit string
+	sub []trigramQuery
+}
+
+// litQuery requires s. Strings shorter than a trigram cannot be looked up
+// and constrain nothing.
+func litQuery(s string) trigramQuery {
+	if utf8.RuneCountInString(s) < 3 {
+		return trigramQuery{}
+	}
+	return trigramQuery{op: tqLit, lit: s}
+}
+
+func andQuery(a, b trigramQuery) trigramQuery {
+	if a.op == tqAll {
+		return b
+	}
+	if b.op == tqAll {
+		return a
+	}
+	return joinQueries(tqAnd, a, b)
+}
+
+func orQuery(a, b trigramQuery) trigramQuery {
+	if a.op == tqAll || b.op == tqAll {
+		return trigramQuery{}
+	}
+	return joinQueries(tqOr, a, b)
+}
+
+// joinQueries combines a and b with op, flattening nested operators of the
+// same kind and dropping duplicate operands.
+func joinQueries(op trigramOp, a, b trigramQuery) trigramQuery {
+	var out []trigramQuery
+	seen := map[string]bool{}
+	for _, q := range []trigramQuery{a, b} {
+		parts := []trigramQuery{q}
+		if q.op == op {
+			parts = q.sub
+		}
+		for _, p := range parts {
+			key := p.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, p)
+		}
+	}
+	if len(out) == 1 {
+		return out[0]
+	}
+	return trigramQuery{op: op, sub: out}
+}
+
+// orLits requires at least one of ss.
+func orLits(ss []string) trigramQuery {
+	if len(ss) == 0 {
+		return trigramQuery{}
+	}
+	q := litQuery(ss[0])
+	for _, s := range ss[1:] {
+		q = orQuery(q, litQuery(s))
+	}
+	return q
+}
+
+// String renders q as an FTS5 expression; tqAll renders as "".
+func (q trigramQuery) String() string {
+	switch q.op {
+	case tqLit:
+		return `"` + strings.ReplaceAll(q.lit, `"`, `""`) + `"`
+	case tqAnd, tqOr:
+		sep := " AND "
+		if q.op == tqOr {
+			sep = " OR "
+		}
+		parts := make([]string, 0, len(q.sub))
+		for _, s := range q.sub {
+			str := s.String()
+			if s.op == tqAnd || s.op == tqOr {
+				str = "(" + str + ")"
+			}
+			parts = append(parts, str)
+		}
+		return strings.Join(parts, sep)
+	}
+	return ""
+}
+
+// regexInfo summarises what a subexpression can match. When exact is
+// non-nil it lists every string the subexpression matches; otherwise
+// prefix and suffix hold the (truncated) strings a match can begin and end
+// with, "" meaning anything. match must hold for the text of any match.
+type regexInfo struct {
+	exact  []string
+	prefix []string
+	suffix []string
+	match  trigramQuery
+}
+
+func anyInfo() regexInfo {
+	return regexInfo{prefix: []string{""}, suffix: []string{""}}
+}
+
+func exactInfo(ss ...string) regexInfo {
+	return regexInfo{exact: ss}
+}
+
+// query folds the exact set into match.
+func (i regexInfo) query() trigramQuery {
+	if i.exact != nil {
+		return andQuery(i.match, orLits(i.exact))
+	}
+	return i.match
+}
+
+func (i regexInfo) head() []string {
+	if i.exact != nil {
+		return i.exact
+	}
+	return i.prefix
+}
+
+func (i regexInfo) tail() []string {
+	if i.exact != nil {
+		return i.exact
+	}
+	return i.suffix
+}
+
+// inexact drops the exact set, keeping its information in match, prefix
+// and suffix.
+func (i regexInfo) inexact() regexInfo {
+	if i.exact == nil {
+		return i
+	}
+	return regexInfo{prefix: trimAffix(i.exact, false), suffix: trimAffix(i.exact, true), match: i.query()}
+}
+
+func analyzeRegex(re *syntax.Regexp) regexInfo {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine,
+		syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return exactInfo("")
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase == 0 {
+			return exactInfo(string(re.Rune))
+		}
+		info := exactInfo("")
+		for _, r := range re.Rune {
+			info = concatInfo(info, exactInfo(foldRunes(r)...))
+		}
+		return info
+	case syntax.OpCharClass:
+		var runes []string
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			lo, hi := re.Rune[i], re.Rune[i+1]
+			if int(hi-lo)+1+len(runes) > maxClassSize {
+				return anyInfo()
+			}
+			for r := lo; r <= hi; r++ {
+				runes = append(runes, string(r))
+			}
+		}
+		if len(runes) == 0 {
+			return anyInfo()
+		}
+		return exactInfo(runes...)
+	case syntax.OpCapture:
+		return analyzeRegex(re.Sub[0])
+	case syntax.OpQuest:
+		return alternateInfo(analyzeRegex(re.Sub[0]), exactInfo(""))
+	case syntax.OpPlus:
+		return analyzeRegex(re.Sub[0]).inexact()
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return anyInfo()
+		}
+		return analyzeRegex(re.Sub[0]).inexact()
+	case syntax.OpConcat:
+		info := exactInfo("")
+		for _, sub := range re.Sub {
+			info = concatInfo(info, analyzeRegex(sub))
+		}
+		return info
+	case syntax.OpAlternate:
+		info := analyzeRegex(re.Sub[0])
+		for _, sub := range re.Sub[1:] {
+			info = alternateInfo(info, analyzeRegex(sub))
+		}
+		return info
+	}
+	// OpAnyChar, OpAnyCharNotNL, OpStar and OpNoMatch.
+	return anyInfo()
+}
+
+func concatInfo(x, y regexInfo) regexInfo {
+	if x.exact != nil && y.exact != nil && len(x.exact)*len(y.exact) <= maxExactSet {
+		return regexInfo{exact: crossStrings(x.exact, y.exact), match: andQuery(x.match, y.match)}
+	}
+	match := andQuery(x.query(), y.query())
+	if len(x.tail())*len(y.head()) <= maxExactSet {
+		match = andQuery(match, orLits(crossStrings(x.tail(), y.head())))
+	}
+	info := regexInfo{match: match}
+	if x.exact != nil && len(x.exact)*len(y.head()) <= maxAffixSet {
+		info.prefix = trimAffix(crossStrings(x.exact, y.head()), false)
+	} else {
+		info.prefix = trimAffix(x.head(), false)
+	}
+	if y.exact != nil && len(x.tail())*len(y.exact) <= maxAffixSet {
+		info.suffix = trimAffix(crossStrings(x.tail(), y.exact), true)
+	} else {
+		info.suffix = trimAffix(y.tail(), true)
+	}
+	return info
+}
+
+func alternateInfo(x, y regexInfo) regexInfo {
+	if x.exact != nil && y.exact != nil && len(x.exact)+len(y.exact) <= maxExactSet {
+		return regexInfo{exact: uniqueAppend(x.exact, y.exact), match: orQuery(x.match, y.match)}
+	}
+	return regexInfo{
+		prefix: trimAffix(uniqueAppend(x.head(), y.head()), false),
+		suffix: trimAffix(uniqueAppend(x.tail(), y.tail()), true),
+		match:  orQuery(x.query(), y.query()),
+	}
+}
+
+// foldRunes lists the runes (?i) matching accepts for r. ASCII letters
+// are lowered, as the index already folds those; other variants such as
+// the Kelvin sign for k are kept as alternatives.
+func foldRunes(r rune) []string {
+	var out []string
+	f := r
+	for {
+		v := f
+		if v < utf8.RuneSelf {
+			v = unicode.ToLower(v)
+		}
+		out = append(out, string(v))
+		if f = unicode.SimpleFold(f); f == r {
+			break
+		}
+	}
+	return uniqueAppend(out, nil)
+}
+
+func crossStrings(xs, ys []string) []string {
+	out := make([]string, 0, len(xs)*len(ys))
+	for _, x := range xs {
+		for _, y := range ys {
+			out = append(out, x+y)
+		}
+	}
+	return uniqueAppend(out, nil)
+}
+
+func uniqueAppend(xs, ys []string) []string {
+	out := make([]string, 0, len(xs)+len(ys))
+	seen := make(map[string]bool, len(xs)+len(ys))
+	for _, s := range append(append([]string{}, xs...), ys...) {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// trimAffix keeps the two runes of each string that can combine with a
+// neighbour into a trigram: the last two of a suffix, the first two of a
+// prefix. Oversized sets collapse to "anything".
+func trimAffix(ss []string, suffix bool) []string {
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if n := utf8.RuneCountInString(s); n > 2 {
+			r := []rune(s)
+			if suffix {
+				s = string(r[n-2:])
+			} else {
+				s = string(r[:2])
+			}
+		}
+		out = append(out, s)
+	}
+	out = uniqueAppend(out, nil)
+	if len(out) > maxAffixSet {
+		return []string{""}
+	}
+	return out
+}
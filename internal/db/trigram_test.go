@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexTrigrams(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{`deploy`, `"deploy"`},
+		{`ab`, ``},
+		{`^[a-z]+$`, ``},
+		{`.*`, ``},
+		{`foo.*bar`, `"foo" AND "bar"`},
+		{`(foo|bar)baz`, `"foobaz" OR "barbaz"`},
+		{`colou?r`, `"colour" OR "color"`},
+		{`error \d+ at`, `"error " AND " at"`},
+		{`(deploy|ship)-\d+`, `"deploy-" OR "ship-"`},
+		{`[Dd]eploy`, `"deploy"`},
+		{`(?i)desk`, "\"desk\" OR \"des\u212a\" OR \"de\u017fk\" OR \"de\u017f\u212a\""}, // Kelvin sign, long s
+		{`say "hi"`, `"say ""hi"""`},
+		{`x+yz`, `"xyz"`},
+	}
+	for _, c := range cases {
+		got, err := regexTrigrams(c.in)
+		require.NoError(t, err, c.in)
+		require.Equal(t, c.want, got, c.in)
+	}
+}
+
+func TestSearchRegexComplete(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	base := time.Now().UTC().Truncate(time.Second)
+	mk := func(id, title, body string, age time.Duration, tags ...string) {
+		at := base.Add(-age)
+		_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: id, Version: 1, Title: title, Body: body, Tags: tags, Namespace: "test", CreatedAt: at, UpdatedAt: at})
+		require.NoError(t, err)
+	}
+	// The match is older than limit*20 non-matching notes.
+	for i := 0; i < 60; i++ {
+		mk(fmt.Sprintf("filler-%02d", i), "Filler", "nothing to see", time.Duration(i)*time.Minute)
+	}
+	mk("match", "Release", "Shipped DEPLOY-42 after lunch", 2*time.Hour, "ops")
+
+	search := func(pattern string) []string {
+		got, _, err := store.Entries.Search(ctx, api.SearchQuery{Namespace: "test", Query: pattern, Regex: true, Limit: 1})
+		require.NoError(t, err, pattern)
+		var ids []string
+		for _, e := range got {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+	require.Equal(t, []string{"match"}, search(`DEPLOY-\d+`))
+	require.Equal(t, []string{"match"}, search(`(?i)deploy-4`))
+	require.Equal(t, []string{"match"}, search(`Release\nShipped`))
+	require.Equal(t, []string{"match"}, search(`lunch\nops$`))
+	require.Empty(t, search(`deploy-\d+`))
+
+	cur, err := store.Entries.GetEntry(ctx, "match")
+	require.NoError(t, err)
+	cur.Body, cur.Version = "rolled back", cur.Version+1
+	_, err = store.Entries.UpdateEntryCAS(ctx, cur, cur.Version-1)
+	require.NoError(t, err)
+	require.Empty(t, search(`DEPLOY-\d+`))
+	require.Equal(t, []string{"match"}, search(`rolled`))
+
+	require.NoError(t, store.Entries.DeleteEntry(ctx, "match"))
+	require.Empty(t, search(`rolled`))
+}
+
+func TestTrigramIndexBackfill(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+	now := time.Now().UTC()
+	_, err := store.Entries.CreateEntry(ctx, api.Entry{ID: "old", Version: 1, Title: "Before", Body: "indexed later", Tags: []string{"a", "b"}, Namespace: "test", CreatedAt: now, UpdatedAt: now})
+	require.NoError(t, err)
+
+	dbh := store.Entries.(*sqliteStore).db
+	_, err = dbh.ExecContext(ctx, `DROP TABLE entries_trigram`)
+	require.NoError(t, err)
+	require.NoError(t, migrate(ctx, dbh))
+
+	got, _, err := store.Entries.Search(ctx, api.SearchQuery{Namespace: "test", Query: `later\na,b`, Regex: true})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
+// benchNotes is the corpus size for regex benchmarks; set
+// GINKGO_BENCH_NOTES=100000 to measure at scale.
+func benchNotes(b *testing.B) int {
+	if v := os.Getenv("GINKGO_BENCH_NOTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		require.NoError(b, err)
+		return n
+	}
+	return 10000
+}
+
+func seedBenchStore(b *testing.B, n int) (*Store, context.Context) {
+	dir := b.TempDir()
+	ctx := context.Background()
+	store, closer, err := openSQLite(ctx, "sqlite://"+dir+"/bench.db")
+	require.NoError(b, err)
+	b.Cleanup(func() { closer.Close() })
+
+	words := strings.Fields("alpha beta gamma delta api release meeting notes plan review draft budget travel garden kernel cache index query")
+	rng := rand.New(rand.NewSource(1))
+	tx, err := store.Entries.(*sqliteStore).db.BeginTx(ctx, nil)
+	require.NoError(b, err)
+	txCtx := WithNoEventLog(WithTx(ctx, tx))
+	base := time.Now().UTC()
+	for i := 0; i < n; i++ {
+		body := make([]string, 40)
+		for j := range body {
+			body[j] = words[rng.Intn(len(words))]
+		}
+		if i%100 == 0 {
+			body[rng.Intn(len(body))] = fmt.Sprintf("deploy-%d", i)
+		}
+		at := base.Add(-time.Duration(i) * time.Second)
+		_, err := store.Entries.CreateEntry(txCtx, api.Entry{
+			ID: fmt.Sprintf("n%06d", i), Version: 1, Namespace: "bench",
+			Title: words[i%len(words)] + " " + strconv.Itoa(i), Body: strings.Join(body, " "),
+			Tags: []string{words[rng.Intn(len(words))]}, CreatedAt: at, UpdatedAt: at,
+		})
+		require.NoError(b, err)
+	}
+	require.NoError(b, tx.Commit())
+	return store, ctx
+}
+
+func BenchmarkSearchRegex(b *testing.B) {
+	store, ctx := seedBenchStore(b, benchNotes(b))
+	for _, pattern := range []string{
+		`deploy-\d+`,
+		`(?i)DEPLOY-99\d\d`,
+		`(kernel|cache) index`,
+		`\bzzz\w*`,
+		`^[a-z]+ \d+$`,
+	} {
+		b.Run(pattern, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _, err := store.Entries.Search(ctx, api.SearchQuery{Namespace: "bench", Query: pattern, Regex: true, Limit: 50})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
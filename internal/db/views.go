@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/metrics"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// SaveView creates or replaces the view named v.Name in v.Namespace. A zero
+// UpdatedAt is set to now; replicated views keep the time they carry.
+func (s *sqliteStore) SaveView(ctx context.Context, v api.View) (api.View, error) {
+	defer metrics.ObserveDB("save_view", time.Now())
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" {
+		return api.View{}, fmt.Errorf("view name is required")
+	}
+	if v.UpdatedAt.IsZero() {
+		v.UpdatedAt = time.Now().UTC()
+	}
+	spec, err := json.Marshal(v)
+	if err != nil {
+		return api.View{}, err
+	}
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.View{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO views(namespace, name, spec, updated_at) VALUES(?,?,?,?)
+ON CONFLICT(namespace, name) DO UPDATE SET name=excluded.name, spec=excluded.spec, updated_at=excluded.updated_at`,
+		v.Namespace, v.Name, string(spec), v.UpdatedAt.UTC()); err != nil {
+		return api.View{}, err
+	}
+	if shouldLog(ctx) {
+		if err := appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventViewUpsert, ID: v.Name, Namespace: v.Namespace, View: &v}); err != nil {
+			return api.View{}, err
+		}
+	}
+	if owned {
+		if err := tx.Commit(); err != nil {
+			return api.View{}, err
+		}
+	}
+	return v, nil
+}
+
+func (s *sqliteStore) GetView(ctx context.Context, namespace, name string) (api.View, error) {
+	defer metrics.ObserveDB("get_view", time.Now())
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return api.View{}, err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	var spec string
+	if err := tx.QueryRowContext(ctx, `SELECT spec FROM views WHERE namespace=? AND name=?`, namespace, strings.TrimSpace(name)).Scan(&spec); err != nil {
+		if err == sql.ErrNoRows {
+			return api.View{}, ErrNotFound
+		}
+		return api.View{}, err
+	}
+	var v api.View
+	if err := json.Unmarshal([]byte(spec), &v); err != nil {
+		return api.View{}, err
+	}
+	return v, nil
+}
+
+// ListViews returns the views of a namespace ordered by name.
+func (s *sqliteStore) ListViews(ctx context.Context, namespace string) ([]api.View, error) {
+	defer metrics.ObserveDB("list_views", time.Now())
+	rows, err := s.db.QueryContext(ctx, `SELECT spec FROM views WHERE namespace=? ORDER BY name`, namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []api.View
+	for rows.Next() {
+		var spec string
+		if err := rows.Scan(&spec); err != nil {
+			return nil, err
+		}
+		var v api.View
+		if err := json.Unmarshal([]byte(spec), &v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqliteStore) DeleteView(ctx context.Context, namespace, name string) error {
+	defer metrics.ObserveDB("delete_view", time.Now())
+	tx, owned, err := s.txFor(ctx)
+	if err != nil {
+		return err
+	}
+	if owned {
+		defer tx.Rollback()
+	}
+	name = strings.TrimSpace(name)
+	var stored string
+	if err := tx.QueryRowContext(ctx, `SELECT name FROM views WHERE namespace=? AND name=?`, namespace, name).Scan(&stored); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM views WHERE namespace=? AND name=?`, namespace, name); err != nil {
+		return err
+	}
+	if shouldLog(ctx) {
+		if err := appendEventTx(ctx, tx, api.Event{Time: time.Now().UTC(), Type: api.EventViewDelete, ID: stored, Namespace: namespace}); err != nil {
+			return err
+		}
+	}
+	if owned {
+		return tx.Commit()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewsCRUDAndReplication(t *testing.T) {
+	store, ctx, _ := setupTestDB(t)
+
+	saved, err := store.Views.SaveView(ctx, api.View{Name: "Meetings", Namespace: "test", TagsAll: []string{"work", "meeting"}, Since: "7d"})
+	require.NoError(t, err)
+	require.False(t, saved.UpdatedAt.IsZero())
+
+	got, err := store.Views.GetView(ctx, "test", "meetings")
+	require.NoError(t, err)
+	require.Equal(t, []string{"work", "meeting"}, got.TagsAll)
+	_, err = store.Views.GetView(ctx, "other", "meetings")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	evs, _, err := store.Events.List(ctx, api.Cursor{}, 10)
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+	require.Equal(t, api.EventViewUpsert, evs[0].Type)
+	require.Equal(t, "Meetings", evs[0].ID)
+	require.Equal(t, "plain_v1", evs[0].PayloadType)
+
+	// An older remote edit loses; a newer one replaces the local view.
+	stale := saved
+	stale.Query, stale.UpdatedAt = "stale", saved.UpdatedAt.Add(-time.Minute)
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventViewUpsert, ID: stale.Name, Namespace: "test", View: &stale}))
+	got, err = store.Views.GetView(ctx, "test", "Meetings")
+	require.NoError(t, err)
+	require.Empty(t, got.Query)
+
+	fresh := saved
+	fresh.Query, fresh.UpdatedAt = "standup", saved.UpdatedAt.Add(time.Minute)
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventViewUpsert, ID: fresh.Name, Namespace: "test", View: &fresh}))
+	views, err := store.Views.ListViews(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	require.Equal(t, "standup", views[0].Query)
+
+	// Applied events are not logged again.
+	evs, _, err = store.Events.List(ctx, api.Cursor{}, 10)
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+
+	require.NoError(t, store.Views.DeleteView(ctx, "test", "MEETINGS"))
+	require.ErrorIs(t, store.Views.DeleteView(ctx, "test", "meetings"), ErrNotFound)
+	require.NoError(t, store.ApplyReplication(ctx, api.Event{Type: api.EventViewDelete, ID: "meetings", Namespace: "test"}))
+	views, err = store.Views.ListViews(ctx, "test")
+	require.NoError(t, err)
+	require.Empty(t, views)
+}
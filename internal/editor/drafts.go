@@ -0,0 +1,76 @@
+package editor
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+const draftExt = ".ginkgo.md"
+
+// ListDrafts returns the editor files in Dir as drafts, newest first. An
+// editor file outlives its session when the editor or the CLI is killed or
+// saving fails. The draft's ID is a stored note's for an edit, or a fresh
+// one for a note never saved; its base version is unknown here.
+func ListDrafts() ([]api.Draft, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+draftExt))
+	if err != nil {
+		return nil, err
+	}
+	var out []api.Draft
+	for _, p := range paths {
+		d, err := readDraft(p)
+		if err != nil {
+			continue
+		}
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].UpdatedAt.After(out[j].UpdatedAt) })
+	return out, nil
+}
+
+// DraftID returns the note ID of a PathForID path.
+func DraftID(path string) string {
+	_, id, _ := parseName(path)
+	return id
+}
+
+// readDraft reads the draft file at path.
+func readDraft(path string) (api.Draft, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return api.Draft{}, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return api.Draft{}, err
+	}
+	d := api.Draft{Path: path, Content: string(b), UpdatedAt: fi.ModTime().UTC()}
+	if d.Namespace, d.ID, err = parseName(path); err != nil {
+		return api.Draft{}, err
+	}
+	return d, nil
+}
+
+// parseName splits a PathForID file name back into namespace and ID. IDs
+// contain no dots; namespaces may.
+func parseName(path string) (namespace, id string, err error) {
+	name := strings.TrimSuffix(filepath.Base(path), draftExt)
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return "", name, nil
+	}
+	ns, err := url.PathUnescape(name[:i])
+	if err != nil {
+		return "", "", err
+	}
+	return ns, name[i+1:], nil
+}
@@ -0,0 +1,41 @@
+package editor
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestListDrafts(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	write := func(id, ns, content string, mod time.Time) {
+		t.Helper()
+		path, err := PathForID(id, ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := PrepareAt(path, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	write("old1", "team.space x", ComposeContent("Old", nil, ""), now.Add(-time.Hour))
+	write("new2", "", ComposeContent("New", nil, "body"), now)
+
+	ds, err := ListDrafts()
+	if err != nil {
+		t.Fatalf("ListDrafts: %v", err)
+	}
+	if len(ds) != 2 || ds[0].ID != "new2" || ds[0].Namespace != "" || ds[0].Content != ComposeContent("New", nil, "body") {
+		t.Fatalf("drafts=%+v", ds)
+	}
+	if ds[1].ID != "old1" || ds[1].Namespace != "team.space x" {
+		t.Fatalf("drafts[1]=%+v", ds[1])
+	}
+	if got := DraftID(ds[1].Path); got != "old1" {
+		t.Fatalf("DraftID=%q", got)
+	}
+}
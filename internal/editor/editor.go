@@ -14,14 +14,30 @@ import (
 const (
 	TitlePrefix = "Title: "
 	TagsPrefix  = "Tags: "
+	// RemindPrefix starts the optional reminder header, e.g.
+	// "Remind: tomorrow 9am".
+	RemindPrefix = "Remind: "
 )
 
 // ComposeContent creates the text presented to the editor.
 func ComposeContent(title string, tags []string, body string) string {
+	return compose(title, tags, nil, body)
+}
+
+// ComposeContentRemind is ComposeContent with a Remind header holding
+// remind, which may be empty.
+func ComposeContentRemind(title string, tags []string, remind string, body string) string {
+	return compose(title, tags, &remind, body)
+}
+
+func compose(title string, tags []string, remind *string, body string) string {
 	var b bytes.Buffer
 	b.WriteString("# GinkGo Note\n")
 	b.WriteString("# Lines starting with '#' are ignored.\n")
 	b.WriteString("# Set Title and Tags (comma-separated). After '---', write Markdown body.\n")
+	if remind != nil {
+		b.WriteString("# Remind takes a time like 'tomorrow 9am'; leave it empty for no reminder.\n")
+	}
 	b.WriteString(TitlePrefix)
 	b.WriteString(title)
 	b.WriteString("\n")
@@ -29,6 +45,11 @@ func ComposeContent(title string, tags []string, body string) string {
 	if len(tags) > 0 {
 		b.WriteString(strings.Join(tags, ", "))
 	}
+	if remind != nil {
+		b.WriteString("\n")
+		b.WriteString(RemindPrefix)
+		b.WriteString(*remind)
+	}
 	b.WriteString("\n---\n")
 	if body != "" {
 		if !strings.HasSuffix(body, "\n") {
@@ -61,15 +82,23 @@ func PathForID(id string, namespace string) (string, error) {
 	if strings.TrimSpace(namespace) != "" {
 		prefix = encodeNamespace(namespace) + "."
 	}
-	name := prefix + id + ".ginkgo.md"
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, prefix+id+draftExt), nil
+}
+
+// Dir is the directory of the editor's temp files.
+func Dir() (string, error) {
 	if xdg := os.Getenv("XDG_RUNTIME_DIR"); xdg != "" {
-		return filepath.Join(xdg, "ginkgo", name), nil
+		return filepath.Join(xdg, "ginkgo"), nil
 	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".cache", "ginkgo", "edit", name), nil
+	return filepath.Join(home, ".cache", "ginkgo", "edit"), nil
 }
 
 func encodeNamespace(namespace string) string {
@@ -176,6 +205,20 @@ func ParseEditedNote(s string) (title string, tags []string, body string) {
 	return title, tags, strings.TrimSpace(body)
 }
 
+// ParseRemind returns the Remind header of the editor output, or "" when
+// it is missing or empty.
+func ParseRemind(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, strings.TrimSpace(RemindPrefix)); ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
 // FirstLine returns the first trimmed line, squashed and truncated.
 func FirstLine(s string) string {
 	s = strings.TrimSpace(s)
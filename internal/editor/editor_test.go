@@ -72,3 +72,17 @@ func TestPathForID(t *testing.T) {
 		t.Fatalf("PathForID base=%q", base)
 	}
 }
+
+func TestParseRemind(t *testing.T) {
+	content := ComposeContentRemind("T", []string{"a"}, "tomorrow 9am", "Remind: not a header")
+	if got := ParseRemind(content); got != "tomorrow 9am" {
+		t.Fatalf("ParseRemind=%q", got)
+	}
+	title, tags, body := ParseEditedNote(content)
+	if title != "T" || len(tags) != 1 || body != "Remind: not a header" {
+		t.Fatalf("ParseEditedNote=%q %v %q", title, tags, body)
+	}
+	if got := ParseRemind(ComposeContent("T", nil, "")); got != "" {
+		t.Fatalf("ParseRemind without header=%q", got)
+	}
+}
@@ -0,0 +1,366 @@
+package editor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the editor file: the Title:/Tags:/--- headers, or YAML
+// frontmatter as Markdown tools write it.
+const (
+	FormatHeaders     = "headers"
+	FormatFrontmatter = "frontmatter"
+)
+
+// annotationPrefix starts the lines Annotate puts at the top of a file.
+const annotationPrefix = "# ginkgo: "
+
+// Note is the content of an editor file. Remind is nil when the file has no
+// Remind header. Namespace, CreatedAt and Fields (the keys it does not know)
+// are only kept by the frontmatter format; Fields is non-nil whenever the
+// file has frontmatter, so an empty map means all fields were removed.
+type Note struct {
+	Title     string
+	Tags      []string
+	Namespace string
+	CreatedAt time.Time
+	Remind    *string
+	Fields    map[string]any
+	Body      string
+
+	// lines maps frontmatter keys to their file lines, for Errorf.
+	lines map[string]int
+}
+
+// RemindText is the Remind header, "" when missing.
+func (n Note) RemindText() string {
+	if n.Remind == nil {
+		return ""
+	}
+	return *n.Remind
+}
+
+// NewCreatedAt is n.CreatedAt when it differs from was, zero otherwise. The
+// file keeps created_at to the second, so a note left alone keeps its
+// sub-second time.
+func (n Note) NewCreatedAt(was time.Time) time.Time {
+	if n.CreatedAt.IsZero() || n.CreatedAt.Equal(was.Truncate(time.Second)) || n.CreatedAt.Equal(was) {
+		return time.Time{}
+	}
+	return n.CreatedAt
+}
+
+// Errorf returns a SyntaxError at the line of frontmatter key, or at the
+// first line when the key is not there.
+func (n Note) Errorf(key, format string, args ...any) error {
+	line := n.lines[key]
+	if line == 0 {
+		line = 1
+	}
+	return &SyntaxError{Line: line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// SyntaxError is a problem in the editor file at Line (1-based).
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string { return fmt.Sprintf("line %d: %s", e.Line, e.Msg) }
+
+// Compose renders n in format; anything but FormatFrontmatter is the
+// headers format.
+func Compose(format string, n Note) string {
+	if format == FormatFrontmatter {
+		return ComposeFrontmatter(n)
+	}
+	return compose(n.Title, n.Tags, n.Remind, n.Body)
+}
+
+// Parse reads editor output in format. Only frontmatter fails to parse.
+func Parse(format, s string) (Note, error) {
+	if format == FormatFrontmatter {
+		return ParseFrontmatter(s)
+	}
+	title, tags, body := ParseEditedNote(s)
+	n := Note{Title: title, Tags: tags, Body: body}
+	if hasRemindHeader(s) {
+		r := ParseRemind(s)
+		n.Remind = &r
+	}
+	return n, nil
+}
+
+// DetectFormat tells the format of editor content: frontmatter when it
+// starts with a '---' line.
+func DetectFormat(s string) string {
+	s, _ = stripAnnotations(s)
+	if first, _, _ := strings.Cut(strings.TrimLeft(s, " \t\r\n"), "\n"); strings.TrimRight(first, " \t\r") == "---" {
+		return FormatFrontmatter
+	}
+	return FormatHeaders
+}
+
+// Edit opens the editor on path with initial content and parses the result
+// in format. Output that fails to parse, or that check rejects with a
+// *SyntaxError, is shown again with the error at the top until it parses;
+// leaving the editor without fixing it returns the error. changed reports
+// whether out differs from initial.
+func Edit(path, format string, initial []byte, check func(Note) error) (n Note, out []byte, changed bool, err error) {
+	content := initial
+	for shown := 0; ; shown++ {
+		var edited bool
+		out, edited, err = OpenAt(path, content)
+		if err != nil {
+			return Note{}, nil, false, err
+		}
+		n, err = Parse(format, string(out))
+		if err == nil && check != nil {
+			err = check(n)
+		}
+		changed = !bytes.Equal(out, initial)
+		var se *SyntaxError
+		if err == nil || !errors.As(err, &se) || (shown > 0 && !edited) {
+			return n, out, changed, err
+		}
+		content = []byte(Annotate(string(out), err))
+	}
+}
+
+// Annotate puts err at the top of editor content s as comment lines,
+// replacing those of an earlier Annotate. A SyntaxError's line is adjusted
+// to the annotated content.
+func Annotate(s string, err error) string {
+	rest, old := stripAnnotations(s)
+	hint := annotationPrefix + "fix this and save, or quit without saving to keep it as a draft"
+	msg := err.Error()
+	var se *SyntaxError
+	if errors.As(err, &se) {
+		msg = (&SyntaxError{Line: se.Line - old + 2, Msg: se.Msg}).Error()
+	}
+	return annotationPrefix + msg + "\n" + hint + "\n" + rest
+}
+
+// stripAnnotations removes the leading Annotate lines of s and returns how
+// many there were.
+func stripAnnotations(s string) (string, int) {
+	n := 0
+	for strings.HasPrefix(s, annotationPrefix) {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			return "", n + 1
+		}
+		s, n = s[i+1:], n+1
+	}
+	return s, n
+}
+
+// ComposeFrontmatter renders n as YAML frontmatter (title, tags, namespace,
+// created_at, remind, then custom fields by name) followed by the body.
+func ComposeFrontmatter(n Note) string {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(key string, v *yaml.Node) {
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, v)
+	}
+	add("title", strNode(n.Title))
+	tags := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, t := range n.Tags {
+		tags.Content = append(tags.Content, strNode(t))
+	}
+	add("tags", tags)
+	if n.Namespace != "" {
+		add("namespace", strNode(n.Namespace))
+	}
+	if !n.CreatedAt.IsZero() {
+		add("created_at", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: n.CreatedAt.Local().Format(time.RFC3339)})
+	}
+	if n.Remind != nil {
+		add("remind", strNode(*n.Remind))
+	}
+	keys := make([]string, 0, len(n.Fields))
+	for k := range n.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var v yaml.Node
+		if err := v.Encode(n.Fields[k]); err != nil {
+			v = *strNode(fmt.Sprint(n.Fields[k]))
+		}
+		add(k, &v)
+	}
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	_ = enc.Encode(doc)
+	_ = enc.Close()
+	b.WriteString("---\n")
+	if n.Body != "" {
+		b.WriteString(n.Body)
+		if !strings.HasSuffix(n.Body, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func strNode(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// ParseFrontmatter reads a Markdown file with optional YAML frontmatter;
+// without one the whole file is the body. Errors are *SyntaxError with the
+// file's line numbers.
+func ParseFrontmatter(s string) (Note, error) {
+	s, offset := stripAnnotations(s)
+	lines := strings.Split(s, "\n")
+	start := 0
+	for start < len(lines) && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	if start == len(lines) || strings.TrimRight(lines[start], " \t\r") != "---" {
+		return Note{Body: strings.TrimSpace(s)}, nil
+	}
+	// fileLine turns a line of the YAML document into one of the file.
+	fileLine := func(l int) int { return offset + start + 1 + l }
+	end := -1
+	for i := start + 1; i < len(lines); i++ {
+		if t := strings.TrimRight(lines[i], " \t\r"); t == "---" || t == "..." {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return Note{}, &SyntaxError{Line: fileLine(0), Msg: "frontmatter has no closing '---' line"}
+	}
+	n := Note{Fields: map[string]any{}, Body: strings.TrimSpace(strings.Join(lines[end+1:], "\n")), lines: map[string]int{}}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[start+1:end], "\n")), &doc); err != nil {
+		return Note{}, yamlError(err, fileLine)
+	}
+	if len(doc.Content) == 0 {
+		return n, nil
+	}
+	m := doc.Content[0]
+	if m.Kind != yaml.MappingNode {
+		return Note{}, &SyntaxError{Line: fileLine(m.Line), Msg: "frontmatter must be a mapping of keys to values"}
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		k, v := m.Content[i], m.Content[i+1]
+		bad := func(format string, args ...any) error {
+			return &SyntaxError{Line: fileLine(v.Line), Msg: k.Value + ": " + fmt.Sprintf(format, args...)}
+		}
+		if first, ok := n.lines[k.Value]; ok {
+			return Note{}, bad("already set on line %d", first)
+		}
+		n.lines[k.Value] = fileLine(k.Line)
+		switch k.Value {
+		case "title", "namespace", "remind":
+			if v.Kind != yaml.ScalarNode {
+				return Note{}, bad("must be text")
+			}
+			val := v.Value
+			if v.Tag == "!!null" {
+				val = ""
+			}
+			switch k.Value {
+			case "title":
+				n.Title = strings.TrimSpace(val)
+			case "namespace":
+				n.Namespace = strings.TrimSpace(val)
+			default:
+				r := strings.TrimSpace(val)
+				n.Remind = &r
+			}
+		case "tags":
+			switch v.Kind {
+			case yaml.SequenceNode:
+				for _, t := range v.Content {
+					if t.Kind != yaml.ScalarNode {
+						return Note{}, bad("must be a list of words")
+					}
+					if tt := strings.TrimSpace(t.Value); tt != "" {
+						n.Tags = append(n.Tags, tt)
+					}
+				}
+			case yaml.ScalarNode:
+				if v.Tag == "!!null" {
+					break
+				}
+				for _, t := range strings.Split(v.Value, ",") {
+					if tt := strings.TrimSpace(t); tt != "" {
+						n.Tags = append(n.Tags, tt)
+					}
+				}
+			default:
+				return Note{}, bad("must be a list of words")
+			}
+		case "created_at":
+			if v.Tag == "!!null" {
+				break
+			}
+			t, err := parseTimeNode(v)
+			if err != nil {
+				return Note{}, bad("%q is not a time like 2006-01-02T15:04:05Z or 2006-01-02 15:04", v.Value)
+			}
+			n.CreatedAt = t.UTC()
+		default:
+			var val any
+			if err := v.Decode(&val); err != nil {
+				return Note{}, bad("%v", err)
+			}
+			n.Fields[k.Value] = val
+		}
+	}
+	return n, nil
+}
+
+func parseTimeNode(v *yaml.Node) (time.Time, error) {
+	var t time.Time
+	if err := v.Decode(&t); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, v.Value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("not a time")
+}
+
+var yamlLineRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// yamlError turns a yaml.v3 error into a SyntaxError on the file's lines.
+func yamlError(err error, fileLine func(int) int) error {
+	msg := err.Error()
+	if te := (*yaml.TypeError)(nil); errors.As(err, &te) && len(te.Errors) > 0 {
+		msg = te.Errors[0]
+	}
+	if m := yamlLineRe.FindStringSubmatch(msg); m != nil {
+		l, _ := strconv.Atoi(m[1])
+		return &SyntaxError{Line: fileLine(l), Msg: m[2]}
+	}
+	return &SyntaxError{Line: fileLine(0), Msg: strings.TrimPrefix(msg, "yaml: ")}
+}
+
+// hasRemindHeader reports whether headers-format content has a Remind line.
+func hasRemindHeader(s string) bool {
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "---" {
+			return false
+		}
+		if strings.HasPrefix(line, strings.TrimSpace(RemindPrefix)) {
+			return true
+		}
+	}
+	return false
+}
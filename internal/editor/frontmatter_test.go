@@ -0,0 +1,96 @@
+package editor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFrontmatterRoundTrip(t *testing.T) {
+	remind := "tomorrow 9am"
+	in := Note{
+		Title:     "yes: a title",
+		Tags:      []string{"work", "deep work"},
+		Namespace: "team.space",
+		CreatedAt: time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC),
+		Remind:    &remind,
+		Fields:    map[string]any{"mood": 4, "project": "ginkgo", "done": true},
+		Body:      "# Heading\n\n---\nbody",
+	}
+	s := ComposeFrontmatter(in)
+	if !strings.HasPrefix(s, "---\ntitle: ") {
+		t.Fatalf("compose=%q", s)
+	}
+	out, err := ParseFrontmatter(s)
+	if err != nil {
+		t.Fatalf("parse: %v\n%s", err, s)
+	}
+	out.lines = nil
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("round trip:\n in=%+v\nout=%+v", in, out)
+	}
+}
+
+func TestParseFrontmatterForms(t *testing.T) {
+	n, err := ParseFrontmatter("# Just Markdown\nbody\n")
+	if err != nil || n.Body != "# Just Markdown\nbody" || n.Title != "" {
+		t.Fatalf("no frontmatter: %+v %v", n, err)
+	}
+	n, err = ParseFrontmatter("---\ntags: a, b\ncreated_at: 2025-03-01 09:30\n---\n")
+	if err != nil || !reflect.DeepEqual(n.Tags, []string{"a", "b"}) || n.CreatedAt.IsZero() || n.Remind != nil {
+		t.Fatalf("tags string: %+v %v", n, err)
+	}
+	if DetectFormat(ComposeFrontmatter(Note{})) != FormatFrontmatter || DetectFormat(ComposeContent("T", nil, "")) != FormatHeaders {
+		t.Fatal("DetectFormat")
+	}
+	n, err = Parse(FormatHeaders, ComposeContentRemind("T", nil, "", "b"))
+	if err != nil || n.Title != "T" || n.Remind == nil {
+		t.Fatalf("headers: %+v %v", n, err)
+	}
+}
+
+func TestParseFrontmatterErrors(t *testing.T) {
+	cases := []struct {
+		in   string
+		line int
+		msg  string
+	}{
+		{"---\ntitle: a\n  bad: x\n---\n", 3, "mapping values"},
+		{"\n---\ntitle: [a]\n---\n", 3, "title: must be text"},
+		{"---\ntitle: a\ntitle: b\n---\n", 3, "already set on line 2"},
+		{"---\ncreated_at: someday\n---\n", 2, "created_at"},
+		{"---\ntitle: a\n", 1, "no closing"},
+	}
+	for _, c := range cases {
+		_, err := ParseFrontmatter(c.in)
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Line != c.line || !strings.Contains(se.Msg, c.msg) {
+			t.Errorf("%q: err=%v, want line %d %q", c.in, err, c.line, c.msg)
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	bad := "---\ntitle: [a]\n---\nbody\n"
+	_, err := ParseFrontmatter(bad)
+	shown := Annotate(bad, err)
+	if !strings.HasPrefix(shown, "# ginkgo: line 4: title: must be text\n") || !strings.HasSuffix(shown, bad) {
+		t.Fatalf("annotated=%q", shown)
+	}
+	// Errors in annotated content point at its lines; re-annotating
+	// replaces the old lines.
+	_, err = ParseFrontmatter(shown)
+	var se *SyntaxError
+	if !errors.As(err, &se) || se.Line != 4 {
+		t.Fatalf("annotated parse err=%v", err)
+	}
+	if again := Annotate(shown, err); again != shown {
+		t.Fatalf("re-annotated=%q", again)
+	}
+	n, err := ParseFrontmatter(Annotate("---\ntitle: ok\n---\n", errors.New("x")))
+	if err != nil || n.Title != "ok" {
+		t.Fatalf("annotations not stripped: %+v %v", n, err)
+	}
+}
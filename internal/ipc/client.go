@@ -2,6 +2,7 @@ package ipc
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -18,9 +19,9 @@ func Request(ctx context.Context, path string, m Message) (Response, error) {
 	preq := &pb.Request{}
 	switch m.Name {
 	case "note.add":
-		preq.Cmd = &pb.Request_NoteAdd{NoteAdd: &pb.NoteAdd{Title: m.Title, Body: m.Body, Tags: m.Tags, Namespace: m.Namespace}}
+		preq.Cmd = &pb.Request_NoteAdd{NoteAdd: &pb.NoteAdd{Title: m.Title, Body: m.Body, Tags: m.Tags, Namespace: m.Namespace, Dedupe: m.Dedupe, CreatedAt: pbTimestamp(m.CreatedAt), UpdatedAt: pbTimestamp(m.UpdatedAt), Fields: toPbFields(m.Fields)}}
 	case "note.edit":
-		preq.Cmd = &pb.Request_NoteEdit{NoteEdit: &pb.NoteEdit{Id: m.ID, IfVersion: m.IfVersion, Title: m.Title, Body: m.Body, Tags: m.Tags, Namespace: m.Namespace}}
+		preq.Cmd = &pb.Request_NoteEdit{NoteEdit: &pb.NoteEdit{Id: m.ID, IfVersion: m.IfVersion, Title: m.Title, Body: m.Body, Tags: m.Tags, Namespace: m.Namespace, CreatedAt: pbTimestamp(m.CreatedAt), Fields: toPbFields(m.Fields), SetFields: m.SetFields}}
 	case "note.delete":
 		preq.Cmd = &pb.Request_NoteDelete{NoteDelete: &pb.NoteDelete{Id: m.ID, Namespace: m.Namespace}}
 	case "note.show":
@@ -28,19 +29,73 @@ func Request(ctx context.Context, path string, m Message) (Response, error) {
 	case "note.list":
 		preq.Cmd = &pb.Request_NoteList{NoteList: toPbListFilter(m)}
 	case "note.search.fts":
-		preq.Cmd = &pb.Request_NoteSearchFts{NoteSearchFts: &pb.SearchFTS{Query: m.Title, Filter: toPbListFilter(m)}}
+		preq.Cmd = &pb.Request_NoteSearchFts{NoteSearchFts: &pb.SearchFTS{Query: m.Title, Filter: toPbListFilter(m), SortBy: m.SortBy}}
+	case "note.search.fuzzy":
+		preq.Cmd = &pb.Request_NoteSearchFuzzy{NoteSearchFuzzy: &pb.SearchFTS{Query: m.Title, Filter: toPbListFilter(m), SortBy: m.SortBy}}
 	case "note.search.regex":
 		preq.Cmd = &pb.Request_NoteSearchRegex{NoteSearchRegex: &pb.SearchRegex{Pattern: m.Title, Filter: toPbListFilter(m)}}
 	case "sync.run":
 		preq.Cmd = &pb.Request_SyncRun{SyncRun: &pb.SyncRun{}}
 	case "sync.queue":
 		preq.Cmd = &pb.Request_QueueList{QueueList: &pb.QueueRequest{Limit: int32(m.Limit), Remote: m.Remote}}
+	case "sync.status":
+		preq.Cmd = &pb.Request_SyncStatus{SyncStatus: &pb.SyncStatusRequest{Remote: m.Remote}}
+	case "sync.plan":
+		preq.Cmd = &pb.Request_SyncPlan{SyncPlan: &pb.SyncPlanRequest{Remote: m.Remote, Limit: int32(m.Limit)}}
+	case "sync.replay":
+		rr := &pb.SyncReplayRequest{Remote: m.Remote}
+		if ts := parseRFC3339OrEmpty(m.Since); !ts.IsZero() {
+			rr.From = timestamppb.New(ts)
+		}
+		preq.Cmd = &pb.Request_SyncReplay{SyncReplay: rr}
 	case "namespace.list":
 		preq.Cmd = &pb.Request_NamespaceList{NamespaceList: &pb.NamespaceList{}}
 	case "namespace.delete":
 		preq.Cmd = &pb.Request_NamespaceDelete{NamespaceDelete: &pb.NamespaceDelete{Namespace: m.Namespace}}
 	case "tag.list":
 		preq.Cmd = &pb.Request_TagList{TagList: &pb.TagList{Namespace: m.Namespace}}
+	case "note.related":
+		preq.Cmd = &pb.Request_NoteRelated{NoteRelated: &pb.NoteRelated{Id: m.ID, Namespace: m.Namespace, Limit: int32(m.Limit)}}
+	case "note.dupes":
+		preq.Cmd = &pb.Request_NoteDupes{NoteDupes: &pb.NoteDupes{Namespace: m.Namespace}}
+	case "note.merge":
+		preq.Cmd = &pb.Request_NoteMerge{NoteMerge: &pb.NoteMerge{Id: m.ID, Others: m.Args, Namespace: m.Namespace}}
+	case "note.links":
+		preq.Cmd = &pb.Request_NoteLinks{NoteLinks: &pb.NoteLinks{Id: m.ID, Namespace: m.Namespace}}
+	case "note.backlinks":
+		preq.Cmd = &pb.Request_NoteBacklinks{NoteBacklinks: &pb.NoteBacklinks{Id: m.ID, Namespace: m.Namespace}}
+	case "tag.describe", "tag.rename", "tag.merge", "tag.delete":
+		preq.Cmd = &pb.Request_TagEdit{TagEdit: &pb.TagEdit{
+			Op: strings.TrimPrefix(m.Name, "tag."), Namespace: m.Namespace, Tags: m.Tags,
+			To: m.Title, Description: m.Body, Recursive: m.Recursive,
+		}}
+	case "notify.status", "notify.snooze", "notify.mute", "notify.unmute":
+		preq.Cmd = &pb.Request_Notify{Notify: &pb.NotifyControl{
+			Op: strings.TrimPrefix(m.Name, "notify."), Namespace: m.Namespace, Until: m.Until,
+		}}
+	case "note.remind":
+		preq.Cmd = &pb.Request_NoteRemind{NoteRemind: &pb.NoteRemind{Id: m.ID, Namespace: m.Namespace, At: m.At, Device: m.Device}}
+	case "note.reminders":
+		preq.Cmd = &pb.Request_NoteReminders{NoteReminders: &pb.NoteReminders{Namespace: m.Namespace, All: m.All}}
+	case "note.daily":
+		preq.Cmd = &pb.Request_NoteDaily{NoteDaily: &pb.NoteDaily{Namespace: m.Namespace, Date: m.Date, Title: m.Title, Body: m.Body, Tags: m.Tags}}
+	case "draft.save":
+		preq.Cmd = &pb.Request_DraftSave{DraftSave: &pb.DraftSave{Draft: &pb.Draft{
+			Id: m.ID, Namespace: m.Namespace, Path: m.Path, BaseVersion: m.IfVersion, Content: m.Body,
+		}}}
+	case "draft.list":
+		preq.Cmd = &pb.Request_DraftList{DraftList: &pb.DraftList{Namespace: m.Namespace, All: m.All}}
+	case "draft.delete":
+		preq.Cmd = &pb.Request_DraftDelete{DraftDelete: &pb.DraftDelete{Id: m.ID}}
+	case "view.save":
+		preq.Cmd = &pb.Request_ViewSave{ViewSave: &pb.ViewSave{View: &pb.View{
+			Name: m.Title, Namespace: m.Namespace, Query: m.Query,
+			TagsAny: m.TagsAny, TagsAll: m.TagsAll, Since: m.Since, Until: m.Until,
+		}}}
+	case "view.list":
+		preq.Cmd = &pb.Request_ViewList{ViewList: &pb.ViewList{Namespace: m.Namespace, Name: m.Title}}
+	case "view.delete":
+		preq.Cmd = &pb.Request_ViewDelete{ViewDelete: &pb.ViewDelete{Namespace: m.Namespace, Name: m.Title}}
 	}
 
 	c := transport.NewUnixClient(path)
@@ -53,6 +108,7 @@ func Request(ctx context.Context, path string, m Message) (Response, error) {
 	presp := prespAny.(*pb.Response)
 	r.OK = presp.Ok
 	r.Msg = presp.Msg
+	r.Changed = int(presp.Changed)
 	if presp.Entry != nil {
 		r.Entry = fromPbEntry(presp.Entry)
 	}
@@ -62,6 +118,36 @@ func Request(ctx context.Context, path string, m Message) (Response, error) {
 			r.Entries = append(r.Entries, *fromPbEntry(e))
 		}
 	}
+	if len(presp.Hits) > 0 {
+		r.Hits = make([]api.SearchHit, 0, len(presp.Hits))
+		for _, h := range presp.Hits {
+			r.Hits = append(r.Hits, fromPbSearchHit(h))
+		}
+		// Hits carry their entries; expose them as Entries too so paging
+		// helpers work unchanged.
+		if len(r.Entries) == 0 {
+			r.Entries = make([]api.Entry, 0, len(r.Hits))
+			for _, h := range r.Hits {
+				r.Entries = append(r.Entries, h.Entry)
+			}
+		}
+	}
+	r.Corrected = presp.Corrected
+	for _, ts := range presp.Suggestions {
+		r.Suggestions = append(r.Suggestions, api.TermSuggestion{Term: ts.GetTerm(), Suggestions: ts.GetSuggestions()})
+	}
+	for _, v := range presp.Views {
+		r.Views = append(r.Views, fromPbView(v))
+	}
+	for _, d := range presp.Duplicates {
+		r.Duplicates = append(r.Duplicates, fromPbDuplicate(d))
+	}
+	for _, c := range presp.Clusters {
+		r.Clusters = append(r.Clusters, fromPbDupeCluster(c))
+	}
+	for _, l := range presp.Links {
+		r.Links = append(r.Links, api.Link{Target: l.GetTarget(), Entry: fromPbEntry(l.GetEntry())})
+	}
 	if len(presp.Queue) > 0 {
 		r.Queue = make([]QueueRemote, 0, len(presp.Queue))
 		for _, q := range presp.Queue {
@@ -85,7 +171,28 @@ func Request(ctx context.Context, path string, m Message) (Response, error) {
 	if len(presp.Tags) > 0 {
 		r.Tags = make([]api.TagStat, 0, len(presp.Tags))
 		for _, t := range presp.Tags {
-			r.Tags = append(r.Tags, api.TagStat{Tag: t.GetTag(), Count: int(t.GetCount()), Description: t.GetDescription()})
+			r.Tags = append(r.Tags, api.TagStat{Tag: t.GetTag(), Count: int(t.GetCount()), Description: t.GetDescription(), Frecency: int(t.GetFrecency())})
+		}
+	}
+	for _, st := range presp.NotifyStatus {
+		r.NotifyStatus = append(r.NotifyStatus, fromPbNotifyStatus(st))
+	}
+	for _, rm := range presp.Reminders {
+		r.Reminders = append(r.Reminders, fromPbReminder(rm))
+	}
+	for _, d := range presp.Drafts {
+		r.Drafts = append(r.Drafts, fromPbDraft(d))
+	}
+	if len(presp.SyncStatus) > 0 {
+		r.SyncStatus = make([]SyncStatus, 0, len(presp.SyncStatus))
+		for _, st := range presp.SyncStatus {
+			r.SyncStatus = append(r.SyncStatus, fromPbSyncStatus(st))
+		}
+	}
+	if len(presp.SyncPlan) > 0 {
+		r.SyncPlan = make([]SyncPlan, 0, len(presp.SyncPlan))
+		for _, p := range presp.SyncPlan {
+			r.SyncPlan = append(r.SyncPlan, fromPbSyncPlan(p))
 		}
 	}
 	if presp.Page != nil {
@@ -94,8 +201,137 @@ func Request(ctx context.Context, path string, m Message) (Response, error) {
 	return r, nil
 }
 
+func fromPbSearchHit(h *pb.SearchHit) api.SearchHit {
+	out := api.SearchHit{Score: h.GetScore()}
+	if e := fromPbEntry(h.GetEntry()); e != nil {
+		out.Entry = *e
+	}
+	for _, sn := range h.GetSnippets() {
+		s := api.Snippet{Field: sn.GetField(), Text: sn.GetText()}
+		for _, m := range sn.GetMatches() {
+			s.Matches = append(s.Matches, api.TextRange{Start: int(m.GetStart()), End: int(m.GetEnd())})
+		}
+		out.Snippets = append(out.Snippets, s)
+	}
+	return out
+}
+
+func fromPbView(v *pb.View) api.View {
+	return api.View{
+		Name: v.GetName(), Namespace: v.GetNamespace(), Query: v.GetQuery(),
+		TagsAny: v.GetTagsAny(), TagsAll: v.GetTagsAll(), Since: v.GetSince(), Until: v.GetUntil(),
+		UpdatedAt: pbTime(v.GetUpdatedAt()),
+	}
+}
+
+func fromPbDuplicate(d *pb.Duplicate) api.Duplicate {
+	out := api.Duplicate{Exact: d.GetExact(), Distance: int(d.GetDistance())}
+	if e := fromPbEntry(d.GetEntry()); e != nil {
+		out.Entry = *e
+	}
+	return out
+}
+
+func fromPbDupeCluster(c *pb.DupeCluster) api.DupeCluster {
+	out := api.DupeCluster{Exact: c.GetExact()}
+	for _, e := range c.GetEntries() {
+		out.Entries = append(out.Entries, *fromPbEntry(e))
+	}
+	return out
+}
+
+func fromPbSyncStatus(st *pb.SyncStatus) SyncStatus {
+	return SyncStatus{
+		Name:         st.GetName(),
+		URL:          st.GetUrl(),
+		LastAttempt:  pbTime(st.GetLastAttempt()),
+		LastSuccess:  pbTime(st.GetLastSuccess()),
+		LastError:    st.GetLastError(),
+		LastErrorAt:  pbTime(st.GetLastErrorAt()),
+		Failures:     st.GetFailures(),
+		PushedEvents: st.GetPushedEvents(),
+		PulledEvents: st.GetPulledEvents(),
+		PushedBytes:  st.GetPushedBytes(),
+		PulledBytes:  st.GetPulledBytes(),
+		Pending:      st.GetPending(),
+		BackoffMS:    st.GetBackoffMs(),
+		NextRun:      pbTime(st.GetNextRun()),
+	}
+}
+
+func fromPbNotifyStatus(st *pb.NotifyStatus) NotifyStatus {
+	return NotifyStatus{
+		Namespace:    st.GetNamespace(),
+		EveryMS:      st.GetEveryMs(),
+		LastNote:     pbTime(st.GetLastNote()),
+		LastSent:     pbTime(st.GetLastSent()),
+		Due:          pbTime(st.GetDue()),
+		SnoozedUntil: pbTime(st.GetSnoozedUntil()),
+		Muted:        st.GetMuted(),
+		Enabled:      st.GetEnabled(),
+		QuietHours:   st.GetQuietHours(),
+	}
+}
+
+func fromPbReminder(r *pb.Reminder) api.Reminder {
+	return api.Reminder{
+		NoteID:    r.GetNoteId(),
+		Namespace: r.GetNamespace(),
+		At:        pbTime(r.GetAt()),
+		Device:    r.GetDevice(),
+		FiredAt:   pbTime(r.GetFiredAt()),
+		UpdatedAt: pbTime(r.GetUpdatedAt()),
+		Title:     r.GetTitle(),
+	}
+}
+
+func fromPbDraft(d *pb.Draft) api.Draft {
+	return api.Draft{
+		ID:          d.GetId(),
+		Namespace:   d.GetNamespace(),
+		Path:        d.GetPath(),
+		BaseVersion: d.GetBaseVersion(),
+		Content:     d.GetContent(),
+		CreatedAt:   pbTime(d.GetCreatedAt()),
+		UpdatedAt:   pbTime(d.GetUpdatedAt()),
+	}
+}
+
+func fromPbSyncPlan(p *pb.SyncPlan) SyncPlan {
+	out := SyncPlan{
+		Name:      p.GetName(),
+		URL:       p.GetUrl(),
+		Push:      p.GetPush(),
+		Create:    p.GetCreate(),
+		Update:    p.GetUpdate(),
+		Delete:    p.GetDelete(),
+		Unchanged: p.GetUnchanged(),
+		Errors:    p.GetErrors(),
+	}
+	for _, ev := range p.Events {
+		out.Events = append(out.Events, SyncPlanEvent{
+			Time:      pbTime(ev.GetTime()),
+			Type:      ev.GetType(),
+			ID:        ev.GetId(),
+			Namespace: ev.GetNamespace(),
+			Title:     ev.GetTitle(),
+			Action:    ev.GetAction(),
+			Err:       ev.GetErr(),
+		})
+	}
+	return out
+}
+
+// pbTime converts an optional timestamp, keeping unset values as the zero time.
+func pbTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
 func toPbListFilter(m Message) *pb.ListFilter {
-	lf := &pb.ListFilter{Namespace: m.Namespace, TagsAny: m.TagsAny, TagsAll: m.TagsAll, Limit: int32(m.Limit), Cursor: m.Cursor, Reverse: m.Reverse, IncludeBody: m.IncludeBody}
+	lf := &pb.ListFilter{Namespace: m.Namespace, TagsAny: m.TagsAny, TagsAll: m.TagsAll, Limit: int32(m.Limit), Cursor: m.Cursor, Reverse: m.Reverse, IncludeBody: m.IncludeBody, Query: m.Query, Sort: m.Sort}
 	if ts := parseRFC3339OrEmpty(m.Since); !ts.IsZero() {
 		lf.Since = timestamppb.New(ts)
 	}
@@ -127,6 +363,7 @@ func fromPbEntry(e *pb.Entry) *api.Entry {
 		Body:      e.Body,
 		Tags:      append([]string(nil), e.Tags...),
 		Namespace: e.Namespace,
+		Fields:    fromPbFields(e.Fields),
 	}
 	if e.CreatedAt != nil {
 		ae.CreatedAt = e.CreatedAt.AsTime()
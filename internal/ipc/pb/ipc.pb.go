@@ -32,6 +32,7 @@ type Entry struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Namespace     string                 `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Fields        map[string]*FieldValue `protobuf:"bytes,9,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -122,19 +123,132 @@ func (x *Entry) GetNamespace() string {
 	return ""
 }
 
+func (x *Entry) GetFields() map[string]*FieldValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// FieldValue is a custom field value: text, a number or true/false.
+type FieldValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*FieldValue_Text
+	//	*FieldValue_Number
+	//	*FieldValue_Flag
+	Kind          isFieldValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldValue) Reset() {
+	*x = FieldValue{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldValue) ProtoMessage() {}
+
+func (x *FieldValue) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldValue.ProtoReflect.Descriptor instead.
+func (*FieldValue) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FieldValue) GetKind() isFieldValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *FieldValue) GetText() string {
+	if x != nil {
+		if x, ok := x.Kind.(*FieldValue_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *FieldValue) GetNumber() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*FieldValue_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+func (x *FieldValue) GetFlag() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*FieldValue_Flag); ok {
+			return x.Flag
+		}
+	}
+	return false
+}
+
+type isFieldValue_Kind interface {
+	isFieldValue_Kind()
+}
+
+type FieldValue_Text struct {
+	Text string `protobuf:"bytes,1,opt,name=text,proto3,oneof"`
+}
+
+type FieldValue_Number struct {
+	Number float64 `protobuf:"fixed64,2,opt,name=number,proto3,oneof"`
+}
+
+type FieldValue_Flag struct {
+	Flag bool `protobuf:"varint,3,opt,name=flag,proto3,oneof"`
+}
+
+func (*FieldValue_Text) isFieldValue_Kind() {}
+
+func (*FieldValue_Number) isFieldValue_Kind() {}
+
+func (*FieldValue_Flag) isFieldValue_Kind() {}
+
+// dedupe is what to do when the note duplicates a stored one:
+// "skip", "merge" or "keep" (the default).
+// NoteAdd creates a note; created_at and updated_at default to now.
 type NoteAdd struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
 	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Dedupe        string                 `protobuf:"bytes,5,opt,name=dedupe,proto3" json:"dedupe,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Fields        map[string]*FieldValue `protobuf:"bytes,8,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *NoteAdd) Reset() {
 	*x = NoteAdd{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[1]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +260,7 @@ func (x *NoteAdd) String() string {
 func (*NoteAdd) ProtoMessage() {}
 
 func (x *NoteAdd) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[1]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +273,7 @@ func (x *NoteAdd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteAdd.ProtoReflect.Descriptor instead.
 func (*NoteAdd) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{1}
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *NoteAdd) GetTitle() string {
@@ -190,6 +304,36 @@ func (x *NoteAdd) GetNamespace() string {
 	return ""
 }
 
+func (x *NoteAdd) GetDedupe() string {
+	if x != nil {
+		return x.Dedupe
+	}
+	return ""
+}
+
+func (x *NoteAdd) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NoteAdd) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *NoteAdd) GetFields() map[string]*FieldValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// NoteEdit updates a note; an unset created_at keeps the note's, and
+// fields replace the note's only when set_fields is true.
 type NoteEdit struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -198,13 +342,16 @@ type NoteEdit struct {
 	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
 	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
 	Namespace     string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Fields        map[string]*FieldValue `protobuf:"bytes,8,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SetFields     bool                   `protobuf:"varint,9,opt,name=set_fields,json=setFields,proto3" json:"set_fields,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *NoteEdit) Reset() {
 	*x = NoteEdit{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[2]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -216,7 +363,7 @@ func (x *NoteEdit) String() string {
 func (*NoteEdit) ProtoMessage() {}
 
 func (x *NoteEdit) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[2]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -229,7 +376,7 @@ func (x *NoteEdit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteEdit.ProtoReflect.Descriptor instead.
 func (*NoteEdit) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{2}
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *NoteEdit) GetId() string {
@@ -274,6 +421,27 @@ func (x *NoteEdit) GetNamespace() string {
 	return ""
 }
 
+func (x *NoteEdit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NoteEdit) GetFields() map[string]*FieldValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *NoteEdit) GetSetFields() bool {
+	if x != nil {
+		return x.SetFields
+	}
+	return false
+}
+
 type NoteDelete struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -284,7 +452,7 @@ type NoteDelete struct {
 
 func (x *NoteDelete) Reset() {
 	*x = NoteDelete{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[3]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -296,7 +464,7 @@ func (x *NoteDelete) String() string {
 func (*NoteDelete) ProtoMessage() {}
 
 func (x *NoteDelete) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[3]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +477,7 @@ func (x *NoteDelete) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteDelete.ProtoReflect.Descriptor instead.
 func (*NoteDelete) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{3}
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *NoteDelete) GetId() string {
@@ -336,7 +504,7 @@ type NoteShow struct {
 
 func (x *NoteShow) Reset() {
 	*x = NoteShow{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[4]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -348,7 +516,7 @@ func (x *NoteShow) String() string {
 func (*NoteShow) ProtoMessage() {}
 
 func (x *NoteShow) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[4]
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +529,7 @@ func (x *NoteShow) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteShow.ProtoReflect.Descriptor instead.
 func (*NoteShow) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{4}
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *NoteShow) GetId() string {
@@ -378,36 +546,30 @@ func (x *NoteShow) GetNamespace() string {
 	return ""
 }
 
-type ListFilter struct {
+type NoteRelated struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	TagsAny       []string               `protobuf:"bytes,2,rep,name=tags_any,json=tagsAny,proto3" json:"tags_any,omitempty"`
-	TagsAll       []string               `protobuf:"bytes,3,rep,name=tags_all,json=tagsAll,proto3" json:"tags_all,omitempty"`
-	Since         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
-	Until         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
-	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
-	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Reverse       bool                   `protobuf:"varint,8,opt,name=reverse,proto3" json:"reverse,omitempty"`
-	IncludeBody   bool                   `protobuf:"varint,9,opt,name=include_body,json=includeBody,proto3" json:"include_body,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilter) Reset() {
-	*x = ListFilter{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[5]
+func (x *NoteRelated) Reset() {
+	*x = NoteRelated{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilter) String() string {
+func (x *NoteRelated) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFilter) ProtoMessage() {}
+func (*NoteRelated) ProtoMessage() {}
 
-func (x *ListFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[5]
+func (x *NoteRelated) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -418,97 +580,101 @@ func (x *ListFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFilter.ProtoReflect.Descriptor instead.
-func (*ListFilter) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use NoteRelated.ProtoReflect.Descriptor instead.
+func (*NoteRelated) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListFilter) GetNamespace() string {
+func (x *NoteRelated) GetId() string {
 	if x != nil {
-		return x.Namespace
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ListFilter) GetTagsAny() []string {
+func (x *NoteRelated) GetNamespace() string {
 	if x != nil {
-		return x.TagsAny
+		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-func (x *ListFilter) GetTagsAll() []string {
+func (x *NoteRelated) GetLimit() int32 {
 	if x != nil {
-		return x.TagsAll
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *ListFilter) GetSince() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Since
-	}
-	return nil
+type NoteDupes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListFilter) GetUntil() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Until
-	}
-	return nil
+func (x *NoteDupes) Reset() {
+	*x = NoteDupes{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListFilter) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+func (x *NoteDupes) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ListFilter) GetCursor() string {
+func (*NoteDupes) ProtoMessage() {}
+
+func (x *NoteDupes) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[7]
 	if x != nil {
-		return x.Cursor
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ListFilter) GetReverse() bool {
-	if x != nil {
-		return x.Reverse
-	}
-	return false
+// Deprecated: Use NoteDupes.ProtoReflect.Descriptor instead.
+func (*NoteDupes) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListFilter) GetIncludeBody() bool {
+func (x *NoteDupes) GetNamespace() string {
 	if x != nil {
-		return x.IncludeBody
+		return x.Namespace
 	}
-	return false
+	return ""
 }
 
-type SearchFTS struct {
+// NoteMerge folds the notes others into id and deletes them.
+type NoteMerge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Others        []string               `protobuf:"bytes,2,rep,name=others,proto3" json:"others,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchFTS) Reset() {
-	*x = SearchFTS{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[6]
+func (x *NoteMerge) Reset() {
+	*x = NoteMerge{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchFTS) String() string {
+func (x *NoteMerge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchFTS) ProtoMessage() {}
+func (*NoteMerge) ProtoMessage() {}
 
-func (x *SearchFTS) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[6]
+func (x *NoteMerge) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,48 +685,55 @@ func (x *SearchFTS) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchFTS.ProtoReflect.Descriptor instead.
-func (*SearchFTS) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use NoteMerge.ProtoReflect.Descriptor instead.
+func (*NoteMerge) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SearchFTS) GetQuery() string {
+func (x *NoteMerge) GetId() string {
 	if x != nil {
-		return x.Query
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SearchFTS) GetFilter() *ListFilter {
+func (x *NoteMerge) GetOthers() []string {
 	if x != nil {
-		return x.Filter
+		return x.Others
 	}
 	return nil
 }
 
-type SearchRegex struct {
+func (x *NoteMerge) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type NoteLinks struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pattern       string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
-	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchRegex) Reset() {
-	*x = SearchRegex{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[7]
+func (x *NoteLinks) Reset() {
+	*x = NoteLinks{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRegex) String() string {
+func (x *NoteLinks) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRegex) ProtoMessage() {}
+func (*NoteLinks) ProtoMessage() {}
 
-func (x *SearchRegex) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[7]
+func (x *NoteLinks) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -571,47 +744,48 @@ func (x *SearchRegex) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRegex.ProtoReflect.Descriptor instead.
-func (*SearchRegex) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use NoteLinks.ProtoReflect.Descriptor instead.
+func (*NoteLinks) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SearchRegex) GetPattern() string {
+func (x *NoteLinks) GetId() string {
 	if x != nil {
-		return x.Pattern
+		return x.Id
 	}
 	return ""
 }
 
-func (x *SearchRegex) GetFilter() *ListFilter {
+func (x *NoteLinks) GetNamespace() string {
 	if x != nil {
-		return x.Filter
+		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-type TagList struct {
+type NoteBacklinks struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TagList) Reset() {
-	*x = TagList{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[8]
+func (x *NoteBacklinks) Reset() {
+	*x = NoteBacklinks{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TagList) String() string {
+func (x *NoteBacklinks) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagList) ProtoMessage() {}
+func (*NoteBacklinks) ProtoMessage() {}
 
-func (x *TagList) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[8]
+func (x *NoteBacklinks) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,54 +796,60 @@ func (x *TagList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagList.ProtoReflect.Descriptor instead.
-func (*TagList) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use NoteBacklinks.ProtoReflect.Descriptor instead.
+func (*NoteBacklinks) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *TagList) GetNamespace() string {
+func (x *NoteBacklinks) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NoteBacklinks) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-type Request struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Cmd:
-	//
-	//	*Request_NoteAdd
-	//	*Request_NoteEdit
-	//	*Request_NoteDelete
-	//	*Request_NoteShow
-	//	*Request_NoteList
-	//	*Request_NoteSearchFts
-	//	*Request_NoteSearchRegex
-	//	*Request_SyncRun
-	//	*Request_QueueList
-	//	*Request_NamespaceList
-	//	*Request_TagList
-	//	*Request_NamespaceDelete
-	Cmd           isRequest_Cmd `protobuf_oneof:"cmd"`
+type ListFilter struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Namespace   string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	TagsAny     []string               `protobuf:"bytes,2,rep,name=tags_any,json=tagsAny,proto3" json:"tags_any,omitempty"`
+	TagsAll     []string               `protobuf:"bytes,3,rep,name=tags_all,json=tagsAll,proto3" json:"tags_all,omitempty"`
+	Since       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
+	Limit       int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor      string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Reverse     bool                   `protobuf:"varint,8,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	IncludeBody bool                   `protobuf:"varint,9,opt,name=include_body,json=includeBody,proto3" json:"include_body,omitempty"`
+	// query is a search-language expression (tags, dates, text) applied on top
+	// of the other filters.
+	Query string `protobuf:"bytes,10,opt,name=query,proto3" json:"query,omitempty"`
+	// sort orders by a custom field, "-name" for descending.
+	Sort          string `protobuf:"bytes,11,opt,name=sort,proto3" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Request) Reset() {
-	*x = Request{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[9]
+func (x *ListFilter) Reset() {
+	*x = ListFilter{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Request) String() string {
+func (x *ListFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Request) ProtoMessage() {}
+func (*ListFilter) ProtoMessage() {}
 
-func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[9]
+func (x *ListFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,226 +860,2696 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Request.ProtoReflect.Descriptor instead.
-func (*Request) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListFilter.ProtoReflect.Descriptor instead.
+func (*ListFilter) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Request) GetCmd() isRequest_Cmd {
+func (x *ListFilter) GetNamespace() string {
 	if x != nil {
-		return x.Cmd
+		return x.Namespace
 	}
-	return nil
+	return ""
 }
 
-func (x *Request) GetNoteAdd() *NoteAdd {
+func (x *ListFilter) GetTagsAny() []string {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteAdd); ok {
-			return x.NoteAdd
-		}
+		return x.TagsAny
 	}
 	return nil
 }
 
-func (x *Request) GetNoteEdit() *NoteEdit {
+func (x *ListFilter) GetTagsAll() []string {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteEdit); ok {
-			return x.NoteEdit
-		}
+		return x.TagsAll
 	}
 	return nil
 }
 
-func (x *Request) GetNoteDelete() *NoteDelete {
+func (x *ListFilter) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteDelete); ok {
-			return x.NoteDelete
-		}
+		return x.Since
 	}
 	return nil
 }
 
-func (x *Request) GetNoteShow() *NoteShow {
+func (x *ListFilter) GetUntil() *timestamppb.Timestamp {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteShow); ok {
-			return x.NoteShow
-		}
+		return x.Until
 	}
 	return nil
 }
 
-func (x *Request) GetNoteList() *ListFilter {
+func (x *ListFilter) GetLimit() int32 {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteList); ok {
-			return x.NoteList
-		}
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *Request) GetNoteSearchFts() *SearchFTS {
+func (x *ListFilter) GetCursor() string {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteSearchFts); ok {
-			return x.NoteSearchFts
-		}
+		return x.Cursor
 	}
-	return nil
+	return ""
 }
 
-func (x *Request) GetNoteSearchRegex() *SearchRegex {
+func (x *ListFilter) GetReverse() bool {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NoteSearchRegex); ok {
-			return x.NoteSearchRegex
-		}
+		return x.Reverse
 	}
-	return nil
+	return false
 }
 
-func (x *Request) GetSyncRun() *SyncRun {
+func (x *ListFilter) GetIncludeBody() bool {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_SyncRun); ok {
-			return x.SyncRun
-		}
+		return x.IncludeBody
 	}
-	return nil
+	return false
 }
 
-func (x *Request) GetQueueList() *QueueRequest {
+func (x *ListFilter) GetQuery() string {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_QueueList); ok {
-			return x.QueueList
-		}
+		return x.Query
 	}
-	return nil
+	return ""
 }
 
-func (x *Request) GetNamespaceList() *NamespaceList {
+func (x *ListFilter) GetSort() string {
 	if x != nil {
-		if x, ok := x.Cmd.(*Request_NamespaceList); ok {
-			return x.NamespaceList
-		}
+		return x.Sort
 	}
-	return nil
+	return ""
 }
 
-func (x *Request) GetTagList() *TagList {
-	if x != nil {
-		if x, ok := x.Cmd.(*Request_TagList); ok {
-			return x.TagList
-		}
-	}
-	return nil
+type SearchFTS struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	SortBy        string                 `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Request) GetNamespaceDelete() *NamespaceDelete {
-	if x != nil {
-		if x, ok := x.Cmd.(*Request_NamespaceDelete); ok {
-			return x.NamespaceDelete
-		}
-	}
-	return nil
+func (x *SearchFTS) Reset() {
+	*x = SearchFTS{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type isRequest_Cmd interface {
-	isRequest_Cmd()
+func (x *SearchFTS) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Request_NoteAdd struct {
-	NoteAdd *NoteAdd `protobuf:"bytes,1,opt,name=note_add,json=noteAdd,proto3,oneof"`
-}
+func (*SearchFTS) ProtoMessage() {}
 
-type Request_NoteEdit struct {
-	NoteEdit *NoteEdit `protobuf:"bytes,2,opt,name=note_edit,json=noteEdit,proto3,oneof"`
+func (x *SearchFTS) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Request_NoteDelete struct {
-	NoteDelete *NoteDelete `protobuf:"bytes,3,opt,name=note_delete,json=noteDelete,proto3,oneof"`
+// Deprecated: Use SearchFTS.ProtoReflect.Descriptor instead.
+func (*SearchFTS) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{12}
 }
 
-type Request_NoteShow struct {
-	NoteShow *NoteShow `protobuf:"bytes,4,opt,name=note_show,json=noteShow,proto3,oneof"`
+func (x *SearchFTS) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
 }
 
-type Request_NoteList struct {
-	NoteList *ListFilter `protobuf:"bytes,5,opt,name=note_list,json=noteList,proto3,oneof"`
+func (x *SearchFTS) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
 }
 
-type Request_NoteSearchFts struct {
-	NoteSearchFts *SearchFTS `protobuf:"bytes,6,opt,name=note_search_fts,json=noteSearchFts,proto3,oneof"`
+func (x *SearchFTS) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
 }
 
-type Request_NoteSearchRegex struct {
-	NoteSearchRegex *SearchRegex `protobuf:"bytes,7,opt,name=note_search_regex,json=noteSearchRegex,proto3,oneof"`
+type SearchRegex struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pattern       string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Filter        *ListFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Request_SyncRun struct {
-	SyncRun *SyncRun `protobuf:"bytes,8,opt,name=sync_run,json=syncRun,proto3,oneof"`
+func (x *SearchRegex) Reset() {
+	*x = SearchRegex{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Request_QueueList struct {
-	QueueList *QueueRequest `protobuf:"bytes,9,opt,name=queue_list,json=queueList,proto3,oneof"`
+func (x *SearchRegex) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Request_NamespaceList struct {
-	NamespaceList *NamespaceList `protobuf:"bytes,10,opt,name=namespace_list,json=namespaceList,proto3,oneof"`
+func (*SearchRegex) ProtoMessage() {}
+
+func (x *SearchRegex) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Request_TagList struct {
-	TagList *TagList `protobuf:"bytes,11,opt,name=tag_list,json=tagList,proto3,oneof"`
+// Deprecated: Use SearchRegex.ProtoReflect.Descriptor instead.
+func (*SearchRegex) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{13}
 }
 
-type Request_NamespaceDelete struct {
-	NamespaceDelete *NamespaceDelete `protobuf:"bytes,12,opt,name=namespace_delete,json=namespaceDelete,proto3,oneof"`
+func (x *SearchRegex) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *SearchRegex) GetFilter() *ListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// View is a saved list filter. since and until keep the user's relative
+// form (e.g. "7d") and are resolved when the view runs.
+type View struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	TagsAny       []string               `protobuf:"bytes,4,rep,name=tags_any,json=tagsAny,proto3" json:"tags_any,omitempty"`
+	TagsAll       []string               `protobuf:"bytes,5,rep,name=tags_all,json=tagsAll,proto3" json:"tags_all,omitempty"`
+	Since         string                 `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	Until         string                 `protobuf:"bytes,7,opt,name=until,proto3" json:"until,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *View) Reset() {
+	*x = View{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *View) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*View) ProtoMessage() {}
+
+func (x *View) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use View.ProtoReflect.Descriptor instead.
+func (*View) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *View) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *View) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *View) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *View) GetTagsAny() []string {
+	if x != nil {
+		return x.TagsAny
+	}
+	return nil
+}
+
+func (x *View) GetTagsAll() []string {
+	if x != nil {
+		return x.TagsAll
+	}
+	return nil
+}
+
+func (x *View) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *View) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *View) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ViewSave struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          *View                  `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewSave) Reset() {
+	*x = ViewSave{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewSave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewSave) ProtoMessage() {}
+
+func (x *ViewSave) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewSave.ProtoReflect.Descriptor instead.
+func (*ViewSave) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ViewSave) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+// ViewList lists a namespace's views, or fetches one when name is set.
+type ViewList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewList) Reset() {
+	*x = ViewList{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewList) ProtoMessage() {}
+
+func (x *ViewList) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewList.ProtoReflect.Descriptor instead.
+func (*ViewList) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ViewList) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ViewList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ViewDelete struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewDelete) Reset() {
+	*x = ViewDelete{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewDelete) ProtoMessage() {}
+
+func (x *ViewDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewDelete.ProtoReflect.Descriptor instead.
+func (*ViewDelete) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ViewDelete) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ViewDelete) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type TagList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagList) Reset() {
+	*x = TagList{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagList) ProtoMessage() {}
+
+func (x *TagList) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagList.ProtoReflect.Descriptor instead.
+func (*TagList) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TagList) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// TagEdit manages tags. op is "describe" (tags[0] gets description),
+// "rename" (tags[0] becomes to), "merge" (every tag becomes to) or "delete"
+// (recursive also removes child tags).
+type TagEdit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Recursive     bool                   `protobuf:"varint,6,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagEdit) Reset() {
+	*x = TagEdit{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagEdit) ProtoMessage() {}
+
+func (x *TagEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagEdit.ProtoReflect.Descriptor instead.
+func (*TagEdit) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TagEdit) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *TagEdit) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *TagEdit) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TagEdit) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TagEdit) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TagEdit) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+type NotifyControl struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// op is status, snooze, mute or unmute.
+	Op string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	// namespace is empty for all namespaces.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// until ends a snooze (RFC 3339); empty resumes notifications.
+	Until         string `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyControl) Reset() {
+	*x = NotifyControl{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyControl) ProtoMessage() {}
+
+func (x *NotifyControl) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyControl.ProtoReflect.Descriptor instead.
+func (*NotifyControl) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NotifyControl) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *NotifyControl) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NotifyControl) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+// NoteRemind sets the reminder of note id at at (RFC 3339), fired on device
+// (default: the daemon's own). An empty at clears the reminder.
+type NoteRemind struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	At            string                 `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	Device        string                 `protobuf:"bytes,4,opt,name=device,proto3" json:"device,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteRemind) Reset() {
+	*x = NoteRemind{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteRemind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteRemind) ProtoMessage() {}
+
+func (x *NoteRemind) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteRemind.ProtoReflect.Descriptor instead.
+func (*NoteRemind) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NoteRemind) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NoteRemind) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NoteRemind) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+func (x *NoteRemind) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+// NoteReminders lists pending reminders; all includes fired ones.
+type NoteReminders struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteReminders) Reset() {
+	*x = NoteReminders{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteReminders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteReminders) ProtoMessage() {}
+
+func (x *NoteReminders) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteReminders.ProtoReflect.Descriptor instead.
+func (*NoteReminders) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *NoteReminders) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NoteReminders) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// NoteDaily fetches the namespace's note for date (YYYY-MM-DD, default
+// today). With a body it appends the body as a new section, creating the
+// note with title and tags when it does not exist yet.
+type NoteDaily struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteDaily) Reset() {
+	*x = NoteDaily{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteDaily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteDaily) ProtoMessage() {}
+
+func (x *NoteDaily) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteDaily.ProtoReflect.Descriptor instead.
+func (*NoteDaily) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NoteDaily) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NoteDaily) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *NoteDaily) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NoteDaily) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *NoteDaily) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// DraftSave records the editor file of note id (a fresh id for a new note)
+// and the version the edit started from. content is optional; the daemon
+// snapshots the file itself.
+type DraftSave struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Draft         *Draft                 `protobuf:"bytes,1,opt,name=draft,proto3" json:"draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DraftSave) Reset() {
+	*x = DraftSave{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DraftSave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DraftSave) ProtoMessage() {}
+
+func (x *DraftSave) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DraftSave.ProtoReflect.Descriptor instead.
+func (*DraftSave) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DraftSave) GetDraft() *Draft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+// DraftList lists the drafts of namespace, or of every namespace with all.
+type DraftList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DraftList) Reset() {
+	*x = DraftList{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DraftList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DraftList) ProtoMessage() {}
+
+func (x *DraftList) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DraftList.ProtoReflect.Descriptor instead.
+func (*DraftList) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DraftList) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DraftList) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type DraftDelete struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DraftDelete) Reset() {
+	*x = DraftDelete{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DraftDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DraftDelete) ProtoMessage() {}
+
+func (x *DraftDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DraftDelete.ProtoReflect.Descriptor instead.
+func (*DraftDelete) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DraftDelete) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Request struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Cmd:
+	//
+	//	*Request_NoteAdd
+	//	*Request_NoteEdit
+	//	*Request_NoteDelete
+	//	*Request_NoteShow
+	//	*Request_NoteList
+	//	*Request_NoteSearchFts
+	//	*Request_NoteSearchRegex
+	//	*Request_SyncRun
+	//	*Request_QueueList
+	//	*Request_NamespaceList
+	//	*Request_TagList
+	//	*Request_NamespaceDelete
+	//	*Request_SyncStatus
+	//	*Request_SyncPlan
+	//	*Request_SyncReplay
+	//	*Request_NoteSearchFuzzy
+	//	*Request_ViewSave
+	//	*Request_ViewList
+	//	*Request_ViewDelete
+	//	*Request_NoteRelated
+	//	*Request_NoteDupes
+	//	*Request_NoteMerge
+	//	*Request_NoteLinks
+	//	*Request_NoteBacklinks
+	//	*Request_TagEdit
+	//	*Request_Notify
+	//	*Request_NoteRemind
+	//	*Request_NoteReminders
+	//	*Request_NoteDaily
+	//	*Request_DraftSave
+	//	*Request_DraftList
+	//	*Request_DraftDelete
+	Cmd           isRequest_Cmd `protobuf_oneof:"cmd"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Request) GetCmd() isRequest_Cmd {
+	if x != nil {
+		return x.Cmd
+	}
+	return nil
+}
+
+func (x *Request) GetNoteAdd() *NoteAdd {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteAdd); ok {
+			return x.NoteAdd
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteEdit() *NoteEdit {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteEdit); ok {
+			return x.NoteEdit
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteDelete() *NoteDelete {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteDelete); ok {
+			return x.NoteDelete
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteShow() *NoteShow {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteShow); ok {
+			return x.NoteShow
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteList() *ListFilter {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteList); ok {
+			return x.NoteList
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteSearchFts() *SearchFTS {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteSearchFts); ok {
+			return x.NoteSearchFts
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteSearchRegex() *SearchRegex {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteSearchRegex); ok {
+			return x.NoteSearchRegex
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetSyncRun() *SyncRun {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_SyncRun); ok {
+			return x.SyncRun
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetQueueList() *QueueRequest {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_QueueList); ok {
+			return x.QueueList
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNamespaceList() *NamespaceList {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NamespaceList); ok {
+			return x.NamespaceList
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetTagList() *TagList {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_TagList); ok {
+			return x.TagList
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNamespaceDelete() *NamespaceDelete {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NamespaceDelete); ok {
+			return x.NamespaceDelete
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetSyncStatus() *SyncStatusRequest {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_SyncStatus); ok {
+			return x.SyncStatus
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetSyncPlan() *SyncPlanRequest {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_SyncPlan); ok {
+			return x.SyncPlan
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetSyncReplay() *SyncReplayRequest {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_SyncReplay); ok {
+			return x.SyncReplay
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteSearchFuzzy() *SearchFTS {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteSearchFuzzy); ok {
+			return x.NoteSearchFuzzy
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetViewSave() *ViewSave {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_ViewSave); ok {
+			return x.ViewSave
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetViewList() *ViewList {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_ViewList); ok {
+			return x.ViewList
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetViewDelete() *ViewDelete {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_ViewDelete); ok {
+			return x.ViewDelete
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteRelated() *NoteRelated {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteRelated); ok {
+			return x.NoteRelated
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteDupes() *NoteDupes {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteDupes); ok {
+			return x.NoteDupes
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteMerge() *NoteMerge {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteMerge); ok {
+			return x.NoteMerge
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteLinks() *NoteLinks {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteLinks); ok {
+			return x.NoteLinks
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteBacklinks() *NoteBacklinks {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteBacklinks); ok {
+			return x.NoteBacklinks
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetTagEdit() *TagEdit {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_TagEdit); ok {
+			return x.TagEdit
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNotify() *NotifyControl {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_Notify); ok {
+			return x.Notify
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteRemind() *NoteRemind {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteRemind); ok {
+			return x.NoteRemind
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteReminders() *NoteReminders {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteReminders); ok {
+			return x.NoteReminders
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetNoteDaily() *NoteDaily {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_NoteDaily); ok {
+			return x.NoteDaily
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetDraftSave() *DraftSave {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_DraftSave); ok {
+			return x.DraftSave
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetDraftList() *DraftList {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_DraftList); ok {
+			return x.DraftList
+		}
+	}
+	return nil
+}
+
+func (x *Request) GetDraftDelete() *DraftDelete {
+	if x != nil {
+		if x, ok := x.Cmd.(*Request_DraftDelete); ok {
+			return x.DraftDelete
+		}
+	}
+	return nil
+}
+
+type isRequest_Cmd interface {
+	isRequest_Cmd()
+}
+
+type Request_NoteAdd struct {
+	NoteAdd *NoteAdd `protobuf:"bytes,1,opt,name=note_add,json=noteAdd,proto3,oneof"`
+}
+
+type Request_NoteEdit struct {
+	NoteEdit *NoteEdit `protobuf:"bytes,2,opt,name=note_edit,json=noteEdit,proto3,oneof"`
+}
+
+type Request_NoteDelete struct {
+	NoteDelete *NoteDelete `protobuf:"bytes,3,opt,name=note_delete,json=noteDelete,proto3,oneof"`
+}
+
+type Request_NoteShow struct {
+	NoteShow *NoteShow `protobuf:"bytes,4,opt,name=note_show,json=noteShow,proto3,oneof"`
+}
+
+type Request_NoteList struct {
+	NoteList *ListFilter `protobuf:"bytes,5,opt,name=note_list,json=noteList,proto3,oneof"`
+}
+
+type Request_NoteSearchFts struct {
+	NoteSearchFts *SearchFTS `protobuf:"bytes,6,opt,name=note_search_fts,json=noteSearchFts,proto3,oneof"`
+}
+
+type Request_NoteSearchRegex struct {
+	NoteSearchRegex *SearchRegex `protobuf:"bytes,7,opt,name=note_search_regex,json=noteSearchRegex,proto3,oneof"`
+}
+
+type Request_SyncRun struct {
+	SyncRun *SyncRun `protobuf:"bytes,8,opt,name=sync_run,json=syncRun,proto3,oneof"`
+}
+
+type Request_QueueList struct {
+	QueueList *QueueRequest `protobuf:"bytes,9,opt,name=queue_list,json=queueList,proto3,oneof"`
+}
+
+type Request_NamespaceList struct {
+	NamespaceList *NamespaceList `protobuf:"bytes,10,opt,name=namespace_list,json=namespaceList,proto3,oneof"`
+}
+
+type Request_TagList struct {
+	TagList *TagList `protobuf:"bytes,11,opt,name=tag_list,json=tagList,proto3,oneof"`
+}
+
+type Request_NamespaceDelete struct {
+	NamespaceDelete *NamespaceDelete `protobuf:"bytes,12,opt,name=namespace_delete,json=namespaceDelete,proto3,oneof"`
+}
+
+type Request_SyncStatus struct {
+	SyncStatus *SyncStatusRequest `protobuf:"bytes,13,opt,name=sync_status,json=syncStatus,proto3,oneof"`
+}
+
+type Request_SyncPlan struct {
+	SyncPlan *SyncPlanRequest `protobuf:"bytes,14,opt,name=sync_plan,json=syncPlan,proto3,oneof"`
+}
+
+type Request_SyncReplay struct {
+	SyncReplay *SyncReplayRequest `protobuf:"bytes,15,opt,name=sync_replay,json=syncReplay,proto3,oneof"`
+}
+
+type Request_NoteSearchFuzzy struct {
+	// note_search_fuzzy corrects unknown terms before running the search.
+	NoteSearchFuzzy *SearchFTS `protobuf:"bytes,16,opt,name=note_search_fuzzy,json=noteSearchFuzzy,proto3,oneof"`
+}
+
+type Request_ViewSave struct {
+	ViewSave *ViewSave `protobuf:"bytes,17,opt,name=view_save,json=viewSave,proto3,oneof"`
+}
+
+type Request_ViewList struct {
+	ViewList *ViewList `protobuf:"bytes,18,opt,name=view_list,json=viewList,proto3,oneof"`
+}
+
+type Request_ViewDelete struct {
+	ViewDelete *ViewDelete `protobuf:"bytes,19,opt,name=view_delete,json=viewDelete,proto3,oneof"`
+}
+
+type Request_NoteRelated struct {
+	NoteRelated *NoteRelated `protobuf:"bytes,20,opt,name=note_related,json=noteRelated,proto3,oneof"`
+}
+
+type Request_NoteDupes struct {
+	NoteDupes *NoteDupes `protobuf:"bytes,21,opt,name=note_dupes,json=noteDupes,proto3,oneof"`
+}
+
+type Request_NoteMerge struct {
+	NoteMerge *NoteMerge `protobuf:"bytes,22,opt,name=note_merge,json=noteMerge,proto3,oneof"`
+}
+
+type Request_NoteLinks struct {
+	NoteLinks *NoteLinks `protobuf:"bytes,23,opt,name=note_links,json=noteLinks,proto3,oneof"`
+}
+
+type Request_NoteBacklinks struct {
+	NoteBacklinks *NoteBacklinks `protobuf:"bytes,24,opt,name=note_backlinks,json=noteBacklinks,proto3,oneof"`
+}
+
+type Request_TagEdit struct {
+	TagEdit *TagEdit `protobuf:"bytes,25,opt,name=tag_edit,json=tagEdit,proto3,oneof"`
+}
+
+type Request_Notify struct {
+	Notify *NotifyControl `protobuf:"bytes,26,opt,name=notify,proto3,oneof"`
+}
+
+type Request_NoteRemind struct {
+	NoteRemind *NoteRemind `protobuf:"bytes,27,opt,name=note_remind,json=noteRemind,proto3,oneof"`
+}
+
+type Request_NoteReminders struct {
+	NoteReminders *NoteReminders `protobuf:"bytes,28,opt,name=note_reminders,json=noteReminders,proto3,oneof"`
+}
+
+type Request_NoteDaily struct {
+	NoteDaily *NoteDaily `protobuf:"bytes,29,opt,name=note_daily,json=noteDaily,proto3,oneof"`
+}
+
+type Request_DraftSave struct {
+	DraftSave *DraftSave `protobuf:"bytes,30,opt,name=draft_save,json=draftSave,proto3,oneof"`
+}
+
+type Request_DraftList struct {
+	DraftList *DraftList `protobuf:"bytes,31,opt,name=draft_list,json=draftList,proto3,oneof"`
+}
+
+type Request_DraftDelete struct {
+	DraftDelete *DraftDelete `protobuf:"bytes,32,opt,name=draft_delete,json=draftDelete,proto3,oneof"`
 }
 
 func (*Request_NoteAdd) isRequest_Cmd() {}
 
-func (*Request_NoteEdit) isRequest_Cmd() {}
+func (*Request_NoteEdit) isRequest_Cmd() {}
+
+func (*Request_NoteDelete) isRequest_Cmd() {}
+
+func (*Request_NoteShow) isRequest_Cmd() {}
+
+func (*Request_NoteList) isRequest_Cmd() {}
+
+func (*Request_NoteSearchFts) isRequest_Cmd() {}
+
+func (*Request_NoteSearchRegex) isRequest_Cmd() {}
+
+func (*Request_SyncRun) isRequest_Cmd() {}
+
+func (*Request_QueueList) isRequest_Cmd() {}
+
+func (*Request_NamespaceList) isRequest_Cmd() {}
+
+func (*Request_TagList) isRequest_Cmd() {}
+
+func (*Request_NamespaceDelete) isRequest_Cmd() {}
+
+func (*Request_SyncStatus) isRequest_Cmd() {}
+
+func (*Request_SyncPlan) isRequest_Cmd() {}
+
+func (*Request_SyncReplay) isRequest_Cmd() {}
+
+func (*Request_NoteSearchFuzzy) isRequest_Cmd() {}
+
+func (*Request_ViewSave) isRequest_Cmd() {}
+
+func (*Request_ViewList) isRequest_Cmd() {}
+
+func (*Request_ViewDelete) isRequest_Cmd() {}
+
+func (*Request_NoteRelated) isRequest_Cmd() {}
+
+func (*Request_NoteDupes) isRequest_Cmd() {}
+
+func (*Request_NoteMerge) isRequest_Cmd() {}
+
+func (*Request_NoteLinks) isRequest_Cmd() {}
+
+func (*Request_NoteBacklinks) isRequest_Cmd() {}
+
+func (*Request_TagEdit) isRequest_Cmd() {}
+
+func (*Request_Notify) isRequest_Cmd() {}
+
+func (*Request_NoteRemind) isRequest_Cmd() {}
+
+func (*Request_NoteReminders) isRequest_Cmd() {}
+
+func (*Request_NoteDaily) isRequest_Cmd() {}
+
+func (*Request_DraftSave) isRequest_Cmd() {}
+
+func (*Request_DraftList) isRequest_Cmd() {}
+
+func (*Request_DraftDelete) isRequest_Cmd() {}
+
+type TagStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Frecency      int32                  `protobuf:"varint,4,opt,name=frecency,proto3" json:"frecency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagStat) Reset() {
+	*x = TagStat{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagStat) ProtoMessage() {}
+
+func (x *TagStat) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagStat.ProtoReflect.Descriptor instead.
+func (*TagStat) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TagStat) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagStat) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TagStat) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TagStat) GetFrecency() int32 {
+	if x != nil {
+		return x.Frecency
+	}
+	return 0
+}
+
+type Response struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Ok         bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Msg        string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	Entry      *Entry                 `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	Entries    []*Entry               `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
+	Queue      []*QueueRemote         `protobuf:"bytes,5,rep,name=queue,proto3" json:"queue,omitempty"`
+	Namespaces []string               `protobuf:"bytes,6,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	Tags       []*TagStat             `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Page       *Page                  `protobuf:"bytes,8,opt,name=page,proto3" json:"page,omitempty"`
+	SyncStatus []*SyncStatus          `protobuf:"bytes,9,rep,name=sync_status,json=syncStatus,proto3" json:"sync_status,omitempty"`
+	SyncPlan   []*SyncPlan            `protobuf:"bytes,10,rep,name=sync_plan,json=syncPlan,proto3" json:"sync_plan,omitempty"`
+	Hits       []*SearchHit           `protobuf:"bytes,11,rep,name=hits,proto3" json:"hits,omitempty"`
+	// corrected is the rewritten query of a fuzzy search, when it differs.
+	Corrected   string            `protobuf:"bytes,12,opt,name=corrected,proto3" json:"corrected,omitempty"`
+	Suggestions []*TermSuggestion `protobuf:"bytes,13,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	Views       []*View           `protobuf:"bytes,14,rep,name=views,proto3" json:"views,omitempty"`
+	Duplicates  []*Duplicate      `protobuf:"bytes,15,rep,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Clusters    []*DupeCluster    `protobuf:"bytes,16,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	Links       []*Link           `protobuf:"bytes,17,rep,name=links,proto3" json:"links,omitempty"`
+	// changed counts the notes a tag edit rewrote.
+	Changed       int32           `protobuf:"varint,18,opt,name=changed,proto3" json:"changed,omitempty"`
+	NotifyStatus  []*NotifyStatus `protobuf:"bytes,19,rep,name=notify_status,json=notifyStatus,proto3" json:"notify_status,omitempty"`
+	Reminders     []*Reminder     `protobuf:"bytes,20,rep,name=reminders,proto3" json:"reminders,omitempty"`
+	Drafts        []*Draft        `protobuf:"bytes,21,rep,name=drafts,proto3" json:"drafts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Response) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *Response) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *Response) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *Response) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *Response) GetQueue() []*QueueRemote {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+func (x *Response) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *Response) GetTags() []*TagStat {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Response) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *Response) GetSyncStatus() []*SyncStatus {
+	if x != nil {
+		return x.SyncStatus
+	}
+	return nil
+}
+
+func (x *Response) GetSyncPlan() []*SyncPlan {
+	if x != nil {
+		return x.SyncPlan
+	}
+	return nil
+}
+
+func (x *Response) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *Response) GetCorrected() string {
+	if x != nil {
+		return x.Corrected
+	}
+	return ""
+}
+
+func (x *Response) GetSuggestions() []*TermSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *Response) GetViews() []*View {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *Response) GetDuplicates() []*Duplicate {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+func (x *Response) GetClusters() []*DupeCluster {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+func (x *Response) GetLinks() []*Link {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *Response) GetChanged() int32 {
+	if x != nil {
+		return x.Changed
+	}
+	return 0
+}
+
+func (x *Response) GetNotifyStatus() []*NotifyStatus {
+	if x != nil {
+		return x.NotifyStatus
+	}
+	return nil
+}
+
+func (x *Response) GetReminders() []*Reminder {
+	if x != nil {
+		return x.Reminders
+	}
+	return nil
+}
+
+func (x *Response) GetDrafts() []*Draft {
+	if x != nil {
+		return x.Drafts
+	}
+	return nil
+}
+
+type TermSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          string                 `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Suggestions   []string               `protobuf:"bytes,2,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermSuggestion) Reset() {
+	*x = TermSuggestion{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermSuggestion) ProtoMessage() {}
+
+func (x *TermSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermSuggestion.ProtoReflect.Descriptor instead.
+func (*TermSuggestion) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TermSuggestion) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *TermSuggestion) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type TextRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextRange) Reset() {
+	*x = TextRange{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextRange) ProtoMessage() {}
+
+func (x *TextRange) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextRange.ProtoReflect.Descriptor instead.
+func (*TextRange) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TextRange) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TextRange) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type Snippet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Matches       []*TextRange           `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snippet) Reset() {
+	*x = Snippet{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snippet) ProtoMessage() {}
+
+func (x *Snippet) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snippet.ProtoReflect.Descriptor instead.
+func (*Snippet) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Snippet) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Snippet) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Snippet) GetMatches() []*TextRange {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *Entry                 `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Snippets      []*Snippet             `protobuf:"bytes,3,rep,name=snippets,proto3" json:"snippets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SearchHit) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetSnippets() []*Snippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+type Duplicate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *Entry                 `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Exact         bool                   `protobuf:"varint,2,opt,name=exact,proto3" json:"exact,omitempty"`
+	Distance      int32                  `protobuf:"varint,3,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Duplicate) Reset() {
+	*x = Duplicate{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Duplicate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Duplicate) ProtoMessage() {}
+
+func (x *Duplicate) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Duplicate.ProtoReflect.Descriptor instead.
+func (*Duplicate) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Duplicate) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *Duplicate) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+func (x *Duplicate) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type DupeCluster struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Entry               `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Exact         bool                   `protobuf:"varint,2,opt,name=exact,proto3" json:"exact,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DupeCluster) Reset() {
+	*x = DupeCluster{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DupeCluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DupeCluster) ProtoMessage() {}
+
+func (x *DupeCluster) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DupeCluster.ProtoReflect.Descriptor instead.
+func (*DupeCluster) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DupeCluster) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *DupeCluster) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+// Link is a [[target]] reference; entry is unset when it resolves to no note.
+type Link struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Entry         *Entry                 `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Link) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Link) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type Page struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Next          string                 `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
+	Prev          string                 `protobuf:"bytes,2,opt,name=prev,proto3" json:"prev,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Page) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+func (x *Page) GetPrev() string {
+	if x != nil {
+		return x.Prev
+	}
+	return ""
+}
+
+type RepEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	NamespaceId   string                 `protobuf:"bytes,4,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	PayloadType   string                 `protobuf:"bytes,5,opt,name=payload_type,json=payloadType,proto3" json:"payload_type,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	SignerId      string                 `protobuf:"bytes,7,opt,name=signer_id,json=signerId,proto3" json:"signer_id,omitempty"`
+	Sig           []byte                 `protobuf:"bytes,8,opt,name=sig,proto3" json:"sig,omitempty"`
+	OriginLabel   string                 `protobuf:"bytes,9,opt,name=origin_label,json=originLabel,proto3" json:"origin_label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepEvent) Reset() {
+	*x = RepEvent{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepEvent) ProtoMessage() {}
+
+func (x *RepEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepEvent.ProtoReflect.Descriptor instead.
+func (*RepEvent) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RepEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *RepEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RepEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RepEvent) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *RepEvent) GetPayloadType() string {
+	if x != nil {
+		return x.PayloadType
+	}
+	return ""
+}
+
+func (x *RepEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *RepEvent) GetSignerId() string {
+	if x != nil {
+		return x.SignerId
+	}
+	return ""
+}
+
+func (x *RepEvent) GetSig() []byte {
+	if x != nil {
+		return x.Sig
+	}
+	return nil
+}
+
+func (x *RepEvent) GetOriginLabel() string {
+	if x != nil {
+		return x.OriginLabel
+	}
+	return ""
+}
+
+type PushBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*RepEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushBatch) Reset() {
+	*x = PushBatch{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBatch) ProtoMessage() {}
+
+func (x *PushBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBatch.ProtoReflect.Descriptor instead.
+func (*PushBatch) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PushBatch) GetEvents() []*RepEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ItemStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ok    bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Msg   string                 `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// retry marks rejections that may succeed later (e.g. quota); clients
+	// should not advance past them.
+	Retry         bool `protobuf:"varint,4,opt,name=retry,proto3" json:"retry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemStatus) Reset() {
+	*x = ItemStatus{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemStatus) ProtoMessage() {}
+
+func (x *ItemStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemStatus.ProtoReflect.Descriptor instead.
+func (*ItemStatus) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ItemStatus) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ItemStatus) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ItemStatus) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *ItemStatus) GetRetry() bool {
+	if x != nil {
+		return x.Retry
+	}
+	return false
+}
+
+type Cursor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	After         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cursor) Reset() {
+	*x = Cursor{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cursor) ProtoMessage() {}
+
+func (x *Cursor) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cursor.ProtoReflect.Descriptor instead.
+func (*Cursor) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Cursor) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+type PushResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ItemStatus          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Next          *Cursor                `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushResult) Reset() {
+	*x = PushResult{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushResult) ProtoMessage() {}
+
+func (x *PushResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushResult.ProtoReflect.Descriptor instead.
+func (*PushResult) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PushResult) GetItems() []*ItemStatus {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *PushResult) GetNext() *Cursor {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+type PullResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*RepEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Next          *Cursor                `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullResult) Reset() {
+	*x = PullResult{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Request_NoteDelete) isRequest_Cmd() {}
+func (x *PullResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Request_NoteShow) isRequest_Cmd() {}
+func (*PullResult) ProtoMessage() {}
 
-func (*Request_NoteList) isRequest_Cmd() {}
+func (x *PullResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Request_NoteSearchFts) isRequest_Cmd() {}
+// Deprecated: Use PullResult.ProtoReflect.Descriptor instead.
+func (*PullResult) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{43}
+}
 
-func (*Request_NoteSearchRegex) isRequest_Cmd() {}
+func (x *PullResult) GetEvents() []*RepEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
 
-func (*Request_SyncRun) isRequest_Cmd() {}
+func (x *PullResult) GetNext() *Cursor {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
 
-func (*Request_QueueList) isRequest_Cmd() {}
+type SyncRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (*Request_NamespaceList) isRequest_Cmd() {}
+func (x *SyncRun) Reset() {
+	*x = SyncRun{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Request_TagList) isRequest_Cmd() {}
+func (x *SyncRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Request_NamespaceDelete) isRequest_Cmd() {}
+func (*SyncRun) ProtoMessage() {}
 
-type TagStat struct {
+func (x *SyncRun) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRun.ProtoReflect.Descriptor instead.
+func (*SyncRun) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{44}
+}
+
+type NamespaceList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TagStat) Reset() {
-	*x = TagStat{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[10]
+func (x *NamespaceList) Reset() {
+	*x = NamespaceList{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TagStat) String() string {
+func (x *NamespaceList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagStat) ProtoMessage() {}
+func (*NamespaceList) ProtoMessage() {}
 
-func (x *TagStat) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[10]
+func (x *NamespaceList) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -910,61 +3560,131 @@ func (x *TagStat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagStat.ProtoReflect.Descriptor instead.
-func (*TagStat) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use NamespaceList.ProtoReflect.Descriptor instead.
+func (*NamespaceList) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *TagStat) GetTag() string {
+type NamespaceDelete struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceDelete) Reset() {
+	*x = NamespaceDelete{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceDelete) ProtoMessage() {}
+
+func (x *NamespaceDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[46]
 	if x != nil {
-		return x.Tag
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceDelete.ProtoReflect.Descriptor instead.
+func (*NamespaceDelete) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *NamespaceDelete) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *TagStat) GetCount() int32 {
+type QueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Remote        string                 `protobuf:"bytes,2,opt,name=remote,proto3" json:"remote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueRequest) Reset() {
+	*x = QueueRequest{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueRequest) ProtoMessage() {}
+
+func (x *QueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[47]
 	if x != nil {
-		return x.Count
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueRequest.ProtoReflect.Descriptor instead.
+func (*QueueRequest) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *QueueRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *TagStat) GetDescription() string {
+func (x *QueueRequest) GetRemote() string {
 	if x != nil {
-		return x.Description
+		return x.Remote
 	}
 	return ""
 }
 
-type Response struct {
+type QueueEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
-	Msg           string                 `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	Entry         *Entry                 `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
-	Entries       []*Entry               `protobuf:"bytes,4,rep,name=entries,proto3" json:"entries,omitempty"`
-	Queue         []*QueueRemote         `protobuf:"bytes,5,rep,name=queue,proto3" json:"queue,omitempty"`
-	Namespaces    []string               `protobuf:"bytes,6,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
-	Tags          []*TagStat             `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
-	Page          *Page                  `protobuf:"bytes,8,opt,name=page,proto3" json:"page,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Response) Reset() {
-	*x = Response{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[11]
+func (x *QueueEvent) Reset() {
+	*x = QueueEvent{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Response) String() string {
+func (x *QueueEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Response) ProtoMessage() {}
+func (*QueueEvent) ProtoMessage() {}
 
-func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[11]
+func (x *QueueEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -975,90 +3695,122 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Response.ProtoReflect.Descriptor instead.
-func (*Response) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use QueueEvent.ProtoReflect.Descriptor instead.
+func (*QueueEvent) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *Response) GetOk() bool {
+func (x *QueueEvent) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Ok
+		return x.Time
 	}
-	return false
+	return nil
 }
 
-func (x *Response) GetMsg() string {
+func (x *QueueEvent) GetType() string {
 	if x != nil {
-		return x.Msg
+		return x.Type
 	}
 	return ""
 }
 
-func (x *Response) GetEntry() *Entry {
+func (x *QueueEvent) GetId() string {
 	if x != nil {
-		return x.Entry
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *Response) GetEntries() []*Entry {
+type QueueRemote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Pending       int64                  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	Events        []*QueueEvent          `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueRemote) Reset() {
+	*x = QueueRemote{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueRemote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueRemote) ProtoMessage() {}
+
+func (x *QueueRemote) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[49]
 	if x != nil {
-		return x.Entries
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Response) GetQueue() []*QueueRemote {
+// Deprecated: Use QueueRemote.ProtoReflect.Descriptor instead.
+func (*QueueRemote) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *QueueRemote) GetName() string {
 	if x != nil {
-		return x.Queue
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *Response) GetNamespaces() []string {
+func (x *QueueRemote) GetUrl() string {
 	if x != nil {
-		return x.Namespaces
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-func (x *Response) GetTags() []*TagStat {
+func (x *QueueRemote) GetPending() int64 {
 	if x != nil {
-		return x.Tags
+		return x.Pending
 	}
-	return nil
+	return 0
 }
 
-func (x *Response) GetPage() *Page {
+func (x *QueueRemote) GetEvents() []*QueueEvent {
 	if x != nil {
-		return x.Page
+		return x.Events
 	}
 	return nil
 }
 
-type Page struct {
+type SyncStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Next          string                 `protobuf:"bytes,1,opt,name=next,proto3" json:"next,omitempty"`
-	Prev          string                 `protobuf:"bytes,2,opt,name=prev,proto3" json:"prev,omitempty"`
+	Remote        string                 `protobuf:"bytes,1,opt,name=remote,proto3" json:"remote,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Page) Reset() {
-	*x = Page{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[12]
+func (x *SyncStatusRequest) Reset() {
+	*x = SyncStatusRequest{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Page) String() string {
+func (x *SyncStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Page) ProtoMessage() {}
+func (*SyncStatusRequest) ProtoMessage() {}
 
-func (x *Page) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[12]
+func (x *SyncStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,55 +3821,53 @@ func (x *Page) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Page.ProtoReflect.Descriptor instead.
-func (*Page) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *Page) GetNext() string {
-	if x != nil {
-		return x.Next
-	}
-	return ""
+// Deprecated: Use SyncStatusRequest.ProtoReflect.Descriptor instead.
+func (*SyncStatusRequest) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *Page) GetPrev() string {
+func (x *SyncStatusRequest) GetRemote() string {
 	if x != nil {
-		return x.Prev
+		return x.Remote
 	}
 	return ""
 }
 
-type RepEvent struct {
+type SyncStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
-	NamespaceId   string                 `protobuf:"bytes,4,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
-	PayloadType   string                 `protobuf:"bytes,5,opt,name=payload_type,json=payloadType,proto3" json:"payload_type,omitempty"`
-	Payload       []byte                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
-	SignerId      string                 `protobuf:"bytes,7,opt,name=signer_id,json=signerId,proto3" json:"signer_id,omitempty"`
-	Sig           []byte                 `protobuf:"bytes,8,opt,name=sig,proto3" json:"sig,omitempty"`
-	OriginLabel   string                 `protobuf:"bytes,9,opt,name=origin_label,json=originLabel,proto3" json:"origin_label,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	LastAttempt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_attempt,json=lastAttempt,proto3" json:"last_attempt,omitempty"`
+	LastSuccess   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	LastError     string                 `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_error_at,json=lastErrorAt,proto3" json:"last_error_at,omitempty"`
+	Failures      int64                  `protobuf:"varint,7,opt,name=failures,proto3" json:"failures,omitempty"`
+	PushedEvents  int64                  `protobuf:"varint,8,opt,name=pushed_events,json=pushedEvents,proto3" json:"pushed_events,omitempty"`
+	PulledEvents  int64                  `protobuf:"varint,9,opt,name=pulled_events,json=pulledEvents,proto3" json:"pulled_events,omitempty"`
+	PushedBytes   int64                  `protobuf:"varint,10,opt,name=pushed_bytes,json=pushedBytes,proto3" json:"pushed_bytes,omitempty"`
+	PulledBytes   int64                  `protobuf:"varint,11,opt,name=pulled_bytes,json=pulledBytes,proto3" json:"pulled_bytes,omitempty"`
+	Pending       int64                  `protobuf:"varint,12,opt,name=pending,proto3" json:"pending,omitempty"`
+	BackoffMs     int64                  `protobuf:"varint,13,opt,name=backoff_ms,json=backoffMs,proto3" json:"backoff_ms,omitempty"`
+	NextRun       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RepEvent) Reset() {
-	*x = RepEvent{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[13]
+func (x *SyncStatus) Reset() {
+	*x = SyncStatus{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RepEvent) String() string {
+func (x *SyncStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RepEvent) ProtoMessage() {}
+func (*SyncStatus) ProtoMessage() {}
 
-func (x *RepEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[13]
+func (x *SyncStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,142 +3878,132 @@ func (x *RepEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RepEvent.ProtoReflect.Descriptor instead.
-func (*RepEvent) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SyncStatus.ProtoReflect.Descriptor instead.
+func (*SyncStatus) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *RepEvent) GetTime() *timestamppb.Timestamp {
+func (x *SyncStatus) GetName() string {
 	if x != nil {
-		return x.Time
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *RepEvent) GetType() string {
+func (x *SyncStatus) GetUrl() string {
 	if x != nil {
-		return x.Type
+		return x.Url
 	}
 	return ""
 }
 
-func (x *RepEvent) GetId() string {
+func (x *SyncStatus) GetLastAttempt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.LastAttempt
 	}
-	return ""
+	return nil
 }
 
-func (x *RepEvent) GetNamespaceId() string {
+func (x *SyncStatus) GetLastSuccess() *timestamppb.Timestamp {
 	if x != nil {
-		return x.NamespaceId
+		return x.LastSuccess
 	}
-	return ""
+	return nil
 }
 
-func (x *RepEvent) GetPayloadType() string {
+func (x *SyncStatus) GetLastError() string {
 	if x != nil {
-		return x.PayloadType
+		return x.LastError
 	}
 	return ""
 }
 
-func (x *RepEvent) GetPayload() []byte {
+func (x *SyncStatus) GetLastErrorAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Payload
+		return x.LastErrorAt
 	}
 	return nil
 }
 
-func (x *RepEvent) GetSignerId() string {
+func (x *SyncStatus) GetFailures() int64 {
 	if x != nil {
-		return x.SignerId
+		return x.Failures
 	}
-	return ""
+	return 0
 }
 
-func (x *RepEvent) GetSig() []byte {
+func (x *SyncStatus) GetPushedEvents() int64 {
 	if x != nil {
-		return x.Sig
+		return x.PushedEvents
 	}
-	return nil
+	return 0
 }
 
-func (x *RepEvent) GetOriginLabel() string {
+func (x *SyncStatus) GetPulledEvents() int64 {
 	if x != nil {
-		return x.OriginLabel
+		return x.PulledEvents
 	}
-	return ""
-}
-
-type PushBatch struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Events        []*RepEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return 0
 }
 
-func (x *PushBatch) Reset() {
-	*x = PushBatch{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SyncStatus) GetPushedBytes() int64 {
+	if x != nil {
+		return x.PushedBytes
+	}
+	return 0
 }
 
-func (x *PushBatch) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SyncStatus) GetPulledBytes() int64 {
+	if x != nil {
+		return x.PulledBytes
+	}
+	return 0
 }
 
-func (*PushBatch) ProtoMessage() {}
-
-func (x *PushBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[14]
+func (x *SyncStatus) GetPending() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Pending
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PushBatch.ProtoReflect.Descriptor instead.
-func (*PushBatch) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{14}
+func (x *SyncStatus) GetBackoffMs() int64 {
+	if x != nil {
+		return x.BackoffMs
+	}
+	return 0
 }
 
-func (x *PushBatch) GetEvents() []*RepEvent {
+func (x *SyncStatus) GetNextRun() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Events
+		return x.NextRun
 	}
 	return nil
 }
 
-type ItemStatus struct {
+type SyncPlanRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Ok            bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
-	Msg           string                 `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Remote        string                 `protobuf:"bytes,1,opt,name=remote,proto3" json:"remote,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ItemStatus) Reset() {
-	*x = ItemStatus{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[15]
+func (x *SyncPlanRequest) Reset() {
+	*x = SyncPlanRequest{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ItemStatus) String() string {
+func (x *SyncPlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ItemStatus) ProtoMessage() {}
+func (*SyncPlanRequest) ProtoMessage() {}
 
-func (x *ItemStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[15]
+func (x *SyncPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1274,54 +4014,48 @@ func (x *ItemStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ItemStatus.ProtoReflect.Descriptor instead.
-func (*ItemStatus) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SyncPlanRequest.ProtoReflect.Descriptor instead.
+func (*SyncPlanRequest) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *ItemStatus) GetId() string {
+func (x *SyncPlanRequest) GetRemote() string {
 	if x != nil {
-		return x.Id
+		return x.Remote
 	}
 	return ""
 }
 
-func (x *ItemStatus) GetOk() bool {
-	if x != nil {
-		return x.Ok
-	}
-	return false
-}
-
-func (x *ItemStatus) GetMsg() string {
+func (x *SyncPlanRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Msg
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type Cursor struct {
+type SyncReplayRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	After         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=after,proto3" json:"after,omitempty"`
+	Remote        string                 `protobuf:"bytes,1,opt,name=remote,proto3" json:"remote,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Cursor) Reset() {
-	*x = Cursor{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[16]
+func (x *SyncReplayRequest) Reset() {
+	*x = SyncReplayRequest{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Cursor) String() string {
+func (x *SyncReplayRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Cursor) ProtoMessage() {}
+func (*SyncReplayRequest) ProtoMessage() {}
 
-func (x *Cursor) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[16]
+func (x *SyncReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1332,41 +4066,53 @@ func (x *Cursor) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Cursor.ProtoReflect.Descriptor instead.
-func (*Cursor) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use SyncReplayRequest.ProtoReflect.Descriptor instead.
+func (*SyncReplayRequest) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *Cursor) GetAfter() *timestamppb.Timestamp {
+func (x *SyncReplayRequest) GetRemote() string {
 	if x != nil {
-		return x.After
+		return x.Remote
+	}
+	return ""
+}
+
+func (x *SyncReplayRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
 	}
 	return nil
 }
 
-type PushResult struct {
+type SyncPlanEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*ItemStatus          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	Next          *Cursor                `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Action        string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"`
+	Err           string                 `protobuf:"bytes,7,opt,name=err,proto3" json:"err,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PushResult) Reset() {
-	*x = PushResult{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[17]
+func (x *SyncPlanEvent) Reset() {
+	*x = SyncPlanEvent{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PushResult) String() string {
+func (x *SyncPlanEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushResult) ProtoMessage() {}
+func (*SyncPlanEvent) ProtoMessage() {}
 
-func (x *PushResult) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[17]
+func (x *SyncPlanEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1377,98 +4123,90 @@ func (x *PushResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushResult.ProtoReflect.Descriptor instead.
-func (*PushResult) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use SyncPlanEvent.ProtoReflect.Descriptor instead.
+func (*SyncPlanEvent) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *PushResult) GetItems() []*ItemStatus {
+func (x *SyncPlanEvent) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Items
+		return x.Time
 	}
 	return nil
 }
 
-func (x *PushResult) GetNext() *Cursor {
+func (x *SyncPlanEvent) GetType() string {
 	if x != nil {
-		return x.Next
+		return x.Type
 	}
-	return nil
-}
-
-type PullResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Events        []*RepEvent            `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
-	Next          *Cursor                `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PullResult) Reset() {
-	*x = PullResult{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[18]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *PullResult) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SyncPlanEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-func (*PullResult) ProtoMessage() {}
-
-func (x *PullResult) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[18]
+func (x *SyncPlanEvent) GetNamespace() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Namespace
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use PullResult.ProtoReflect.Descriptor instead.
-func (*PullResult) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{18}
+func (x *SyncPlanEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
 }
 
-func (x *PullResult) GetEvents() []*RepEvent {
+func (x *SyncPlanEvent) GetAction() string {
 	if x != nil {
-		return x.Events
+		return x.Action
 	}
-	return nil
+	return ""
 }
 
-func (x *PullResult) GetNext() *Cursor {
+func (x *SyncPlanEvent) GetErr() string {
 	if x != nil {
-		return x.Next
+		return x.Err
 	}
-	return nil
+	return ""
 }
 
-type SyncRun struct {
+type SyncPlan struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Push          int64                  `protobuf:"varint,3,opt,name=push,proto3" json:"push,omitempty"`
+	Create        int64                  `protobuf:"varint,4,opt,name=create,proto3" json:"create,omitempty"`
+	Update        int64                  `protobuf:"varint,5,opt,name=update,proto3" json:"update,omitempty"`
+	Delete        int64                  `protobuf:"varint,6,opt,name=delete,proto3" json:"delete,omitempty"`
+	Unchanged     int64                  `protobuf:"varint,7,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Errors        int64                  `protobuf:"varint,8,opt,name=errors,proto3" json:"errors,omitempty"`
+	Events        []*SyncPlanEvent       `protobuf:"bytes,9,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncRun) Reset() {
-	*x = SyncRun{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[19]
+func (x *SyncPlan) Reset() {
+	*x = SyncPlan{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncRun) String() string {
+func (x *SyncPlan) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncRun) ProtoMessage() {}
+func (*SyncPlan) ProtoMessage() {}
 
-func (x *SyncRun) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[19]
+func (x *SyncPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1479,69 +4217,104 @@ func (x *SyncRun) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncRun.ProtoReflect.Descriptor instead.
-func (*SyncRun) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use SyncPlan.ProtoReflect.Descriptor instead.
+func (*SyncPlan) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{55}
 }
 
-type NamespaceList struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SyncPlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *NamespaceList) Reset() {
-	*x = NamespaceList{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *SyncPlan) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
 }
 
-func (x *NamespaceList) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *SyncPlan) GetPush() int64 {
+	if x != nil {
+		return x.Push
+	}
+	return 0
 }
 
-func (*NamespaceList) ProtoMessage() {}
+func (x *SyncPlan) GetCreate() int64 {
+	if x != nil {
+		return x.Create
+	}
+	return 0
+}
 
-func (x *NamespaceList) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[20]
+func (x *SyncPlan) GetUpdate() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Update
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use NamespaceList.ProtoReflect.Descriptor instead.
-func (*NamespaceList) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{20}
+func (x *SyncPlan) GetDelete() int64 {
+	if x != nil {
+		return x.Delete
+	}
+	return 0
 }
 
-type NamespaceDelete struct {
+func (x *SyncPlan) GetUnchanged() int64 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *SyncPlan) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *SyncPlan) GetEvents() []*SyncPlanEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type NotifyStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	EveryMs       int64                  `protobuf:"varint,2,opt,name=every_ms,json=everyMs,proto3" json:"every_ms,omitempty"`
+	LastNote      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_note,json=lastNote,proto3" json:"last_note,omitempty"`
+	LastSent      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_sent,json=lastSent,proto3" json:"last_sent,omitempty"`
+	Due           *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due,proto3" json:"due,omitempty"`
+	SnoozedUntil  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=snoozed_until,json=snoozedUntil,proto3" json:"snoozed_until,omitempty"`
+	Muted         bool                   `protobuf:"varint,7,opt,name=muted,proto3" json:"muted,omitempty"`
+	Enabled       bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	QuietHours    string                 `protobuf:"bytes,9,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NamespaceDelete) Reset() {
-	*x = NamespaceDelete{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[21]
+func (x *NotifyStatus) Reset() {
+	*x = NotifyStatus{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NamespaceDelete) String() string {
+func (x *NotifyStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NamespaceDelete) ProtoMessage() {}
+func (*NotifyStatus) ProtoMessage() {}
 
-func (x *NamespaceDelete) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[21]
+func (x *NotifyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,94 +4325,102 @@ func (x *NamespaceDelete) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NamespaceDelete.ProtoReflect.Descriptor instead.
-func (*NamespaceDelete) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use NotifyStatus.ProtoReflect.Descriptor instead.
+func (*NotifyStatus) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *NamespaceDelete) GetNamespace() string {
+func (x *NotifyStatus) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-type QueueRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Remote        string                 `protobuf:"bytes,2,opt,name=remote,proto3" json:"remote,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *NotifyStatus) GetEveryMs() int64 {
+	if x != nil {
+		return x.EveryMs
+	}
+	return 0
 }
 
-func (x *QueueRequest) Reset() {
-	*x = QueueRequest{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[22]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *NotifyStatus) GetLastNote() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastNote
+	}
+	return nil
 }
 
-func (x *QueueRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *NotifyStatus) GetLastSent() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSent
+	}
+	return nil
 }
 
-func (*QueueRequest) ProtoMessage() {}
+func (x *NotifyStatus) GetDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
 
-func (x *QueueRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[22]
+func (x *NotifyStatus) GetSnoozedUntil() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.SnoozedUntil
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use QueueRequest.ProtoReflect.Descriptor instead.
-func (*QueueRequest) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{22}
+func (x *NotifyStatus) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
 }
 
-func (x *QueueRequest) GetLimit() int32 {
+func (x *NotifyStatus) GetEnabled() bool {
 	if x != nil {
-		return x.Limit
+		return x.Enabled
 	}
-	return 0
+	return false
 }
 
-func (x *QueueRequest) GetRemote() string {
+func (x *NotifyStatus) GetQuietHours() string {
 	if x != nil {
-		return x.Remote
+		return x.QuietHours
 	}
 	return ""
 }
 
-type QueueEvent struct {
+type Reminder struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	Device        string                 `protobuf:"bytes,4,opt,name=device,proto3" json:"device,omitempty"`
+	FiredAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=fired_at,json=firedAt,proto3" json:"fired_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Title         string                 `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueueEvent) Reset() {
-	*x = QueueEvent{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[23]
+func (x *Reminder) Reset() {
+	*x = Reminder{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueueEvent) String() string {
+func (x *Reminder) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueueEvent) ProtoMessage() {}
+func (*Reminder) ProtoMessage() {}
 
-func (x *QueueEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[23]
+func (x *Reminder) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,57 +4431,90 @@ func (x *QueueEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueueEvent.ProtoReflect.Descriptor instead.
-func (*QueueEvent) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use Reminder.ProtoReflect.Descriptor instead.
+func (*Reminder) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *QueueEvent) GetTime() *timestamppb.Timestamp {
+func (x *Reminder) GetNoteId() string {
 	if x != nil {
-		return x.Time
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *Reminder) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Reminder) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
 	}
 	return nil
 }
 
-func (x *QueueEvent) GetType() string {
+func (x *Reminder) GetDevice() string {
 	if x != nil {
-		return x.Type
+		return x.Device
 	}
 	return ""
 }
 
-func (x *QueueEvent) GetId() string {
+func (x *Reminder) GetFiredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.FiredAt
+	}
+	return nil
+}
+
+func (x *Reminder) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Reminder) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
 	return ""
 }
 
-type QueueRemote struct {
+// Draft is a note being written in an editor; content is the daemon's
+// latest snapshot of the file at path.
+type Draft struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	Pending       int64                  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
-	Events        []*QueueEvent          `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	BaseVersion   int64                  `protobuf:"varint,4,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueueRemote) Reset() {
-	*x = QueueRemote{}
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[24]
+func (x *Draft) Reset() {
+	*x = Draft{}
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueueRemote) String() string {
+func (x *Draft) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueueRemote) ProtoMessage() {}
+func (*Draft) ProtoMessage() {}
 
-func (x *QueueRemote) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[24]
+func (x *Draft) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_ipc_pb_ipc_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1711,35 +4525,56 @@ func (x *QueueRemote) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueueRemote.ProtoReflect.Descriptor instead.
-func (*QueueRemote) Descriptor() ([]byte, []int) {
-	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use Draft.ProtoReflect.Descriptor instead.
+func (*Draft) Descriptor() ([]byte, []int) {
+	return file_internal_ipc_pb_ipc_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *QueueRemote) GetName() string {
+func (x *Draft) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *QueueRemote) GetUrl() string {
+func (x *Draft) GetNamespace() string {
 	if x != nil {
-		return x.Url
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *QueueRemote) GetPending() int64 {
+func (x *Draft) GetPath() string {
 	if x != nil {
-		return x.Pending
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Draft) GetBaseVersion() int64 {
+	if x != nil {
+		return x.BaseVersion
 	}
 	return 0
 }
 
-func (x *QueueRemote) GetEvents() []*QueueEvent {
+func (x *Draft) GetContent() string {
 	if x != nil {
-		return x.Events
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Draft) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Draft) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
 	}
 	return nil
 }
@@ -1748,7 +4583,7 @@ var File_internal_ipc_pb_ipc_proto protoreflect.FileDescriptor
 
 const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"\n" +
-	"\x19internal/ipc/pb/ipc.proto\x12\x03ipc\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x02\n" +
+	"\x19internal/ipc/pb/ipc.proto\x12\x03ipc\x1a\x1fgoogle/protobuf/timestamp.proto\"\xff\x02\n" +
 	"\x05Entry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x14\n" +
@@ -1759,12 +4594,31 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1c\n" +
-	"\tnamespace\x18\b \x01(\tR\tnamespace\"e\n" +
+	"\tnamespace\x18\b \x01(\tR\tnamespace\x12.\n" +
+	"\x06fields\x18\t \x03(\v2\x16.ipc.Entry.FieldsEntryR\x06fields\x1aJ\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.ipc.FieldValueR\x05value:\x028\x01\"Z\n" +
+	"\n" +
+	"FieldValue\x12\x14\n" +
+	"\x04text\x18\x01 \x01(\tH\x00R\x04text\x12\x18\n" +
+	"\x06number\x18\x02 \x01(\x01H\x00R\x06number\x12\x14\n" +
+	"\x04flag\x18\x03 \x01(\bH\x00R\x04flagB\x06\n" +
+	"\x04kind\"\xf1\x02\n" +
 	"\aNoteAdd\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
 	"\x04body\x18\x02 \x01(\tR\x04body\x12\x12\n" +
 	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x1c\n" +
-	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x95\x01\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06dedupe\x18\x05 \x01(\tR\x06dedupe\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x120\n" +
+	"\x06fields\x18\b \x03(\v2\x18.ipc.NoteAdd.FieldsEntryR\x06fields\x1aJ\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.ipc.FieldValueR\x05value:\x028\x01\"\xee\x02\n" +
 	"\bNoteEdit\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1772,14 +4626,38 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
 	"\x04body\x18\x04 \x01(\tR\x04body\x12\x12\n" +
 	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1c\n" +
-	"\tnamespace\x18\x06 \x01(\tR\tnamespace\":\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x121\n" +
+	"\x06fields\x18\b \x03(\v2\x19.ipc.NoteEdit.FieldsEntryR\x06fields\x12\x1d\n" +
+	"\n" +
+	"set_fields\x18\t \x01(\bR\tsetFields\x1aJ\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.ipc.FieldValueR\x05value:\x028\x01\":\n" +
 	"\n" +
 	"NoteDelete\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"8\n" +
 	"\bNoteShow\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
-	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xaf\x02\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"Q\n" +
+	"\vNoteRelated\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\")\n" +
+	"\tNoteDupes\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"Q\n" +
+	"\tNoteMerge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06others\x18\x02 \x03(\tR\x06others\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"9\n" +
+	"\tNoteLinks\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"=\n" +
+	"\rNoteBacklinks\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xd9\x02\n" +
 	"\n" +
 	"ListFilter\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x19\n" +
@@ -1790,15 +4668,72 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06cursor\x18\a \x01(\tR\x06cursor\x12\x18\n" +
 	"\areverse\x18\b \x01(\bR\areverse\x12!\n" +
-	"\finclude_body\x18\t \x01(\bR\vincludeBody\"J\n" +
+	"\finclude_body\x18\t \x01(\bR\vincludeBody\x12\x14\n" +
+	"\x05query\x18\n" +
+	" \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\v \x01(\tR\x04sort\"c\n" +
 	"\tSearchFTS\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12'\n" +
-	"\x06filter\x18\x02 \x01(\v2\x0f.ipc.ListFilterR\x06filter\"P\n" +
+	"\x06filter\x18\x02 \x01(\v2\x0f.ipc.ListFilterR\x06filter\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\"P\n" +
 	"\vSearchRegex\x12\x18\n" +
 	"\apattern\x18\x01 \x01(\tR\apattern\x12'\n" +
-	"\x06filter\x18\x02 \x01(\v2\x0f.ipc.ListFilterR\x06filter\"'\n" +
+	"\x06filter\x18\x02 \x01(\v2\x0f.ipc.ListFilterR\x06filter\"\xeb\x01\n" +
+	"\x04View\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x19\n" +
+	"\btags_any\x18\x04 \x03(\tR\atagsAny\x12\x19\n" +
+	"\btags_all\x18\x05 \x03(\tR\atagsAll\x12\x14\n" +
+	"\x05since\x18\x06 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\a \x01(\tR\x05until\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\")\n" +
+	"\bViewSave\x12\x1d\n" +
+	"\x04view\x18\x01 \x01(\v2\t.ipc.ViewR\x04view\"<\n" +
+	"\bViewList\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\">\n" +
+	"\n" +
+	"ViewDelete\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"'\n" +
 	"\aTagList\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\xff\x04\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x9b\x01\n" +
+	"\aTagEdit\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1c\n" +
+	"\trecursive\x18\x06 \x01(\bR\trecursive\"S\n" +
+	"\rNotifyControl\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05until\x18\x03 \x01(\tR\x05until\"b\n" +
+	"\n" +
+	"NoteRemind\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x0e\n" +
+	"\x02at\x18\x03 \x01(\tR\x02at\x12\x16\n" +
+	"\x06device\x18\x04 \x01(\tR\x06device\"?\n" +
+	"\rNoteReminders\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"{\n" +
+	"\tNoteDaily\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\"-\n" +
+	"\tDraftSave\x12 \n" +
+	"\x05draft\x18\x01 \x01(\v2\n" +
+	".ipc.DraftR\x05draft\";\n" +
+	"\tDraftList\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"\x1d\n" +
+	"\vDraftDelete\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x93\r\n" +
 	"\aRequest\x12)\n" +
 	"\bnote_add\x18\x01 \x01(\v2\f.ipc.NoteAddH\x00R\anoteAdd\x12,\n" +
 	"\tnote_edit\x18\x02 \x01(\v2\r.ipc.NoteEditH\x00R\bnoteEdit\x122\n" +
@@ -1814,12 +4749,43 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"\x0enamespace_list\x18\n" +
 	" \x01(\v2\x12.ipc.NamespaceListH\x00R\rnamespaceList\x12)\n" +
 	"\btag_list\x18\v \x01(\v2\f.ipc.TagListH\x00R\atagList\x12A\n" +
-	"\x10namespace_delete\x18\f \x01(\v2\x14.ipc.NamespaceDeleteH\x00R\x0fnamespaceDeleteB\x05\n" +
-	"\x03cmd\"S\n" +
+	"\x10namespace_delete\x18\f \x01(\v2\x14.ipc.NamespaceDeleteH\x00R\x0fnamespaceDelete\x129\n" +
+	"\vsync_status\x18\r \x01(\v2\x16.ipc.SyncStatusRequestH\x00R\n" +
+	"syncStatus\x123\n" +
+	"\tsync_plan\x18\x0e \x01(\v2\x14.ipc.SyncPlanRequestH\x00R\bsyncPlan\x129\n" +
+	"\vsync_replay\x18\x0f \x01(\v2\x16.ipc.SyncReplayRequestH\x00R\n" +
+	"syncReplay\x12<\n" +
+	"\x11note_search_fuzzy\x18\x10 \x01(\v2\x0e.ipc.SearchFTSH\x00R\x0fnoteSearchFuzzy\x12,\n" +
+	"\tview_save\x18\x11 \x01(\v2\r.ipc.ViewSaveH\x00R\bviewSave\x12,\n" +
+	"\tview_list\x18\x12 \x01(\v2\r.ipc.ViewListH\x00R\bviewList\x122\n" +
+	"\vview_delete\x18\x13 \x01(\v2\x0f.ipc.ViewDeleteH\x00R\n" +
+	"viewDelete\x125\n" +
+	"\fnote_related\x18\x14 \x01(\v2\x10.ipc.NoteRelatedH\x00R\vnoteRelated\x12/\n" +
+	"\n" +
+	"note_dupes\x18\x15 \x01(\v2\x0e.ipc.NoteDupesH\x00R\tnoteDupes\x12/\n" +
+	"\n" +
+	"note_merge\x18\x16 \x01(\v2\x0e.ipc.NoteMergeH\x00R\tnoteMerge\x12/\n" +
+	"\n" +
+	"note_links\x18\x17 \x01(\v2\x0e.ipc.NoteLinksH\x00R\tnoteLinks\x12;\n" +
+	"\x0enote_backlinks\x18\x18 \x01(\v2\x12.ipc.NoteBacklinksH\x00R\rnoteBacklinks\x12)\n" +
+	"\btag_edit\x18\x19 \x01(\v2\f.ipc.TagEditH\x00R\atagEdit\x12,\n" +
+	"\x06notify\x18\x1a \x01(\v2\x12.ipc.NotifyControlH\x00R\x06notify\x122\n" +
+	"\vnote_remind\x18\x1b \x01(\v2\x0f.ipc.NoteRemindH\x00R\n" +
+	"noteRemind\x12;\n" +
+	"\x0enote_reminders\x18\x1c \x01(\v2\x12.ipc.NoteRemindersH\x00R\rnoteReminders\x12/\n" +
+	"\n" +
+	"note_daily\x18\x1d \x01(\v2\x0e.ipc.NoteDailyH\x00R\tnoteDaily\x12/\n" +
+	"\n" +
+	"draft_save\x18\x1e \x01(\v2\x0e.ipc.DraftSaveH\x00R\tdraftSave\x12/\n" +
+	"\n" +
+	"draft_list\x18\x1f \x01(\v2\x0e.ipc.DraftListH\x00R\tdraftList\x125\n" +
+	"\fdraft_delete\x18  \x01(\v2\x10.ipc.DraftDeleteH\x00R\vdraftDeleteB\x05\n" +
+	"\x03cmd\"o\n" +
 	"\aTagStat\x12\x10\n" +
 	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x14\n" +
 	"\x05count\x18\x02 \x01(\x05R\x05count\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xfd\x01\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bfrecency\x18\x04 \x01(\x05R\bfrecency\"\x97\x06\n" +
 	"\bResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x10\n" +
 	"\x03msg\x18\x02 \x01(\tR\x03msg\x12 \n" +
@@ -1832,7 +4798,53 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"namespaces\x18\x06 \x03(\tR\n" +
 	"namespaces\x12 \n" +
 	"\x04tags\x18\a \x03(\v2\f.ipc.TagStatR\x04tags\x12\x1d\n" +
-	"\x04page\x18\b \x01(\v2\t.ipc.PageR\x04page\".\n" +
+	"\x04page\x18\b \x01(\v2\t.ipc.PageR\x04page\x120\n" +
+	"\vsync_status\x18\t \x03(\v2\x0f.ipc.SyncStatusR\n" +
+	"syncStatus\x12*\n" +
+	"\tsync_plan\x18\n" +
+	" \x03(\v2\r.ipc.SyncPlanR\bsyncPlan\x12\"\n" +
+	"\x04hits\x18\v \x03(\v2\x0e.ipc.SearchHitR\x04hits\x12\x1c\n" +
+	"\tcorrected\x18\f \x01(\tR\tcorrected\x125\n" +
+	"\vsuggestions\x18\r \x03(\v2\x13.ipc.TermSuggestionR\vsuggestions\x12\x1f\n" +
+	"\x05views\x18\x0e \x03(\v2\t.ipc.ViewR\x05views\x12.\n" +
+	"\n" +
+	"duplicates\x18\x0f \x03(\v2\x0e.ipc.DuplicateR\n" +
+	"duplicates\x12,\n" +
+	"\bclusters\x18\x10 \x03(\v2\x10.ipc.DupeClusterR\bclusters\x12\x1f\n" +
+	"\x05links\x18\x11 \x03(\v2\t.ipc.LinkR\x05links\x12\x18\n" +
+	"\achanged\x18\x12 \x01(\x05R\achanged\x126\n" +
+	"\rnotify_status\x18\x13 \x03(\v2\x11.ipc.NotifyStatusR\fnotifyStatus\x12+\n" +
+	"\treminders\x18\x14 \x03(\v2\r.ipc.ReminderR\treminders\x12\"\n" +
+	"\x06drafts\x18\x15 \x03(\v2\n" +
+	".ipc.DraftR\x06drafts\"F\n" +
+	"\x0eTermSuggestion\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\tR\x04term\x12 \n" +
+	"\vsuggestions\x18\x02 \x03(\tR\vsuggestions\"3\n" +
+	"\tTextRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"]\n" +
+	"\aSnippet\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12(\n" +
+	"\amatches\x18\x03 \x03(\v2\x0e.ipc.TextRangeR\amatches\"m\n" +
+	"\tSearchHit\x12 \n" +
+	"\x05entry\x18\x01 \x01(\v2\n" +
+	".ipc.EntryR\x05entry\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12(\n" +
+	"\bsnippets\x18\x03 \x03(\v2\f.ipc.SnippetR\bsnippets\"_\n" +
+	"\tDuplicate\x12 \n" +
+	"\x05entry\x18\x01 \x01(\v2\n" +
+	".ipc.EntryR\x05entry\x12\x14\n" +
+	"\x05exact\x18\x02 \x01(\bR\x05exact\x12\x1a\n" +
+	"\bdistance\x18\x03 \x01(\x05R\bdistance\"I\n" +
+	"\vDupeCluster\x12$\n" +
+	"\aentries\x18\x01 \x03(\v2\n" +
+	".ipc.EntryR\aentries\x12\x14\n" +
+	"\x05exact\x18\x02 \x01(\bR\x05exact\"@\n" +
+	"\x04Link\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12 \n" +
+	"\x05entry\x18\x02 \x01(\v2\n" +
+	".ipc.EntryR\x05entry\".\n" +
 	"\x04Page\x12\x12\n" +
 	"\x04next\x18\x01 \x01(\tR\x04next\x12\x12\n" +
 	"\x04prev\x18\x02 \x01(\tR\x04prev\"\x90\x02\n" +
@@ -1847,12 +4859,13 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"\x03sig\x18\b \x01(\fR\x03sig\x12!\n" +
 	"\forigin_label\x18\t \x01(\tR\voriginLabel\"2\n" +
 	"\tPushBatch\x12%\n" +
-	"\x06events\x18\x01 \x03(\v2\r.ipc.RepEventR\x06events\">\n" +
+	"\x06events\x18\x01 \x03(\v2\r.ipc.RepEventR\x06events\"T\n" +
 	"\n" +
 	"ItemStatus\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x0e\n" +
 	"\x02ok\x18\x02 \x01(\bR\x02ok\x12\x10\n" +
-	"\x03msg\x18\x03 \x01(\tR\x03msg\":\n" +
+	"\x03msg\x18\x03 \x01(\tR\x03msg\x12\x14\n" +
+	"\x05retry\x18\x04 \x01(\bR\x05retry\":\n" +
 	"\x06Cursor\x120\n" +
 	"\x05after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05after\"T\n" +
 	"\n" +
@@ -1879,7 +4892,82 @@ const file_internal_ipc_pb_ipc_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12\x18\n" +
 	"\apending\x18\x03 \x01(\x03R\apending\x12'\n" +
-	"\x06events\x18\x04 \x03(\v2\x0f.ipc.QueueEventR\x06eventsB+Z)github.com/mithrel/ginkgo/internal/ipc/pbb\x06proto3"
+	"\x06events\x18\x04 \x03(\v2\x0f.ipc.QueueEventR\x06events\"+\n" +
+	"\x11SyncStatusRequest\x12\x16\n" +
+	"\x06remote\x18\x01 \x01(\tR\x06remote\"\xab\x04\n" +
+	"\n" +
+	"SyncStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12=\n" +
+	"\flast_attempt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastAttempt\x12=\n" +
+	"\flast_success\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastSuccess\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x05 \x01(\tR\tlastError\x12>\n" +
+	"\rlast_error_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vlastErrorAt\x12\x1a\n" +
+	"\bfailures\x18\a \x01(\x03R\bfailures\x12#\n" +
+	"\rpushed_events\x18\b \x01(\x03R\fpushedEvents\x12#\n" +
+	"\rpulled_events\x18\t \x01(\x03R\fpulledEvents\x12!\n" +
+	"\fpushed_bytes\x18\n" +
+	" \x01(\x03R\vpushedBytes\x12!\n" +
+	"\fpulled_bytes\x18\v \x01(\x03R\vpulledBytes\x12\x18\n" +
+	"\apending\x18\f \x01(\x03R\apending\x12\x1d\n" +
+	"\n" +
+	"backoff_ms\x18\r \x01(\x03R\tbackoffMs\x125\n" +
+	"\bnext_run\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\anextRun\"?\n" +
+	"\x0fSyncPlanRequest\x12\x16\n" +
+	"\x06remote\x18\x01 \x01(\tR\x06remote\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"[\n" +
+	"\x11SyncReplayRequest\x12\x16\n" +
+	"\x06remote\x18\x01 \x01(\tR\x06remote\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\"\xc1\x01\n" +
+	"\rSyncPlanEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x10\n" +
+	"\x03err\x18\a \x01(\tR\x03err\"\xee\x01\n" +
+	"\bSyncPlan\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x12\n" +
+	"\x04push\x18\x03 \x01(\x03R\x04push\x12\x16\n" +
+	"\x06create\x18\x04 \x01(\x03R\x06create\x12\x16\n" +
+	"\x06update\x18\x05 \x01(\x03R\x06update\x12\x16\n" +
+	"\x06delete\x18\x06 \x01(\x03R\x06delete\x12\x1c\n" +
+	"\tunchanged\x18\a \x01(\x03R\tunchanged\x12\x16\n" +
+	"\x06errors\x18\b \x01(\x03R\x06errors\x12*\n" +
+	"\x06events\x18\t \x03(\v2\x12.ipc.SyncPlanEventR\x06events\"\xf9\x02\n" +
+	"\fNotifyStatus\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x19\n" +
+	"\bevery_ms\x18\x02 \x01(\x03R\aeveryMs\x127\n" +
+	"\tlast_note\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastNote\x127\n" +
+	"\tlast_sent\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSent\x12,\n" +
+	"\x03due\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x03due\x12?\n" +
+	"\rsnoozed_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fsnoozedUntil\x12\x14\n" +
+	"\x05muted\x18\a \x01(\bR\x05muted\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x12\x1f\n" +
+	"\vquiet_hours\x18\t \x01(\tR\n" +
+	"quietHours\"\x8d\x02\n" +
+	"\bReminder\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x16\n" +
+	"\x06device\x18\x04 \x01(\tR\x06device\x125\n" +
+	"\bfired_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\afiredAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\"\xfc\x01\n" +
+	"\x05Draft\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12!\n" +
+	"\fbase_version\x18\x04 \x01(\x03R\vbaseVersion\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB+Z)github.com/mithrel/ginkgo/internal/ipc/pbb\x06proto3"
 
 var (
 	file_internal_ipc_pb_ipc_proto_rawDescOnce sync.Once
@@ -1893,73 +4981,175 @@ func file_internal_ipc_pb_ipc_proto_rawDescGZIP() []byte {
 	return file_internal_ipc_pb_ipc_proto_rawDescData
 }
 
-var file_internal_ipc_pb_ipc_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_internal_ipc_pb_ipc_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_internal_ipc_pb_ipc_proto_goTypes = []any{
 	(*Entry)(nil),                 // 0: ipc.Entry
-	(*NoteAdd)(nil),               // 1: ipc.NoteAdd
-	(*NoteEdit)(nil),              // 2: ipc.NoteEdit
-	(*NoteDelete)(nil),            // 3: ipc.NoteDelete
-	(*NoteShow)(nil),              // 4: ipc.NoteShow
-	(*ListFilter)(nil),            // 5: ipc.ListFilter
-	(*SearchFTS)(nil),             // 6: ipc.SearchFTS
-	(*SearchRegex)(nil),           // 7: ipc.SearchRegex
-	(*TagList)(nil),               // 8: ipc.TagList
-	(*Request)(nil),               // 9: ipc.Request
-	(*TagStat)(nil),               // 10: ipc.TagStat
-	(*Response)(nil),              // 11: ipc.Response
-	(*Page)(nil),                  // 12: ipc.Page
-	(*RepEvent)(nil),              // 13: ipc.RepEvent
-	(*PushBatch)(nil),             // 14: ipc.PushBatch
-	(*ItemStatus)(nil),            // 15: ipc.ItemStatus
-	(*Cursor)(nil),                // 16: ipc.Cursor
-	(*PushResult)(nil),            // 17: ipc.PushResult
-	(*PullResult)(nil),            // 18: ipc.PullResult
-	(*SyncRun)(nil),               // 19: ipc.SyncRun
-	(*NamespaceList)(nil),         // 20: ipc.NamespaceList
-	(*NamespaceDelete)(nil),       // 21: ipc.NamespaceDelete
-	(*QueueRequest)(nil),          // 22: ipc.QueueRequest
-	(*QueueEvent)(nil),            // 23: ipc.QueueEvent
-	(*QueueRemote)(nil),           // 24: ipc.QueueRemote
-	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+	(*FieldValue)(nil),            // 1: ipc.FieldValue
+	(*NoteAdd)(nil),               // 2: ipc.NoteAdd
+	(*NoteEdit)(nil),              // 3: ipc.NoteEdit
+	(*NoteDelete)(nil),            // 4: ipc.NoteDelete
+	(*NoteShow)(nil),              // 5: ipc.NoteShow
+	(*NoteRelated)(nil),           // 6: ipc.NoteRelated
+	(*NoteDupes)(nil),             // 7: ipc.NoteDupes
+	(*NoteMerge)(nil),             // 8: ipc.NoteMerge
+	(*NoteLinks)(nil),             // 9: ipc.NoteLinks
+	(*NoteBacklinks)(nil),         // 10: ipc.NoteBacklinks
+	(*ListFilter)(nil),            // 11: ipc.ListFilter
+	(*SearchFTS)(nil),             // 12: ipc.SearchFTS
+	(*SearchRegex)(nil),           // 13: ipc.SearchRegex
+	(*View)(nil),                  // 14: ipc.View
+	(*ViewSave)(nil),              // 15: ipc.ViewSave
+	(*ViewList)(nil),              // 16: ipc.ViewList
+	(*ViewDelete)(nil),            // 17: ipc.ViewDelete
+	(*TagList)(nil),               // 18: ipc.TagList
+	(*TagEdit)(nil),               // 19: ipc.TagEdit
+	(*NotifyControl)(nil),         // 20: ipc.NotifyControl
+	(*NoteRemind)(nil),            // 21: ipc.NoteRemind
+	(*NoteReminders)(nil),         // 22: ipc.NoteReminders
+	(*NoteDaily)(nil),             // 23: ipc.NoteDaily
+	(*DraftSave)(nil),             // 24: ipc.DraftSave
+	(*DraftList)(nil),             // 25: ipc.DraftList
+	(*DraftDelete)(nil),           // 26: ipc.DraftDelete
+	(*Request)(nil),               // 27: ipc.Request
+	(*TagStat)(nil),               // 28: ipc.TagStat
+	(*Response)(nil),              // 29: ipc.Response
+	(*TermSuggestion)(nil),        // 30: ipc.TermSuggestion
+	(*TextRange)(nil),             // 31: ipc.TextRange
+	(*Snippet)(nil),               // 32: ipc.Snippet
+	(*SearchHit)(nil),             // 33: ipc.SearchHit
+	(*Duplicate)(nil),             // 34: ipc.Duplicate
+	(*DupeCluster)(nil),           // 35: ipc.DupeCluster
+	(*Link)(nil),                  // 36: ipc.Link
+	(*Page)(nil),                  // 37: ipc.Page
+	(*RepEvent)(nil),              // 38: ipc.RepEvent
+	(*PushBatch)(nil),             // 39: ipc.PushBatch
+	(*ItemStatus)(nil),            // 40: ipc.ItemStatus
+	(*Cursor)(nil),                // 41: ipc.Cursor
+	(*PushResult)(nil),            // 42: ipc.PushResult
+	(*PullResult)(nil),            // 43: ipc.PullResult
+	(*SyncRun)(nil),               // 44: ipc.SyncRun
+	(*NamespaceList)(nil),         // 45: ipc.NamespaceList
+	(*NamespaceDelete)(nil),       // 46: ipc.NamespaceDelete
+	(*QueueRequest)(nil),          // 47: ipc.QueueRequest
+	(*QueueEvent)(nil),            // 48: ipc.QueueEvent
+	(*QueueRemote)(nil),           // 49: ipc.QueueRemote
+	(*SyncStatusRequest)(nil),     // 50: ipc.SyncStatusRequest
+	(*SyncStatus)(nil),            // 51: ipc.SyncStatus
+	(*SyncPlanRequest)(nil),       // 52: ipc.SyncPlanRequest
+	(*SyncReplayRequest)(nil),     // 53: ipc.SyncReplayRequest
+	(*SyncPlanEvent)(nil),         // 54: ipc.SyncPlanEvent
+	(*SyncPlan)(nil),              // 55: ipc.SyncPlan
+	(*NotifyStatus)(nil),          // 56: ipc.NotifyStatus
+	(*Reminder)(nil),              // 57: ipc.Reminder
+	(*Draft)(nil),                 // 58: ipc.Draft
+	nil,                           // 59: ipc.Entry.FieldsEntry
+	nil,                           // 60: ipc.NoteAdd.FieldsEntry
+	nil,                           // 61: ipc.NoteEdit.FieldsEntry
+	(*timestamppb.Timestamp)(nil), // 62: google.protobuf.Timestamp
 }
 var file_internal_ipc_pb_ipc_proto_depIdxs = []int32{
-	25, // 0: ipc.Entry.created_at:type_name -> google.protobuf.Timestamp
-	25, // 1: ipc.Entry.updated_at:type_name -> google.protobuf.Timestamp
-	25, // 2: ipc.ListFilter.since:type_name -> google.protobuf.Timestamp
-	25, // 3: ipc.ListFilter.until:type_name -> google.protobuf.Timestamp
-	5,  // 4: ipc.SearchFTS.filter:type_name -> ipc.ListFilter
-	5,  // 5: ipc.SearchRegex.filter:type_name -> ipc.ListFilter
-	1,  // 6: ipc.Request.note_add:type_name -> ipc.NoteAdd
-	2,  // 7: ipc.Request.note_edit:type_name -> ipc.NoteEdit
-	3,  // 8: ipc.Request.note_delete:type_name -> ipc.NoteDelete
-	4,  // 9: ipc.Request.note_show:type_name -> ipc.NoteShow
-	5,  // 10: ipc.Request.note_list:type_name -> ipc.ListFilter
-	6,  // 11: ipc.Request.note_search_fts:type_name -> ipc.SearchFTS
-	7,  // 12: ipc.Request.note_search_regex:type_name -> ipc.SearchRegex
-	19, // 13: ipc.Request.sync_run:type_name -> ipc.SyncRun
-	22, // 14: ipc.Request.queue_list:type_name -> ipc.QueueRequest
-	20, // 15: ipc.Request.namespace_list:type_name -> ipc.NamespaceList
-	8,  // 16: ipc.Request.tag_list:type_name -> ipc.TagList
-	21, // 17: ipc.Request.namespace_delete:type_name -> ipc.NamespaceDelete
-	0,  // 18: ipc.Response.entry:type_name -> ipc.Entry
-	0,  // 19: ipc.Response.entries:type_name -> ipc.Entry
-	24, // 20: ipc.Response.queue:type_name -> ipc.QueueRemote
-	10, // 21: ipc.Response.tags:type_name -> ipc.TagStat
-	12, // 22: ipc.Response.page:type_name -> ipc.Page
-	25, // 23: ipc.RepEvent.time:type_name -> google.protobuf.Timestamp
-	13, // 24: ipc.PushBatch.events:type_name -> ipc.RepEvent
-	25, // 25: ipc.Cursor.after:type_name -> google.protobuf.Timestamp
-	15, // 26: ipc.PushResult.items:type_name -> ipc.ItemStatus
-	16, // 27: ipc.PushResult.next:type_name -> ipc.Cursor
-	13, // 28: ipc.PullResult.events:type_name -> ipc.RepEvent
-	16, // 29: ipc.PullResult.next:type_name -> ipc.Cursor
-	25, // 30: ipc.QueueEvent.time:type_name -> google.protobuf.Timestamp
-	23, // 31: ipc.QueueRemote.events:type_name -> ipc.QueueEvent
-	32, // [32:32] is the sub-list for method output_type
-	32, // [32:32] is the sub-list for method input_type
-	32, // [32:32] is the sub-list for extension type_name
-	32, // [32:32] is the sub-list for extension extendee
-	0,  // [0:32] is the sub-list for field type_name
+	62, // 0: ipc.Entry.created_at:type_name -> google.protobuf.Timestamp
+	62, // 1: ipc.Entry.updated_at:type_name -> google.protobuf.Timestamp
+	59, // 2: ipc.Entry.fields:type_name -> ipc.Entry.FieldsEntry
+	62, // 3: ipc.NoteAdd.created_at:type_name -> google.protobuf.Timestamp
+	62, // 4: ipc.NoteAdd.updated_at:type_name -> google.protobuf.Timestamp
+	60, // 5: ipc.NoteAdd.fields:type_name -> ipc.NoteAdd.FieldsEntry
+	62, // 6: ipc.NoteEdit.created_at:type_name -> google.protobuf.Timestamp
+	61, // 7: ipc.NoteEdit.fields:type_name -> ipc.NoteEdit.FieldsEntry
+	62, // 8: ipc.ListFilter.since:type_name -> google.protobuf.Timestamp
+	62, // 9: ipc.ListFilter.until:type_name -> google.protobuf.Timestamp
+	11, // 10: ipc.SearchFTS.filter:type_name -> ipc.ListFilter
+	11, // 11: ipc.SearchRegex.filter:type_name -> ipc.ListFilter
+	62, // 12: ipc.View.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 13: ipc.ViewSave.view:type_name -> ipc.View
+	58, // 14: ipc.DraftSave.draft:type_name -> ipc.Draft
+	2,  // 15: ipc.Request.note_add:type_name -> ipc.NoteAdd
+	3,  // 16: ipc.Request.note_edit:type_name -> ipc.NoteEdit
+	4,  // 17: ipc.Request.note_delete:type_name -> ipc.NoteDelete
+	5,  // 18: ipc.Request.note_show:type_name -> ipc.NoteShow
+	11, // 19: ipc.Request.note_list:type_name -> ipc.ListFilter
+	12, // 20: ipc.Request.note_search_fts:type_name -> ipc.SearchFTS
+	13, // 21: ipc.Request.note_search_regex:type_name -> ipc.SearchRegex
+	44, // 22: ipc.Request.sync_run:type_name -> ipc.SyncRun
+	47, // 23: ipc.Request.queue_list:type_name -> ipc.QueueRequest
+	45, // 24: ipc.Request.namespace_list:type_name -> ipc.NamespaceList
+	18, // 25: ipc.Request.tag_list:type_name -> ipc.TagList
+	46, // 26: ipc.Request.namespace_delete:type_name -> ipc.NamespaceDelete
+	50, // 27: ipc.Request.sync_status:type_name -> ipc.SyncStatusRequest
+	52, // 28: ipc.Request.sync_plan:type_name -> ipc.SyncPlanRequest
+	53, // 29: ipc.Request.sync_replay:type_name -> ipc.SyncReplayRequest
+	12, // 30: ipc.Request.note_search_fuzzy:type_name -> ipc.SearchFTS
+	15, // 31: ipc.Request.view_save:type_name -> ipc.ViewSave
+	16, // 32: ipc.Request.view_list:type_name -> ipc.ViewList
+	17, // 33: ipc.Request.view_delete:type_name -> ipc.ViewDelete
+	6,  // 34: ipc.Request.note_related:type_name -> ipc.NoteRelated
+	7,  // 35: ipc.Request.note_dupes:type_name -> ipc.NoteDupes
+	8,  // 36: ipc.Request.note_merge:type_name -> ipc.NoteMerge
+	9,  // 37: ipc.Request.note_links:type_name -> ipc.NoteLinks
+	10, // 38: ipc.Request.note_backlinks:type_name -> ipc.NoteBacklinks
+	19, // 39: ipc.Request.tag_edit:type_name -> ipc.TagEdit
+	20, // 40: ipc.Request.notify:type_name -> ipc.NotifyControl
+	21, // 41: ipc.Request.note_remind:type_name -> ipc.NoteRemind
+	22, // 42: ipc.Request.note_reminders:type_name -> ipc.NoteReminders
+	23, // 43: ipc.Request.note_daily:type_name -> ipc.NoteDaily
+	24, // 44: ipc.Request.draft_save:type_name -> ipc.DraftSave
+	25, // 45: ipc.Request.draft_list:type_name -> ipc.DraftList
+	26, // 46: ipc.Request.draft_delete:type_name -> ipc.DraftDelete
+	0,  // 47: ipc.Response.entry:type_name -> ipc.Entry
+	0,  // 48: ipc.Response.entries:type_name -> ipc.Entry
+	49, // 49: ipc.Response.queue:type_name -> ipc.QueueRemote
+	28, // 50: ipc.Response.tags:type_name -> ipc.TagStat
+	37, // 51: ipc.Response.page:type_name -> ipc.Page
+	51, // 52: ipc.Response.sync_status:type_name -> ipc.SyncStatus
+	55, // 53: ipc.Response.sync_plan:type_name -> ipc.SyncPlan
+	33, // 54: ipc.Response.hits:type_name -> ipc.SearchHit
+	30, // 55: ipc.Response.suggestions:type_name -> ipc.TermSuggestion
+	14, // 56: ipc.Response.views:type_name -> ipc.View
+	34, // 57: ipc.Response.duplicates:type_name -> ipc.Duplicate
+	35, // 58: ipc.Response.clusters:type_name -> ipc.DupeCluster
+	36, // 59: ipc.Response.links:type_name -> ipc.Link
+	56, // 60: ipc.Response.notify_status:type_name -> ipc.NotifyStatus
+	57, // 61: ipc.Response.reminders:type_name -> ipc.Reminder
+	58, // 62: ipc.Response.drafts:type_name -> ipc.Draft
+	31, // 63: ipc.Snippet.matches:type_name -> ipc.TextRange
+	0,  // 64: ipc.SearchHit.entry:type_name -> ipc.Entry
+	32, // 65: ipc.SearchHit.snippets:type_name -> ipc.Snippet
+	0,  // 66: ipc.Duplicate.entry:type_name -> ipc.Entry
+	0,  // 67: ipc.DupeCluster.entries:type_name -> ipc.Entry
+	0,  // 68: ipc.Link.entry:type_name -> ipc.Entry
+	62, // 69: ipc.RepEvent.time:type_name -> google.protobuf.Timestamp
+	38, // 70: ipc.PushBatch.events:type_name -> ipc.RepEvent
+	62, // 71: ipc.Cursor.after:type_name -> google.protobuf.Timestamp
+	40, // 72: ipc.PushResult.items:type_name -> ipc.ItemStatus
+	41, // 73: ipc.PushResult.next:type_name -> ipc.Cursor
+	38, // 74: ipc.PullResult.events:type_name -> ipc.RepEvent
+	41, // 75: ipc.PullResult.next:type_name -> ipc.Cursor
+	62, // 76: ipc.QueueEvent.time:type_name -> google.protobuf.Timestamp
+	48, // 77: ipc.QueueRemote.events:type_name -> ipc.QueueEvent
+	62, // 78: ipc.SyncStatus.last_attempt:type_name -> google.protobuf.Timestamp
+	62, // 79: ipc.SyncStatus.last_success:type_name -> google.protobuf.Timestamp
+	62, // 80: ipc.SyncStatus.last_error_at:type_name -> google.protobuf.Timestamp
+	62, // 81: ipc.SyncStatus.next_run:type_name -> google.protobuf.Timestamp
+	62, // 82: ipc.SyncReplayRequest.from:type_name -> google.protobuf.Timestamp
+	62, // 83: ipc.SyncPlanEvent.time:type_name -> google.protobuf.Timestamp
+	54, // 84: ipc.SyncPlan.events:type_name -> ipc.SyncPlanEvent
+	62, // 85: ipc.NotifyStatus.last_note:type_name -> google.protobuf.Timestamp
+	62, // 86: ipc.NotifyStatus.last_sent:type_name -> google.protobuf.Timestamp
+	62, // 87: ipc.NotifyStatus.due:type_name -> google.protobuf.Timestamp
+	62, // 88: ipc.NotifyStatus.snoozed_until:type_name -> google.protobuf.Timestamp
+	62, // 89: ipc.Reminder.at:type_name -> google.protobuf.Timestamp
+	62, // 90: ipc.Reminder.fired_at:type_name -> google.protobuf.Timestamp
+	62, // 91: ipc.Reminder.updated_at:type_name -> google.protobuf.Timestamp
+	62, // 92: ipc.Draft.created_at:type_name -> google.protobuf.Timestamp
+	62, // 93: ipc.Draft.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 94: ipc.Entry.FieldsEntry.value:type_name -> ipc.FieldValue
+	1,  // 95: ipc.NoteAdd.FieldsEntry.value:type_name -> ipc.FieldValue
+	1,  // 96: ipc.NoteEdit.FieldsEntry.value:type_name -> ipc.FieldValue
+	97, // [97:97] is the sub-list for method output_type
+	97, // [97:97] is the sub-list for method input_type
+	97, // [97:97] is the sub-list for extension type_name
+	97, // [97:97] is the sub-list for extension extendee
+	0,  // [0:97] is the sub-list for field type_name
 }
 
 func init() { file_internal_ipc_pb_ipc_proto_init() }
@@ -1967,7 +5157,12 @@ func file_internal_ipc_pb_ipc_proto_init() {
 	if File_internal_ipc_pb_ipc_proto != nil {
 		return
 	}
-	file_internal_ipc_pb_ipc_proto_msgTypes[9].OneofWrappers = []any{
+	file_internal_ipc_pb_ipc_proto_msgTypes[1].OneofWrappers = []any{
+		(*FieldValue_Text)(nil),
+		(*FieldValue_Number)(nil),
+		(*FieldValue_Flag)(nil),
+	}
+	file_internal_ipc_pb_ipc_proto_msgTypes[27].OneofWrappers = []any{
 		(*Request_NoteAdd)(nil),
 		(*Request_NoteEdit)(nil),
 		(*Request_NoteDelete)(nil),
@@ -1980,6 +5175,26 @@ func file_internal_ipc_pb_ipc_proto_init() {
 		(*Request_NamespaceList)(nil),
 		(*Request_TagList)(nil),
 		(*Request_NamespaceDelete)(nil),
+		(*Request_SyncStatus)(nil),
+		(*Request_SyncPlan)(nil),
+		(*Request_SyncReplay)(nil),
+		(*Request_NoteSearchFuzzy)(nil),
+		(*Request_ViewSave)(nil),
+		(*Request_ViewList)(nil),
+		(*Request_ViewDelete)(nil),
+		(*Request_NoteRelated)(nil),
+		(*Request_NoteDupes)(nil),
+		(*Request_NoteMerge)(nil),
+		(*Request_NoteLinks)(nil),
+		(*Request_NoteBacklinks)(nil),
+		(*Request_TagEdit)(nil),
+		(*Request_Notify)(nil),
+		(*Request_NoteRemind)(nil),
+		(*Request_NoteReminders)(nil),
+		(*Request_NoteDaily)(nil),
+		(*Request_DraftSave)(nil),
+		(*Request_DraftList)(nil),
+		(*Request_DraftDelete)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1987,7 +5202,7 @@ func file_internal_ipc_pb_ipc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_ipc_pb_ipc_proto_rawDesc), len(file_internal_ipc_pb_ipc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   62,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -2,6 +2,7 @@ package ipc
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -29,6 +30,10 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 		m.Body = x.NoteAdd.Body
 		m.Tags = append([]string(nil), x.NoteAdd.Tags...)
 		m.Namespace = x.NoteAdd.Namespace
+		m.Dedupe = x.NoteAdd.Dedupe
+		m.CreatedAt = pbTime(x.NoteAdd.CreatedAt)
+		m.UpdatedAt = pbTime(x.NoteAdd.UpdatedAt)
+		m.Fields = fromPbFields(x.NoteAdd.Fields)
 	case *pb.Request_NoteEdit:
 		m.Name = "note.edit"
 		m.ID = x.NoteEdit.Id
@@ -37,6 +42,9 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 		m.Body = x.NoteEdit.Body
 		m.Tags = append([]string(nil), x.NoteEdit.Tags...)
 		m.Namespace = x.NoteEdit.Namespace
+		m.CreatedAt = pbTime(x.NoteEdit.CreatedAt)
+		m.Fields = fromPbFields(x.NoteEdit.Fields)
+		m.SetFields = x.NoteEdit.SetFields
 	case *pb.Request_NoteDelete:
 		m.Name = "note.delete"
 		m.ID = x.NoteDelete.Id
@@ -54,8 +62,16 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 		m.Name = "note.search.fts"
 		if x.NoteSearchFts != nil {
 			m.Title = x.NoteSearchFts.Query
+			m.SortBy = x.NoteSearchFts.SortBy
 			fillFilter(&m, x.NoteSearchFts.Filter)
 		}
+	case *pb.Request_NoteSearchFuzzy:
+		m.Name = "note.search.fuzzy"
+		if x.NoteSearchFuzzy != nil {
+			m.Title = x.NoteSearchFuzzy.Query
+			m.SortBy = x.NoteSearchFuzzy.SortBy
+			fillFilter(&m, x.NoteSearchFuzzy.Filter)
+		}
 	case *pb.Request_NoteSearchRegex:
 		m.Name = "note.search.regex"
 		if x.NoteSearchRegex != nil {
@@ -70,6 +86,25 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 			m.Limit = int(x.QueueList.Limit)
 			m.Remote = x.QueueList.Remote
 		}
+	case *pb.Request_SyncStatus:
+		m.Name = "sync.status"
+		if x.SyncStatus != nil {
+			m.Remote = x.SyncStatus.Remote
+		}
+	case *pb.Request_SyncPlan:
+		m.Name = "sync.plan"
+		if x.SyncPlan != nil {
+			m.Remote = x.SyncPlan.Remote
+			m.Limit = int(x.SyncPlan.Limit)
+		}
+	case *pb.Request_SyncReplay:
+		m.Name = "sync.replay"
+		if x.SyncReplay != nil {
+			m.Remote = x.SyncReplay.Remote
+			if x.SyncReplay.From != nil {
+				m.Since = x.SyncReplay.From.AsTime().UTC().Format(time.RFC3339Nano)
+			}
+		}
 	case *pb.Request_NamespaceList:
 		m.Name = "namespace.list"
 	case *pb.Request_NamespaceDelete:
@@ -80,11 +115,71 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 		if x.TagList != nil {
 			m.Namespace = x.TagList.Namespace
 		}
+	case *pb.Request_NoteRelated:
+		m.Name = "note.related"
+		m.ID, m.Namespace, m.Limit = x.NoteRelated.GetId(), x.NoteRelated.GetNamespace(), int(x.NoteRelated.GetLimit())
+	case *pb.Request_NoteDupes:
+		m.Name = "note.dupes"
+		m.Namespace = x.NoteDupes.GetNamespace()
+	case *pb.Request_NoteMerge:
+		m.Name = "note.merge"
+		m.ID, m.Namespace = x.NoteMerge.GetId(), x.NoteMerge.GetNamespace()
+		m.Args = append([]string(nil), x.NoteMerge.GetOthers()...)
+	case *pb.Request_NoteLinks:
+		m.Name = "note.links"
+		m.ID, m.Namespace = x.NoteLinks.GetId(), x.NoteLinks.GetNamespace()
+	case *pb.Request_NoteBacklinks:
+		m.Name = "note.backlinks"
+		m.ID, m.Namespace = x.NoteBacklinks.GetId(), x.NoteBacklinks.GetNamespace()
+	case *pb.Request_TagEdit:
+		te := x.TagEdit
+		m.Name = "tag." + te.GetOp()
+		m.Namespace, m.Title, m.Body, m.Recursive = te.GetNamespace(), te.GetTo(), te.GetDescription(), te.GetRecursive()
+		m.Tags = append([]string(nil), te.GetTags()...)
+	case *pb.Request_Notify:
+		m.Name = "notify." + x.Notify.GetOp()
+		m.Namespace, m.Until = x.Notify.GetNamespace(), x.Notify.GetUntil()
+	case *pb.Request_NoteRemind:
+		nr := x.NoteRemind
+		m.Name = "note.remind"
+		m.ID, m.Namespace, m.At, m.Device = nr.GetId(), nr.GetNamespace(), nr.GetAt(), nr.GetDevice()
+	case *pb.Request_NoteReminders:
+		m.Name = "note.reminders"
+		m.Namespace, m.All = x.NoteReminders.GetNamespace(), x.NoteReminders.GetAll()
+	case *pb.Request_NoteDaily:
+		nd := x.NoteDaily
+		m.Name = "note.daily"
+		m.Namespace, m.Date, m.Title, m.Body = nd.GetNamespace(), nd.GetDate(), nd.GetTitle(), nd.GetBody()
+		m.Tags = append([]string(nil), nd.GetTags()...)
+	case *pb.Request_DraftSave:
+		m.Name = "draft.save"
+		if d := x.DraftSave.GetDraft(); d != nil {
+			m.ID, m.Namespace, m.Path, m.IfVersion, m.Body = d.GetId(), d.GetNamespace(), d.GetPath(), d.GetBaseVersion(), d.GetContent()
+		}
+	case *pb.Request_DraftList:
+		m.Name = "draft.list"
+		m.Namespace, m.All = x.DraftList.GetNamespace(), x.DraftList.GetAll()
+	case *pb.Request_DraftDelete:
+		m.Name = "draft.delete"
+		m.ID = x.DraftDelete.GetId()
+	case *pb.Request_ViewSave:
+		m.Name = "view.save"
+		if v := x.ViewSave.GetView(); v != nil {
+			m.Title, m.Namespace, m.Query = v.Name, v.Namespace, v.Query
+			m.TagsAny, m.TagsAll = v.TagsAny, v.TagsAll
+			m.Since, m.Until = v.Since, v.Until
+		}
+	case *pb.Request_ViewList:
+		m.Name = "view.list"
+		m.Namespace, m.Title = x.ViewList.GetNamespace(), x.ViewList.GetName()
+	case *pb.Request_ViewDelete:
+		m.Name = "view.delete"
+		m.Namespace, m.Title = x.ViewDelete.GetNamespace(), x.ViewDelete.GetName()
 	}
 
 	r := h.fn(m)
 	// Convert Response -> pb.Response
-	presp := &pb.Response{Ok: r.OK, Msg: r.Msg}
+	presp := &pb.Response{Ok: r.OK, Msg: r.Msg, Changed: int32(r.Changed)}
 	if r.Entry != nil {
 		e := toPbEntry(*r.Entry)
 		presp.Entry = &e
@@ -96,6 +191,33 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 			presp.Entries = append(presp.Entries, &ee)
 		}
 	}
+	if len(r.Hits) > 0 {
+		presp.Hits = make([]*pb.SearchHit, 0, len(r.Hits))
+		for _, h := range r.Hits {
+			presp.Hits = append(presp.Hits, toPbSearchHit(h))
+		}
+	}
+	presp.Corrected = r.Corrected
+	for _, ts := range r.Suggestions {
+		presp.Suggestions = append(presp.Suggestions, &pb.TermSuggestion{Term: ts.Term, Suggestions: ts.Suggestions})
+	}
+	for _, v := range r.Views {
+		presp.Views = append(presp.Views, toPbView(v))
+	}
+	for _, d := range r.Duplicates {
+		presp.Duplicates = append(presp.Duplicates, toPbDuplicate(d))
+	}
+	for _, c := range r.Clusters {
+		presp.Clusters = append(presp.Clusters, toPbDupeCluster(c))
+	}
+	for _, l := range r.Links {
+		pl := &pb.Link{Target: l.Target}
+		if l.Entry != nil {
+			e := toPbEntry(*l.Entry)
+			pl.Entry = &e
+		}
+		presp.Links = append(presp.Links, pl)
+	}
 	if len(r.Queue) > 0 {
 		presp.Queue = make([]*pb.QueueRemote, 0, len(r.Queue))
 		for _, qr := range r.Queue {
@@ -115,7 +237,28 @@ func (h pbHandler) Handle(ctx context.Context, req any) (any, error) {
 	if len(r.Tags) > 0 {
 		presp.Tags = make([]*pb.TagStat, 0, len(r.Tags))
 		for _, t := range r.Tags {
-			presp.Tags = append(presp.Tags, &pb.TagStat{Tag: t.Tag, Count: int32(t.Count), Description: t.Description})
+			presp.Tags = append(presp.Tags, &pb.TagStat{Tag: t.Tag, Count: int32(t.Count), Description: t.Description, Frecency: int32(t.Frecency)})
+		}
+	}
+	for _, st := range r.NotifyStatus {
+		presp.NotifyStatus = append(presp.NotifyStatus, toPbNotifyStatus(st))
+	}
+	for _, rm := range r.Reminders {
+		presp.Reminders = append(presp.Reminders, toPbReminder(rm))
+	}
+	for _, d := range r.Drafts {
+		presp.Drafts = append(presp.Drafts, toPbDraft(d))
+	}
+	if len(r.SyncStatus) > 0 {
+		presp.SyncStatus = make([]*pb.SyncStatus, 0, len(r.SyncStatus))
+		for _, st := range r.SyncStatus {
+			presp.SyncStatus = append(presp.SyncStatus, toPbSyncStatus(st))
+		}
+	}
+	if len(r.SyncPlan) > 0 {
+		presp.SyncPlan = make([]*pb.SyncPlan, 0, len(r.SyncPlan))
+		for _, p := range r.SyncPlan {
+			presp.SyncPlan = append(presp.SyncPlan, toPbSyncPlan(p))
 		}
 	}
 	if r.Page.Next != "" || r.Page.Prev != "" {
@@ -140,7 +283,162 @@ func toPbEntry(e api.Entry) pb.Entry {
 		CreatedAt: timestamppb.New(e.CreatedAt),
 		UpdatedAt: timestamppb.New(e.UpdatedAt),
 		Namespace: e.Namespace,
+		Fields:    toPbFields(e.Fields),
+	}
+}
+
+// toPbFields converts custom field values, normalized as by
+// api.NormalizeFields when they can be. Other values travel as their text
+// and the daemon reports invalid names.
+func toPbFields(fields map[string]any) map[string]*pb.FieldValue {
+	if len(fields) == 0 {
+		return nil
+	}
+	if norm, err := api.NormalizeFields(fields); err == nil {
+		fields = norm
+	}
+	out := make(map[string]*pb.FieldValue, len(fields))
+	for k, v := range fields {
+		switch x := v.(type) {
+		case float64:
+			out[k] = &pb.FieldValue{Kind: &pb.FieldValue_Number{Number: x}}
+		case bool:
+			out[k] = &pb.FieldValue{Kind: &pb.FieldValue_Flag{Flag: x}}
+		default:
+			out[k] = &pb.FieldValue{Kind: &pb.FieldValue_Text{Text: api.FormatField(v)}}
+		}
+	}
+	return out
+}
+
+func fromPbFields(fields map[string]*pb.FieldValue) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(fields))
+	for k, v := range fields {
+		switch x := v.GetKind().(type) {
+		case *pb.FieldValue_Number:
+			out[k] = x.Number
+		case *pb.FieldValue_Flag:
+			out[k] = x.Flag
+		case *pb.FieldValue_Text:
+			out[k] = x.Text
+		}
+	}
+	return out
+}
+
+func toPbView(v api.View) *pb.View {
+	return &pb.View{
+		Name: v.Name, Namespace: v.Namespace, Query: v.Query,
+		TagsAny: v.TagsAny, TagsAll: v.TagsAll, Since: v.Since, Until: v.Until,
+		UpdatedAt: pbTimestamp(v.UpdatedAt),
+	}
+}
+
+func toPbDuplicate(d api.Duplicate) *pb.Duplicate {
+	e := toPbEntry(d.Entry)
+	return &pb.Duplicate{Entry: &e, Exact: d.Exact, Distance: int32(d.Distance)}
+}
+
+func toPbDupeCluster(c api.DupeCluster) *pb.DupeCluster {
+	out := &pb.DupeCluster{Exact: c.Exact}
+	for _, e := range c.Entries {
+		pe := toPbEntry(e)
+		out.Entries = append(out.Entries, &pe)
+	}
+	return out
+}
+
+func toPbSyncStatus(st SyncStatus) *pb.SyncStatus {
+	return &pb.SyncStatus{
+		Name:         st.Name,
+		Url:          st.URL,
+		LastAttempt:  pbTimestamp(st.LastAttempt),
+		LastSuccess:  pbTimestamp(st.LastSuccess),
+		LastError:    st.LastError,
+		LastErrorAt:  pbTimestamp(st.LastErrorAt),
+		Failures:     st.Failures,
+		PushedEvents: st.PushedEvents,
+		PulledEvents: st.PulledEvents,
+		PushedBytes:  st.PushedBytes,
+		PulledBytes:  st.PulledBytes,
+		Pending:      st.Pending,
+		BackoffMs:    st.BackoffMS,
+		NextRun:      pbTimestamp(st.NextRun),
+	}
+}
+
+func toPbNotifyStatus(st NotifyStatus) *pb.NotifyStatus {
+	return &pb.NotifyStatus{
+		Namespace:    st.Namespace,
+		EveryMs:      st.EveryMS,
+		LastNote:     pbTimestamp(st.LastNote),
+		LastSent:     pbTimestamp(st.LastSent),
+		Due:          pbTimestamp(st.Due),
+		SnoozedUntil: pbTimestamp(st.SnoozedUntil),
+		Muted:        st.Muted,
+		Enabled:      st.Enabled,
+		QuietHours:   st.QuietHours,
+	}
+}
+
+func toPbReminder(r api.Reminder) *pb.Reminder {
+	return &pb.Reminder{
+		NoteId:    r.NoteID,
+		Namespace: r.Namespace,
+		At:        pbTimestamp(r.At),
+		Device:    r.Device,
+		FiredAt:   pbTimestamp(r.FiredAt),
+		UpdatedAt: pbTimestamp(r.UpdatedAt),
+		Title:     r.Title,
+	}
+}
+
+func toPbDraft(d api.Draft) *pb.Draft {
+	return &pb.Draft{
+		Id:          d.ID,
+		Namespace:   d.Namespace,
+		Path:        d.Path,
+		BaseVersion: d.BaseVersion,
+		Content:     d.Content,
+		CreatedAt:   pbTimestamp(d.CreatedAt),
+		UpdatedAt:   pbTimestamp(d.UpdatedAt),
+	}
+}
+
+func toPbSyncPlan(p SyncPlan) *pb.SyncPlan {
+	out := &pb.SyncPlan{
+		Name:      p.Name,
+		Url:       p.URL,
+		Push:      p.Push,
+		Create:    p.Create,
+		Update:    p.Update,
+		Delete:    p.Delete,
+		Unchanged: p.Unchanged,
+		Errors:    p.Errors,
 	}
+	for _, ev := range p.Events {
+		out.Events = append(out.Events, &pb.SyncPlanEvent{
+			Time:      pbTimestamp(ev.Time),
+			Type:      ev.Type,
+			Id:        ev.ID,
+			Namespace: ev.Namespace,
+			Title:     ev.Title,
+			Action:    ev.Action,
+			Err:       ev.Err,
+		})
+	}
+	return out
+}
+
+// pbTimestamp leaves zero times unset so they round-trip as zero.
+func pbTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
 }
 
 func fillFilter(m *Message, f *pb.ListFilter) {
@@ -160,4 +458,19 @@ func fillFilter(m *Message, f *pb.ListFilter) {
 	m.Cursor = f.Cursor
 	m.Reverse = f.Reverse
 	m.IncludeBody = f.IncludeBody
+	m.Query = f.Query
+	m.Sort = f.Sort
+}
+
+func toPbSearchHit(h api.SearchHit) *pb.SearchHit {
+	e := toPbEntry(h.Entry)
+	out := &pb.SearchHit{Entry: &e, Score: h.Score}
+	for _, sn := range h.Snippets {
+		ps := &pb.Snippet{Field: sn.Field, Text: sn.Text}
+		for _, m := range sn.Matches {
+			ps.Matches = append(ps.Matches, &pb.TextRange{Start: int32(m.Start), End: int32(m.End)})
+		}
+		out.Snippets = append(out.Snippets, ps)
+	}
+	return out
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mithrel/ginkgo/pkg/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +21,8 @@ func TestListFilterTranslationRoundTrip(t *testing.T) {
 		TagsAll:   []string{"ginkgo", "go"},
 		Since:     sinceStr,
 		Until:     untilStr,
+		Query:     "tag:work -tag:draft",
+		Sort:      "-mood",
 	}
 
 	// 2. Convert to Protobuf (simulating client side)
@@ -35,6 +38,8 @@ func TestListFilterTranslationRoundTrip(t *testing.T) {
 	assert.Equal(t, original.TagsAll, received.TagsAll)
 	assert.Equal(t, original.Since, received.Since)
 	assert.Equal(t, original.Until, received.Until)
+	assert.Equal(t, original.Query, received.Query)
+	assert.Equal(t, original.Sort, received.Sort)
 }
 
 func TestSearchFTSTranslationRoundTrip(t *testing.T) {
@@ -60,3 +65,64 @@ func TestSearchFTSTranslationRoundTrip(t *testing.T) {
 	assert.Equal(t, original.Namespace, received.Namespace)
 	assert.Equal(t, original.TagsAny, received.TagsAny)
 }
+
+func TestSearchHitTranslationRoundTrip(t *testing.T) {
+	original := api.SearchHit{
+		Entry: api.Entry{ID: "n1", Title: "Deploy", Namespace: "ns", Tags: []string{"ops"}},
+		Score: 2.25,
+		Snippets: []api.Snippet{
+			{Field: "title", Text: "Deploy", Matches: []api.TextRange{{Start: 0, End: 6}}},
+		},
+	}
+	got := fromPbSearchHit(toPbSearchHit(original))
+	assert.Equal(t, original.Entry.ID, got.Entry.ID)
+	assert.Equal(t, original.Score, got.Score)
+	assert.Equal(t, original.Snippets, got.Snippets)
+}
+
+func TestViewTranslationRoundTrip(t *testing.T) {
+	original := api.View{
+		Name: "meetings", Namespace: "ns", Query: "-tag:draft",
+		TagsAny: []string{"a"}, TagsAll: []string{"work", "meeting"},
+		Since: "7d", Until: "1d", UpdatedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, original, fromPbView(toPbView(original)))
+}
+
+func TestDuplicateTranslationRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	e := api.Entry{ID: "a", Version: 2, Title: "t", Body: "b", Tags: []string{"x"}, Namespace: "ns", CreatedAt: at, UpdatedAt: at}
+	d := api.Duplicate{Entry: e, Distance: 2}
+	assert.Equal(t, d, fromPbDuplicate(toPbDuplicate(d)))
+	c := api.DupeCluster{Entries: []api.Entry{e, e}, Exact: true}
+	assert.Equal(t, c, fromPbDupeCluster(toPbDupeCluster(c)))
+}
+
+func TestEntryFieldsTranslationRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	e := api.Entry{ID: "a", Version: 1, Title: "t", Namespace: "ns", CreatedAt: at, UpdatedAt: at,
+		Fields: map[string]any{"mood": 4.0, "project": "ginkgo", "duration": "45m", "done": true}}
+	pe := toPbEntry(e)
+	assert.Equal(t, e, *fromPbEntry(&pe))
+}
+
+func TestNotifyStatusTranslationRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	st := NotifyStatus{
+		Namespace: "work", EveryMS: (72 * time.Hour).Milliseconds(),
+		LastNote: at, Due: at.Add(72 * time.Hour), Muted: true, Enabled: true, QuietHours: "22:00-08:00",
+	}
+	assert.Equal(t, st, fromPbNotifyStatus(toPbNotifyStatus(st)))
+}
+
+func TestReminderTranslationRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := api.Reminder{NoteID: "n1", Namespace: "work", At: at, Device: "laptop", UpdatedAt: at.Add(-time.Hour), Title: "call back"}
+	assert.Equal(t, r, fromPbReminder(toPbReminder(r)))
+}
+
+func TestDraftTranslationRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	d := api.Draft{ID: "n1", Namespace: "work", Path: "/run/ginkgo/work.n1.ginkgo.md", BaseVersion: 4, Content: "Title: x", CreatedAt: at, UpdatedAt: at.Add(time.Minute)}
+	assert.Equal(t, d, fromPbDraft(toPbDraft(d)))
+}
@@ -26,6 +26,32 @@ type Message struct {
 	IncludeBody bool     `json:"include_body,omitempty"`
 	Remote      string   `json:"remote,omitempty"`
 	SortBy      string   `json:"sort_by,omitempty"`
+	Query       string   `json:"query,omitempty"`
+	// Dedupe is the note.add duplicate policy: skip, merge or keep.
+	Dedupe string `json:"dedupe,omitempty"`
+	// Recursive makes tag.delete remove child tags too.
+	Recursive bool `json:"recursive,omitempty"`
+	// At is the note.remind time (RFC 3339); empty clears the reminder.
+	// Device names where it fires.
+	At     string `json:"at,omitempty"`
+	Device string `json:"device,omitempty"`
+	// All makes note.reminders include fired reminders.
+	All bool `json:"all,omitempty"`
+	// Date picks the day of note.daily (YYYY-MM-DD; empty is today).
+	Date string `json:"date,omitempty"`
+	// CreatedAt and UpdatedAt backdate or schedule note.add; zero is now.
+	// A set CreatedAt also moves the note of note.edit.
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	// Path is the editor file of draft.save; IfVersion is its base version
+	// and Body an optional snapshot.
+	Path string `json:"path,omitempty"`
+	// Fields are the custom fields of note.add and note.edit; note.edit
+	// replaces the note's only when SetFields is true.
+	Fields    map[string]any `json:"fields,omitempty"`
+	SetFields bool           `json:"set_fields,omitempty"`
+	// Sort orders note.list by a custom field ("-name" descending).
+	Sort string `json:"sort,omitempty"`
 }
 
 // Response is a minimal daemon reply.
@@ -38,6 +64,33 @@ type Response struct {
 	Namespaces []string      `json:"namespaces,omitempty"`
 	Tags       []api.TagStat `json:"tags,omitempty"`
 	Page       api.Page      `json:"page,omitempty"`
+	SyncStatus []SyncStatus  `json:"sync_status,omitempty"`
+	SyncPlan   []SyncPlan    `json:"sync_plan,omitempty"`
+	// Hits holds ranked search results with snippets (note.search.fts).
+	// Entries mirrors the hit entries on the client side.
+	Hits []api.SearchHit `json:"hits,omitempty"`
+	// Corrected is the query a fuzzy search ran after rewriting unknown
+	// terms; empty when nothing was rewritten. Suggestions lists the
+	// alternatives for each rewritten term.
+	Corrected   string               `json:"corrected,omitempty"`
+	Suggestions []api.TermSuggestion `json:"suggestions,omitempty"`
+	// Views holds saved views (view.list, view.save).
+	Views []api.View `json:"views,omitempty"`
+	// Duplicates lists stored notes matching a note just added or edited;
+	// Clusters groups a namespace's duplicates (note.dupes).
+	Duplicates []api.Duplicate   `json:"duplicates,omitempty"`
+	Clusters   []api.DupeCluster `json:"clusters,omitempty"`
+	// Links holds a note's resolved links (note.links), or the unresolved
+	// links of a note just saved (note.add, note.edit).
+	Links []api.Link `json:"links,omitempty"`
+	// Changed counts the notes rewritten by tag.rename/merge/delete.
+	Changed int `json:"changed,omitempty"`
+	// NotifyStatus holds the notification rules (notify.*).
+	NotifyStatus []NotifyStatus `json:"notify_status,omitempty"`
+	// Reminders holds note reminders (note.remind, note.reminders).
+	Reminders []api.Reminder `json:"reminders,omitempty"`
+	// Drafts holds editor drafts (draft.list).
+	Drafts []api.Draft `json:"drafts,omitempty"`
 }
 
 type QueueEvent struct {
@@ -52,3 +105,59 @@ type QueueRemote struct {
 	Pending int64        `json:"pending"`
 	Events  []QueueEvent `json:"events"`
 }
+
+// SyncStatus reports replication health for one remote.
+type SyncStatus struct {
+	Name         string    `json:"name"`
+	URL          string    `json:"url"`
+	LastAttempt  time.Time `json:"last_attempt"`
+	LastSuccess  time.Time `json:"last_success"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastErrorAt  time.Time `json:"last_error_at"`
+	Failures     int64     `json:"failures"`
+	PushedEvents int64     `json:"pushed_events"`
+	PulledEvents int64     `json:"pulled_events"`
+	PushedBytes  int64     `json:"pushed_bytes"`
+	PulledBytes  int64     `json:"pulled_bytes"`
+	Pending      int64     `json:"pending"`
+	BackoffMS    int64     `json:"backoff_ms"`
+	NextRun      time.Time `json:"next_run"`
+}
+
+// NotifyStatus reports one notification rule: when its namespace last got
+// a note, when it last notified and when it is due next.
+type NotifyStatus struct {
+	Namespace    string    `json:"namespace"`
+	EveryMS      int64     `json:"every_ms"`
+	LastNote     time.Time `json:"last_note"`
+	LastSent     time.Time `json:"last_sent"`
+	Due          time.Time `json:"due"`
+	SnoozedUntil time.Time `json:"snoozed_until"`
+	Muted        bool      `json:"muted"`
+	Enabled      bool      `json:"enabled"`
+	QuietHours   string    `json:"quiet_hours,omitempty"`
+}
+
+// SyncPlanEvent is one event a dry-run sync would push or apply.
+type SyncPlanEvent struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Namespace string    `json:"namespace,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Action    string    `json:"action"`
+	Err       string    `json:"error,omitempty"`
+}
+
+// SyncPlan summarizes a dry-run sync against one remote.
+type SyncPlan struct {
+	Name      string          `json:"name"`
+	URL       string          `json:"url"`
+	Push      int64           `json:"push"`
+	Create    int64           `json:"create"`
+	Update    int64           `json:"update"`
+	Delete    int64           `json:"delete"`
+	Unchanged int64           `json:"unchanged"`
+	Errors    int64           `json:"errors"`
+	Events    []SyncPlanEvent `json:"events"`
+}
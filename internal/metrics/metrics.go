@@ -0,0 +1,99 @@
+// Package metrics holds the Prometheus collectors shared by the daemon and
+// the replication server. Collectors are always updated; exposing them on
+// /metrics is controlled by the metrics.enabled config key.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ginkgo"
+
+var (
+	// IPCCommands counts daemon IPC commands by Message.Name and result (ok|error).
+	IPCCommands = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "ipc", Name: "commands_total",
+		Help: "IPC commands handled by the daemon.",
+	}, []string{"command", "result"})
+
+	// IPCDuration observes IPC command handling time by Message.Name.
+	IPCDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: "ipc", Name: "command_duration_seconds",
+		Help:    "Time spent handling IPC commands.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"command"})
+
+	// DBQueryDuration observes store operations by name.
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: "db", Name: "query_duration_seconds",
+		Help:    "Latency of database operations.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"op"})
+
+	// SearchDuration observes full-text and regex search latency.
+	SearchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: "search", Name: "duration_seconds",
+		Help:    "Latency of note searches by kind (fts|regex).",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"kind"})
+
+	// SyncDuration observes push and pull passes per remote.
+	SyncDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Subsystem: "sync", Name: "duration_seconds",
+		Help:    "Duration of replication push/pull passes.",
+		Buckets: prometheus.ExponentialBuckets(.01, 2.5, 10),
+	}, []string{"remote", "op", "result"})
+
+	// EventsApplied counts replicated events applied locally.
+	EventsApplied = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "sync", Name: "events_applied_total",
+		Help: "Replicated events applied to the local store.",
+	})
+
+	// Conflicts counts CAS conflicts by source (edit|replication).
+	Conflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "db", Name: "conflicts_total",
+		Help: "Compare-and-swap conflicts.",
+	}, []string{"source"})
+
+	// PushRejections counts push items the replication server rejected, by reason.
+	PushRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Subsystem: "server", Name: "push_rejections_total",
+		Help: "Replication push items rejected by the server.",
+	}, []string{"reason"})
+
+	registry = prometheus.NewRegistry()
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		IPCCommands, IPCDuration, DBQueryDuration, SearchDuration,
+		SyncDuration, EventsApplied, Conflicts, PushRejections,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveDB records the latency of a store operation started at start.
+// Intended for use as `defer metrics.ObserveDB("op", time.Now())`.
+func ObserveDB(op string, start time.Time) {
+	DBQueryDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+}
+
+// Result maps an error to the "ok"/"error" label value.
+func Result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
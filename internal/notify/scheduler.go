@@ -1,15 +1,367 @@
 package notify
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-// Scheduler is a simple placeholder for notification scheduling.
+	"github.com/spf13/viper"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// checkInterval is how often the daemon evaluates the rules.
+const checkInterval = 5 * time.Minute
+
+// Source reports notes; db.EntryRepo satisfies it.
+type Source interface {
+	ListEntries(ctx context.Context, q api.ListQuery) ([]api.Entry, api.Page, error)
+}
+
+// Reminders stores note reminders; db.ReminderRepo satisfies it.
+type Reminders interface {
+	ListReminders(ctx context.Context, namespace string, includeFired bool) ([]api.Reminder, error)
+	SetReminder(ctx context.Context, r api.Reminder) (api.Reminder, error)
+}
+
+// Rule nudges when a namespace has had no new note for Every.
+type Rule struct {
+	Namespace string
+	Every     time.Duration
+}
+
+// RuleStatus is the state of one rule.
+type RuleStatus struct {
+	Namespace string
+	Every     time.Duration
+	// LastNote is when the newest note was created; zero when there is none.
+	LastNote time.Time
+	// LastSent is when the rule last notified.
+	LastSent time.Time
+	// Due is when the rule notifies next, barring mute, snooze and quiet
+	// hours.
+	Due          time.Time
+	SnoozedUntil time.Time
+	Muted        bool
+}
+
+// Scheduler evaluates nudge rules and fires note reminders, delivering
+// notifications to its sinks. Snooze, mute and delivery state persist across
+// daemon restarts; reminders are marked fired in the store.
 type Scheduler struct {
-	Every time.Duration
+	Enabled bool
+	Rules   []Rule
+	Quiet   QuietHours
+	Sinks   []Sink
+	// Device names this daemon for reminders; Devices overrides it per
+	// namespace.
+	Device  string
+	Devices map[string]string
+
+	src       Source
+	rem       Reminders
+	statePath string
+	wake      chan struct{}
+
+	mu    sync.Mutex
+	state State
+}
+
+// New builds a scheduler from the notifications.* settings. The rule for
+// the default namespace uses notifications.every_days; other namespaces
+// get one through namespaces.<name>.notify_every_days. Reminders are fired
+// for the device named by namespaces.<name>.origin_label, else
+// notifications.device, else the hostname.
+func New(cfg *viper.Viper, src Source, rem Reminders) (*Scheduler, error) {
+	quiet, err := ParseQuietHours(cfg.GetString("notifications.quiet_hours"))
+	if err != nil {
+		return nil, err
+	}
+	sinks, err := SinksFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cadence := map[string]int{}
+	if d := cfg.GetInt("notifications.every_days"); d > 0 {
+		cadence[cfg.GetString("namespace")] = d
+	}
+	for name := range cfg.GetStringMap("namespaces") {
+		if d := cfg.GetInt("namespaces." + name + ".notify_every_days"); d > 0 {
+			cadence[name] = d
+		}
+	}
+	device := strings.TrimSpace(cfg.GetString("notifications.device"))
+	if device == "" {
+		device, _ = os.Hostname()
+	}
+	devices := map[string]string{}
+	for name := range cfg.GetStringMap("namespaces") {
+		if label := strings.TrimSpace(cfg.GetString("namespaces." + name + ".origin_label")); label != "" {
+			devices[name] = label
+		}
+	}
+	var rules []Rule
+	for ns, d := range cadence {
+		rules = append(rules, Rule{Namespace: ns, Every: time.Duration(d) * 24 * time.Hour})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Namespace < rules[j].Namespace })
+
+	s := &Scheduler{
+		Enabled:   cfg.GetBool("notifications.enabled"),
+		Rules:     rules,
+		Quiet:     quiet,
+		Sinks:     sinks,
+		Device:    device,
+		Devices:   devices,
+		src:       src,
+		rem:       rem,
+		statePath: filepath.Join(cfg.GetString("data_dir"), "notify.json"),
+		wake:      make(chan struct{}, 1),
+	}
+	s.state = loadState(s.statePath)
+	return s, nil
+}
+
+// Run checks every few minutes until ctx is done, waking early for the next
+// pending reminder or after Wake. Nudges are only sent when notifications
+// are enabled; reminders always fire.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		if _, err := s.Check(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			log.Printf("notify: %v", err)
+		}
+		wait := checkInterval
+		if next, ok := s.nextReminder(ctx); ok {
+			wait = min(wait, max(time.Until(next), time.Second))
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-s.wake:
+			t.Stop()
+		case <-t.C:
+		}
+	}
 }
 
-func (s *Scheduler) Next(now time.Time) time.Time {
-	if s.Every <= 0 {
-		return time.Time{}
+// Wake makes Run check again now, e.g. after a reminder was set.
+func (s *Scheduler) Wake() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// DeviceFor is the device name reminders in namespace fire on here.
+func (s *Scheduler) DeviceFor(namespace string) string {
+	if d := s.Devices[namespace]; d != "" {
+		return d
+	}
+	return s.Device
+}
+
+// Check delivers the notifications due at now and returns them. A
+// notification counts as delivered when at least one sink accepted it.
+// Reminders are explicit, so quiet hours, snooze and mute only hold back
+// nudges.
+func (s *Scheduler) Check(ctx context.Context, now time.Time) ([]Notification, error) {
+	sent, errs := s.fireReminders(ctx, now)
+	if !s.Enabled || s.Quiet.Contains(now) {
+		return sent, joinErrs(errs)
+	}
+	sts, err := s.Status(ctx, now)
+	if err != nil {
+		return sent, err
+	}
+	nudged := 0
+	for _, st := range sts {
+		if st.Muted || st.SnoozedUntil.After(now) || now.Before(st.Due) {
+			continue
+		}
+		n := Notification{Namespace: st.Namespace, Title: "ginkgo", Body: nudgeText(st, now), Time: now}
+		if err := s.deliver(ctx, n); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		s.mu.Lock()
+		s.state.LastSent[st.Namespace] = now
+		s.mu.Unlock()
+		sent = append(sent, n)
+		nudged++
+	}
+	if nudged > 0 {
+		if err := s.save(); err != nil {
+			errs = append(errs, err.Error())
+		}
 	}
-	return now.Add(s.Every)
+	return sent, joinErrs(errs)
+}
+
+// fireReminders delivers this device's pending reminders due at now and
+// marks them fired. A reminder whose delivery fails stays pending.
+func (s *Scheduler) fireReminders(ctx context.Context, now time.Time) ([]Notification, []string) {
+	if s.rem == nil {
+		return nil, nil
+	}
+	rs, err := s.rem.ListReminders(ctx, "", false)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	var sent []Notification
+	var errs []string
+	for _, r := range rs {
+		if r.At.After(now) || !s.ownsReminder(r) {
+			continue
+		}
+		body := r.Title
+		if body == "" {
+			body = "note " + r.NoteID
+		}
+		n := Notification{Namespace: r.Namespace, Title: "Reminder", Body: body, Time: now}
+		if err := s.deliver(ctx, n); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		r.FiredAt, r.UpdatedAt = now.UTC(), now.UTC()
+		if _, err := s.rem.SetReminder(ctx, r); err != nil {
+			errs = append(errs, err.Error())
+		}
+		sent = append(sent, n)
+	}
+	return sent, errs
+}
+
+// nextReminder reports when this device's next pending reminder is due.
+func (s *Scheduler) nextReminder(ctx context.Context) (time.Time, bool) {
+	if s.rem == nil {
+		return time.Time{}, false
+	}
+	rs, err := s.rem.ListReminders(ctx, "", false)
+	if err != nil {
+		return time.Time{}, false
+	}
+	for _, r := range rs {
+		if s.ownsReminder(r) {
+			return r.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ownsReminder reports whether r fires on this device. Reminders always
+// name their device; one without a device fires nowhere rather than
+// everywhere.
+func (s *Scheduler) ownsReminder(r api.Reminder) bool {
+	return r.Device != "" && r.Device == s.DeviceFor(r.Namespace)
+}
+
+func joinErrs(errs []string) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+func nudgeText(st RuleStatus, now time.Time) string {
+	if st.LastNote.IsZero() {
+		return fmt.Sprintf("No notes in %s yet. Jot something down?", st.Namespace)
+	}
+	days := int(now.Sub(st.LastNote).Hours() / 24)
+	return fmt.Sprintf("No new notes in %s for %d days. Jot something down?", st.Namespace, days)
+}
+
+// deliver sends n to every sink, succeeding if any sink does.
+func (s *Scheduler) deliver(ctx context.Context, n Notification) error {
+	if len(s.Sinks) == 0 {
+		return fmt.Errorf("no notification sinks configured")
+	}
+	var errs []string
+	for _, sink := range s.Sinks {
+		if err := sink.Notify(ctx, n); err != nil {
+			errs = append(errs, sink.Name()+": "+err.Error())
+		}
+	}
+	if len(errs) == len(s.Sinks) {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	for _, e := range errs {
+		log.Printf("notify: %s", e)
+	}
+	return nil
+}
+
+// Status reports every rule at now.
+func (s *Scheduler) Status(ctx context.Context, now time.Time) ([]RuleStatus, error) {
+	out := make([]RuleStatus, 0, len(s.Rules))
+	for _, r := range s.Rules {
+		st := RuleStatus{Namespace: r.Namespace, Every: r.Every}
+		latest, _, err := s.src.ListEntries(ctx, api.ListQuery{Namespace: r.Namespace, Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) > 0 {
+			st.LastNote = latest[0].CreatedAt
+		}
+		s.mu.Lock()
+		st.LastSent = s.state.LastSent[r.Namespace]
+		st.Muted = s.state.Muted[""] || s.state.Muted[r.Namespace]
+		st.SnoozedUntil = s.state.SnoozedUntil[r.Namespace]
+		if all := s.state.SnoozedUntil[""]; all.After(st.SnoozedUntil) {
+			st.SnoozedUntil = all
+		}
+		s.mu.Unlock()
+		if !st.SnoozedUntil.After(now) {
+			st.SnoozedUntil = time.Time{}
+		}
+		// Nudge once Every has passed since the last note, then again
+		// every Every while nothing new is written.
+		from := st.LastNote
+		if st.LastSent.After(from) {
+			from = st.LastSent
+		}
+		if !from.IsZero() {
+			st.Due = from.Add(r.Every)
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// Snooze holds back notifications for namespace ("" for all) until until;
+// a zero until resumes them.
+func (s *Scheduler) Snooze(namespace string, until time.Time) error {
+	s.mu.Lock()
+	if until.IsZero() {
+		delete(s.state.SnoozedUntil, namespace)
+	} else {
+		s.state.SnoozedUntil[namespace] = until.UTC()
+	}
+	s.mu.Unlock()
+	return s.save()
+}
+
+// Mute silences namespace ("" for all) until unmuted.
+func (s *Scheduler) Mute(namespace string, muted bool) error {
+	s.mu.Lock()
+	if muted {
+		s.state.Muted[namespace] = true
+	} else {
+		delete(s.state.Muted, namespace)
+	}
+	s.mu.Unlock()
+	return s.save()
+}
+
+func (s *Scheduler) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return saveState(s.statePath, s.state)
 }
@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+type fakeSource map[string]time.Time
+
+func (f fakeSource) ListEntries(_ context.Context, q api.ListQuery) ([]api.Entry, api.Page, error) {
+	if at, ok := f[q.Namespace]; ok {
+		return []api.Entry{{ID: "x", Namespace: q.Namespace, CreatedAt: at}}, api.Page{}, nil
+	}
+	return nil, api.Page{}, nil
+}
+
+type recordSink struct {
+	got []Notification
+	err error
+}
+
+func (*recordSink) Name() string { return "record" }
+
+func (r *recordSink) Notify(_ context.Context, n Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.got = append(r.got, n)
+	return nil
+}
+
+type fakeReminders struct{ rs []api.Reminder }
+
+func (f *fakeReminders) ListReminders(_ context.Context, _ string, includeFired bool) ([]api.Reminder, error) {
+	var out []api.Reminder
+	for _, r := range f.rs {
+		if includeFired || r.FiredAt.IsZero() {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeReminders) SetReminder(_ context.Context, r api.Reminder) (api.Reminder, error) {
+	for i := range f.rs {
+		if f.rs[i].NoteID == r.NoteID {
+			f.rs[i] = r
+		}
+	}
+	return r, nil
+}
+
+func newTestScheduler(t *testing.T, src Source, sinks ...Sink) *Scheduler {
+	path := filepath.Join(t.TempDir(), "notify.json")
+	return &Scheduler{
+		Enabled:   true,
+		Rules:     []Rule{{Namespace: "default", Every: 72 * time.Hour}, {Namespace: "work", Every: 24 * time.Hour}},
+		Sinks:     sinks,
+		src:       src,
+		statePath: path,
+		state:     loadState(path),
+	}
+}
+
+func TestSchedulerNudgesIdleNamespaces(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	sink := &recordSink{}
+	s := newTestScheduler(t, fakeSource{"default": now.Add(-24 * time.Hour), "work": now.Add(-48 * time.Hour)}, sink)
+
+	sent, err := s.Check(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Equal(t, "work", sent[0].Namespace)
+	require.Contains(t, sent[0].Body, "for 2 days")
+
+	// Not again until another cadence has passed.
+	sent, err = s.Check(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, sent)
+	sent, err = s.Check(ctx, now.Add(25*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+
+	// State survives a restart.
+	st := loadState(s.statePath)
+	require.Equal(t, now.Add(25*time.Hour), st.LastSent["work"].UTC())
+}
+
+func TestSchedulerSnoozeMuteQuiet(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	sink := &recordSink{}
+	s := newTestScheduler(t, fakeSource{}, sink)
+
+	require.NoError(t, s.Snooze("", now.Add(2*time.Hour)))
+	require.NoError(t, s.Mute("work", true))
+	sent, err := s.Check(ctx, now)
+	require.NoError(t, err)
+	require.Empty(t, sent)
+
+	sts, err := s.Status(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(2*time.Hour), sts[0].SnoozedUntil)
+	require.True(t, sts[1].Muted)
+
+	s.Quiet, err = ParseQuietHours("22:00-08:00")
+	require.NoError(t, err)
+	sent, err = s.Check(ctx, time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Empty(t, sent)
+
+	// After the snooze and outside quiet hours only the unmuted rule fires.
+	sent, err = s.Check(ctx, now.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Equal(t, "default", sent[0].Namespace)
+	require.Contains(t, sent[0].Body, "yet")
+}
+
+func TestSchedulerDeliveryFailure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	ok := &recordSink{}
+	s := newTestScheduler(t, fakeSource{"work": now}, &recordSink{err: errors.New("boom")}, ok)
+	s.Rules = s.Rules[:1]
+
+	// One working sink is enough.
+	sent, err := s.Check(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+
+	s.Sinks = s.Sinks[:1]
+	s.state.LastSent = map[string]time.Time{}
+	_, err = s.Check(ctx, now)
+	require.ErrorContains(t, err, "boom")
+	require.True(t, s.state.LastSent["default"].IsZero())
+}
+
+func TestSchedulerFiresRemindersOnItsDevice(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)
+	sink := &recordSink{}
+	s := newTestScheduler(t, fakeSource{"default": now, "work": now}, sink)
+	s.Device, s.Devices = "laptop", map[string]string{"work": "desk"}
+	rem := &fakeReminders{rs: []api.Reminder{
+		{NoteID: "a", Namespace: "default", At: now.Add(-time.Hour), Device: "laptop", Title: "call back"},
+		{NoteID: "b", Namespace: "default", At: now.Add(-time.Hour), Device: "phone"},
+		{NoteID: "c", Namespace: "work", At: now.Add(-time.Minute), Device: "desk"},
+		{NoteID: "d", Namespace: "default", At: now.Add(time.Hour), Device: "laptop"},
+		{NoteID: "e", Namespace: "default", At: now.Add(-time.Hour)},
+	}}
+	s.rem = rem
+	// Reminders ignore quiet hours and mute.
+	s.Quiet, _ = ParseQuietHours("22:00-08:00")
+	require.NoError(t, s.Mute("", true))
+
+	sent, err := s.Check(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, sent, 2)
+	require.Equal(t, "Reminder", sent[0].Title)
+	require.Equal(t, "call back", sent[0].Body)
+	require.Equal(t, "note c", sent[1].Body)
+	require.Equal(t, now, rem.rs[0].FiredAt)
+	require.True(t, rem.rs[1].FiredAt.IsZero())
+	require.True(t, rem.rs[4].FiredAt.IsZero())
+
+	// Fired reminders do not fire again; the next one is pending.
+	sent, err = s.Check(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, sent)
+	next, ok := s.nextReminder(ctx)
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Hour), next)
+}
+
+func TestParseQuietHours(t *testing.T) {
+	q, err := ParseQuietHours("22:00-08:30")
+	require.NoError(t, err)
+	require.Equal(t, "22:00-08:30", q.String())
+	at := func(h, m int) time.Time { return time.Date(2025, 1, 1, h, m, 0, 0, time.UTC) }
+	require.True(t, q.Contains(at(23, 0)))
+	require.True(t, q.Contains(at(8, 29)))
+	require.False(t, q.Contains(at(8, 30)))
+	require.False(t, q.Contains(at(12, 0)))
+	_, err = ParseQuietHours("late")
+	require.Error(t, err)
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Notification is one message for the user.
+type Notification struct {
+	Namespace string    `json:"namespace,omitempty"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Time      time.Time `json:"time"`
+}
+
+// Sink delivers notifications.
+type Sink interface {
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+// LogSink writes notifications to a logger (the daemon log by default).
+type LogSink struct {
+	Logger *log.Logger
+}
+
+func (LogSink) Name() string { return "log" }
+
+func (s LogSink) Notify(_ context.Context, n Notification) error {
+	logger := s.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Printf("notify namespace=%s %s: %s", n.Namespace, n.Title, n.Body)
+	return nil
+}
+
+// CommandSink runs a notify-send style command with the title and body
+// appended as arguments.
+type CommandSink struct {
+	Command string
+}
+
+func (CommandSink) Name() string { return "desktop" }
+
+func (s CommandSink) Notify(ctx context.Context, n Notification) error {
+	argv := strings.Fields(s.Command)
+	if len(argv) == 0 {
+		return fmt.Errorf("notifications.command is empty")
+	}
+	out, err := exec.CommandContext(ctx, argv[0], append(argv[1:], n.Title, n.Body)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", argv[0], err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// WebhookSink POSTs each notification as JSON.
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func (WebhookSink) Name() string { return "webhook" }
+
+func (s WebhookSink) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook: %s", resp.Status)
+	}
+	return nil
+}
+
+// SinksFromConfig builds the sinks named by notifications.sinks.
+func SinksFromConfig(cfg *viper.Viper) ([]Sink, error) {
+	var out []Sink
+	for _, name := range cfg.GetStringSlice("notifications.sinks") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "desktop":
+			out = append(out, CommandSink{Command: cfg.GetString("notifications.command")})
+		case "log":
+			out = append(out, LogSink{})
+		case "webhook":
+			u := strings.TrimSpace(cfg.GetString("notifications.webhook_url"))
+			if u == "" {
+				return nil, fmt.Errorf("notifications.webhook_url is required for the webhook sink")
+			}
+			out = append(out, WebhookSink{URL: u})
+		default:
+			return nil, fmt.Errorf("unknown notification sink %q", name)
+		}
+	}
+	return out, nil
+}
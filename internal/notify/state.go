@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State is the persisted snooze, mute and delivery state. The "" key
+// stands for all namespaces.
+type State struct {
+	Muted        map[string]bool      `json:"muted,omitempty"`
+	SnoozedUntil map[string]time.Time `json:"snoozed_until,omitempty"`
+	LastSent     map[string]time.Time `json:"last_sent,omitempty"`
+}
+
+// loadState reads the state at path; a missing or unreadable file yields
+// an empty state.
+func loadState(path string) State {
+	var st State
+	if b, err := os.ReadFile(path); err == nil {
+		_ = json.Unmarshal(b, &st)
+	}
+	if st.Muted == nil {
+		st.Muted = map[string]bool{}
+	}
+	if st.SnoozedUntil == nil {
+		st.SnoozedUntil = map[string]time.Time{}
+	}
+	if st.LastSent == nil {
+		st.LastSent = map[string]time.Time{}
+	}
+	return st
+}
+
+// saveState writes st to path atomically.
+func saveState(path string, st State) error {
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
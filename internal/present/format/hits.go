@@ -0,0 +1,113 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// TSV columns for search hits: the entry columns plus score and snippet.
+var hitHeaderLine = "id\ttitle\tnamespace\tcreated_unix_ms\ttags\tscore\tsnippet\n"
+
+// Highlight returns the snippet text with each match passed through mark.
+func Highlight(sn api.Snippet, mark func(string) string) string {
+	var b strings.Builder
+	pos := 0
+	for _, m := range sn.Matches {
+		if m.Start < pos || m.End > len(sn.Text) || m.Start >= m.End {
+			continue
+		}
+		b.WriteString(sn.Text[pos:m.Start])
+		b.WriteString(mark(sn.Text[m.Start:m.End]))
+		pos = m.End
+	}
+	b.WriteString(sn.Text[pos:])
+	return b.String()
+}
+
+// BestSnippet picks the fragment to show for a hit: body context first,
+// then the title, then tags.
+func BestSnippet(h api.SearchHit) (api.Snippet, bool) {
+	for _, field := range []string{"body", "title", "tags"} {
+		for _, sn := range h.Snippets {
+			if sn.Field == field {
+				return sn, true
+			}
+		}
+	}
+	return api.Snippet{}, false
+}
+
+// markdownBold wraps a match for markdown and plain text output.
+func markdownBold(s string) string { return "**" + s + "**" }
+
+// WriteHits writes a batch of search hits as TSV with score and a
+// highlighted snippet column.
+func (pw *PlainStreamWriter) WriteHits(hits []api.SearchHit) error {
+	if pw.headers && !pw.wroteHeader {
+		_, _ = io.WriteString(pw.tw, hitHeaderLine)
+		pw.wroteHeader = true
+	}
+	for _, h := range hits {
+		e := h.Entry
+		ms := e.CreatedAt.UnixNano() / int64(time.Millisecond)
+		snippet := ""
+		if sn, ok := BestSnippet(h); ok {
+			snippet = Highlight(sn, markdownBold)
+		}
+		line := esc(e.ID) + "\t" + esc(e.Title) + "\t" + esc(e.Namespace) + "\t" + strconv.FormatInt(ms, 10) + "\t" + esc(joinTags(e.Tags)) +
+			"\t" + strconv.FormatFloat(h.Score, 'f', 3, 64) + "\t" + esc(snippet) + "\n"
+		_, _ = io.WriteString(pw.tw, line)
+	}
+	return pw.tw.Flush()
+}
+
+// WritePrettyHits renders search hits as markdown with highlighted title
+// and snippets using glamour.
+func WritePrettyHits(w io.Writer, hits []api.SearchHit) error {
+	var md strings.Builder
+	for i, h := range hits {
+		if i > 0 {
+			md.WriteString("\n---\n\n")
+		}
+		e := h.Entry
+		title := e.Title
+		var others []string
+		for _, sn := range h.Snippets {
+			if sn.Field == "title" {
+				title = Highlight(sn, markdownBold)
+				continue
+			}
+			others = append(others, Highlight(sn, markdownBold))
+		}
+		fmt.Fprintf(&md, "## %s\n\n> **ID:** %s | **Created:** %s | **Score:** %.3f\n",
+			title, e.ID, e.CreatedAt.Local().Format(time.RFC3339), h.Score)
+		if tags := joinTags(e.Tags); tags != "" {
+			fmt.Fprintf(&md, ">\n> **Tags:** %s\n", tags)
+		}
+		if fields := joinFields(e.Fields); fields != "" {
+			fmt.Fprintf(&md, ">\n> **Fields:** %s\n", strings.ReplaceAll(fields, ",", ", "))
+		}
+		for _, s := range others {
+			fmt.Fprintf(&md, "\n%s\n", strings.Join(strings.Fields(s), " "))
+		}
+	}
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle("dracula"),
+		glamour.WithWordWrap(80),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create renderer: %w", err)
+	}
+	out, err := r.Render(md.String())
+	if err != nil {
+		return fmt.Errorf("failed to render markdown: %w", err)
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
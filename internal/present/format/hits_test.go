@@ -0,0 +1,45 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func TestHighlight(t *testing.T) {
+	sn := api.Snippet{Field: "body", Text: "ship the deploy, then deploy again", Matches: []api.TextRange{{Start: 9, End: 15}, {Start: 22, End: 28}}}
+	got := Highlight(sn, func(s string) string { return "<" + s + ">" })
+	if want := "ship the <deploy>, then <deploy> again"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	// Out-of-range matches are ignored rather than panicking.
+	sn.Matches = append(sn.Matches, api.TextRange{Start: 30, End: 99})
+	if got2 := Highlight(sn, func(s string) string { return "<" + s + ">" }); got2 != got {
+		t.Fatalf("bad range changed output: %q", got2)
+	}
+}
+
+func TestPlainWriteHits(t *testing.T) {
+	var buf bytes.Buffer
+	pw := NewPlainStreamWriter(&buf, true)
+	hits := []api.SearchHit{{
+		Entry: api.Entry{ID: "n1", Title: "Deploy", Namespace: "ns"},
+		Score: 1.5,
+		Snippets: []api.Snippet{
+			{Field: "title", Text: "Deploy", Matches: []api.TextRange{{Start: 0, End: 6}}},
+			{Field: "body", Text: "…the deploy\nwent fine", Matches: []api.TextRange{{Start: 7, End: 13}}},
+		},
+	}}
+	if err := pw.WriteHits(hits); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "id") || !strings.Contains(out, "snippet") {
+		t.Fatalf("missing hit header: %q", out)
+	}
+	if !strings.Contains(out, "1.500") || !strings.Contains(out, `…the **deploy**\nwent fine`) {
+		t.Fatalf("unexpected row: %q", out)
+	}
+}
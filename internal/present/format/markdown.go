@@ -10,21 +10,33 @@ import (
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
+// renderLinks turns [[target|label]] note links into Markdown links so they
+// are styled as links; the target is not a URL, so none is shown.
+func renderLinks(body string) string {
+	return api.ReplaceLinks(body, func(target, label string) string {
+		return "[" + label + "](#)"
+	})
+}
+
 // WritePrettyEntry renders a single entry with markdown formatting using glamour.
 func WritePrettyEntry(w io.Writer, e api.Entry) error {
 	ts := e.CreatedAt.Local().Format(time.RFC3339)
 	tags := joinTags(e.Tags)
+	var fields strings.Builder
+	for _, k := range api.FieldNames(e.Fields) {
+		fmt.Fprintf(&fields, ">\n> **%s:** %s\n", k, api.FormatField(e.Fields[k]))
+	}
 
 	md := fmt.Sprintf(`# %s
 
 > **ID:** %s | **Created:** %s
 >
 > **Tags:** %s
-
+%s
 ---
 
 %s
-`, e.Title, e.ID, ts, tags, strings.TrimSpace(e.Body))
+`, e.Title, e.ID, ts, tags, fields.String(), strings.TrimSpace(renderLinks(e.Body)))
 
 	r, err := glamour.NewTermRenderer(
 		glamour.WithStandardStyle("dracula"),
@@ -10,8 +10,8 @@ import (
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
-// TSV columns: id, title, namespace, created_unix_ms, tags
-var headerLine = "id\ttitle\tnamespace\tcreated_unix_ms\ttags\n"
+// TSV columns: id, title, namespace, created_unix_ms, tags, fields
+var headerLine = "id\ttitle\tnamespace\tcreated_unix_ms\ttags\tfields\n"
 
 func esc(field string) string {
 	field = strings.ReplaceAll(field, "\t", "\\t")
@@ -34,6 +34,19 @@ func joinTags(tags []string) string {
 	return b.String()
 }
 
+// joinFields renders custom fields as name=value pairs by name, joined
+// with commas.
+func joinFields(fields map[string]any) string {
+	var b strings.Builder
+	for i, k := range api.FieldNames(fields) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k + "=" + api.FormatField(fields[k]))
+	}
+	return b.String()
+}
+
 func WritePlainEntries(w io.Writer, entries []api.Entry, headers bool) error {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	if headers {
@@ -45,8 +58,8 @@ func WritePlainEntries(w io.Writer, entries []api.Entry, headers bool) error {
 			createdMs = time.Time{}
 		}
 		ms := createdMs.UnixNano() / int64(time.Millisecond)
-		line := fmt.Sprintf("%s\t%s\t%s\t%d\t%s\n",
-			esc(e.ID), esc(e.Title), esc(e.Namespace), ms, esc(joinTags(e.Tags)))
+		line := fmt.Sprintf("%s\t%s\t%s\t%d\t%s\t%s\n",
+			esc(e.ID), esc(e.Title), esc(e.Namespace), ms, esc(joinTags(e.Tags)), esc(joinFields(e.Fields)))
 		_, _ = io.WriteString(tw, line)
 	}
 	return tw.Flush()
@@ -58,8 +71,8 @@ func WritePlainEntry(w io.Writer, e api.Entry, headers bool) error {
 		_, _ = io.WriteString(tw, headerLine)
 	}
 	ms := e.CreatedAt.UnixNano() / int64(time.Millisecond)
-	line := fmt.Sprintf("%s\t%s\t%s\t%d\t%s\n",
-		esc(e.ID), esc(e.Title), esc(e.Namespace), ms, esc(joinTags(e.Tags)))
+	line := fmt.Sprintf("%s\t%s\t%s\t%d\t%s\t%s\n",
+		esc(e.ID), esc(e.Title), esc(e.Namespace), ms, esc(joinTags(e.Tags)), esc(joinFields(e.Fields)))
 	_, _ = io.WriteString(tw, line)
 	return tw.Flush()
 }
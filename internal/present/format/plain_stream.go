@@ -36,7 +36,7 @@ func (pw *PlainStreamWriter) WriteEntries(entries []api.Entry) error {
 			createdMs = time.Time{}
 		}
 		ms := createdMs.UnixNano() / int64(time.Millisecond)
-		line := esc(e.ID) + "\t" + esc(e.Title) + "\t" + esc(e.Namespace) + "\t" + strconv.FormatInt(ms, 10) + "\t" + esc(joinTags(e.Tags)) + "\n"
+		line := esc(e.ID) + "\t" + esc(e.Title) + "\t" + esc(e.Namespace) + "\t" + strconv.FormatInt(ms, 10) + "\t" + esc(joinTags(e.Tags)) + "\t" + esc(joinFields(e.Fields)) + "\n"
 		_, _ = io.WriteString(pw.tw, line)
 	}
 	return pw.tw.Flush()
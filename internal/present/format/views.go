@@ -0,0 +1,33 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// ViewSummary renders a saved view's filters as flag-like text, e.g.
+// `tags-all=work,meeting since=7d`.
+func ViewSummary(v api.View) string {
+	var parts []string
+	if v.Query != "" {
+		parts = append(parts, "query="+strconv.Quote(v.Query))
+	}
+	if len(v.TagsAny) > 0 {
+		parts = append(parts, "tags-any="+strings.Join(v.TagsAny, ","))
+	}
+	if len(v.TagsAll) > 0 {
+		parts = append(parts, "tags-all="+strings.Join(v.TagsAll, ","))
+	}
+	if v.Since != "" {
+		parts = append(parts, "since="+v.Since)
+	}
+	if v.Until != "" {
+		parts = append(parts, "until="+v.Until)
+	}
+	if len(parts) == 0 {
+		return "(all notes)"
+	}
+	return strings.Join(parts, " ")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mithrel/ginkgo/internal/present/format"
 	"github.com/mithrel/ginkgo/internal/present/tui"
+	"github.com/mithrel/ginkgo/internal/templates"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
@@ -31,8 +32,14 @@ type Options struct {
 	FilterTagsAll   string
 	FilterSince     string
 	FilterUntil     string
+	FilterQuery     string
 	Namespace       string
 	TUIBufferRatio  float64
+	// EditorFormat is the editor file format of the TUI's edits
+	// (editor.FormatHeaders or editor.FormatFrontmatter).
+	EditorFormat string
+	// Templates loads the templates the TUI offers for new notes.
+	Templates func() ([]templates.Template, error)
 }
 
 // ParseMode parses a string like "plain", "pretty", "json", "ndjson", "tui".
@@ -67,7 +74,7 @@ func RenderEntries(ctx context.Context, w io.Writer, entries []api.Entry, opts O
 		return format.WritePlainEntries(w, entries, opts.Headers)
 	case ModeTUI:
 		// Pass headers flag through so the TUI can optionally hide column headers.
-		return tui.RenderTable(ctx, entries, opts.Headers, opts.InitialStatus, opts.InitialDuration, opts.FilterTagsAny, opts.FilterTagsAll, opts.FilterSince, opts.FilterUntil, opts.Namespace, opts.TUIBufferRatio)
+		return tui.RenderTable(ctx, entries, opts.Headers, opts.InitialStatus, opts.InitialDuration, opts.FilterTagsAny, opts.FilterTagsAll, opts.FilterSince, opts.FilterUntil, opts.FilterQuery, opts.Namespace, opts.EditorFormat, opts.TUIBufferRatio, opts.Templates)
 	default:
 		return format.WritePlainEntries(w, entries, opts.Headers)
 	}
@@ -7,18 +7,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/mithrel/ginkgo/internal/editor"
 	"github.com/mithrel/ginkgo/internal/ipc"
+	"github.com/mithrel/ginkgo/internal/present/format"
+	"github.com/mithrel/ginkgo/internal/templates"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
 // showNoteResultMsg conveys the result of fetching a full note.
 type showNoteResultMsg struct {
-	entry *api.Entry
-	err   error
-	dur   time.Duration
+	entry     *api.Entry
+	backlinks []api.Entry
+	err       error
+	dur       time.Duration
 }
 
 // manualSyncResultMsg conveys the result of a manual sync trigger.
@@ -27,6 +31,16 @@ type manualSyncResultMsg struct {
 	dur time.Duration
 }
 
+// syncStatusMsg carries a refreshed sync summary for the footer. Scheduled
+// messages re-arm the periodic refresh; one-off refreshes do not.
+type syncStatusMsg struct {
+	line      string
+	scheduled bool
+}
+
+// syncStatusInterval is how often the footer sync line is refreshed.
+const syncStatusInterval = 30 * time.Second
+
 // deleteResultMsg conveys the outcome of a delete operation back to Update.
 type deleteResultMsg struct {
 	idx int
@@ -36,10 +50,12 @@ type deleteResultMsg struct {
 }
 
 // editResultMsg conveys the outcome of an edit operation back to Update.
+// created is set when the edit added a new note from a template.
 type editResultMsg struct {
 	idx     int
 	id      string
 	updated *api.Entry
+	created bool
 	err     error
 	dur     time.Duration
 }
@@ -47,6 +63,8 @@ type editResultMsg struct {
 // windowResultMsg conveys the outcome of loading a centered window.
 type windowResultMsg struct {
 	entries      []api.Entry
+	snippets     map[string]api.Snippet
+	corrected    string
 	anchorID     string
 	anchorIdx    int
 	canFetchPrev bool
@@ -56,6 +74,32 @@ type windowResultMsg struct {
 	dur          time.Duration
 }
 
+// viewsResultMsg carries the saved views for the picker.
+type viewsResultMsg struct {
+	views []api.View
+	err   error
+}
+
+// remindersResultMsg carries the upcoming reminders for the reminders list.
+type remindersResultMsg struct {
+	reminders []api.Reminder
+	err       error
+}
+
+// templatesResultMsg carries the templates for the template picker.
+type templatesResultMsg struct {
+	templates []templates.Template
+	err       error
+}
+
+// relatedResultMsg carries notes similar to the entry with id.
+type relatedResultMsg struct {
+	id   string
+	hits []api.SearchHit
+	err  error
+	dur  time.Duration
+}
+
 // editPrepMsg signals that the editor should be launched.
 type editPrepMsg struct {
 	ctx        context.Context
@@ -68,11 +112,18 @@ type editPrepMsg struct {
 	shellCmd   string
 	curID      string
 	curVersion int64
+	curCreated time.Time
 	sock       string
 	start      time.Time
+	// namespace is the note's; a new note (curID empty) is saved to it via
+	// note.add.
+	namespace string
+	// format is the editor file format of initial.
+	format string
 }
 
-// showNoteCmd fetches the full note via IPC and returns a result message.
+// showNoteCmd fetches the full note and its backlinks via IPC and returns a
+// result message.
 func showNoteCmd(ctx context.Context, id, namespace string) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
@@ -92,7 +143,79 @@ func showNoteCmd(ctx context.Context, id, namespace string) tea.Cmd {
 			return showNoteResultMsg{err: fmt.Errorf("not found"), dur: dur}
 		}
 		e := *resp.Entry
-		return showNoteResultMsg{entry: &e, dur: dur}
+		// Backlinks are optional; the note shows without them on failure.
+		var backlinks []api.Entry
+		if bl, err := ipc.Request(ctx, sock, ipc.Message{Name: "note.backlinks", ID: id, Namespace: namespace}); err == nil && bl.OK {
+			backlinks = bl.Entries
+		}
+		return showNoteResultMsg{entry: &e, backlinks: backlinks, dur: time.Since(start)}
+	}
+}
+
+// relatedCmd fetches notes similar to id via IPC.
+func relatedCmd(ctx context.Context, id, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		sock, err := ipc.SocketPath()
+		if err != nil {
+			return relatedResultMsg{id: id, err: err}
+		}
+		resp, err := ipc.Request(ctx, sock, ipc.Message{Name: "note.related", ID: id, Namespace: namespace, Limit: 20})
+		dur := time.Since(start)
+		if err != nil {
+			return relatedResultMsg{id: id, err: err, dur: dur}
+		}
+		if !resp.OK {
+			return relatedResultMsg{id: id, err: fmt.Errorf("%s", resp.Msg), dur: dur}
+		}
+		return relatedResultMsg{id: id, hits: resp.Hits, dur: dur}
+	}
+}
+
+// listViewsCmd fetches the namespace's saved views via IPC.
+func listViewsCmd(ctx context.Context, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		sock, err := ipc.SocketPath()
+		if err != nil {
+			return viewsResultMsg{err: err}
+		}
+		resp, err := ipc.Request(ctx, sock, ipc.Message{Name: "view.list", Namespace: namespace})
+		if err != nil {
+			return viewsResultMsg{err: err}
+		}
+		if !resp.OK {
+			return viewsResultMsg{err: fmt.Errorf("%s", resp.Msg)}
+		}
+		return viewsResultMsg{views: resp.Views}
+	}
+}
+
+// listTemplatesCmd loads the templates for the template picker.
+func listTemplatesCmd(load func() ([]templates.Template, error)) tea.Cmd {
+	return func() tea.Msg {
+		if load == nil {
+			return templatesResultMsg{}
+		}
+		ts, err := load()
+		return templatesResultMsg{templates: ts, err: err}
+	}
+}
+
+// listRemindersCmd fetches the namespace's pending reminders via IPC.
+func listRemindersCmd(ctx context.Context, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		sock, err := ipc.SocketPath()
+		if err != nil {
+			return remindersResultMsg{err: err}
+		}
+		resp, err := ipc.Request(ctx, sock, ipc.Message{Name: "note.reminders", Namespace: namespace})
+		if err != nil {
+			return remindersResultMsg{err: err}
+		}
+		if !resp.OK {
+			return remindersResultMsg{err: fmt.Errorf("%s", resp.Msg)}
+		}
+		return remindersResultMsg{reminders: resp.Reminders}
 	}
 }
 
@@ -114,7 +237,31 @@ func manualSyncCmd(ctx context.Context) tea.Cmd {
 	}
 }
 
-func windowCmd(ctx context.Context, namespace string, tagsAny, tagsAll []string, since, until string, anchor api.Entry, wantBefore, wantAfter int, status string) tea.Cmd {
+// syncStatusCmd fetches per-remote sync state after delay and summarizes it.
+// Errors (e.g. daemon unavailable) clear the line rather than surfacing.
+func syncStatusCmd(ctx context.Context, delay time.Duration) tea.Cmd {
+	fetch := func(scheduled bool) tea.Msg {
+		sock, err := ipc.SocketPath()
+		if err != nil {
+			return syncStatusMsg{scheduled: scheduled}
+		}
+		resp, err := ipc.Request(ctx, sock, ipc.Message{Name: "sync.status"})
+		if err != nil || !resp.OK {
+			return syncStatusMsg{scheduled: scheduled}
+		}
+		return syncStatusMsg{line: syncSummary(resp.SyncStatus, time.Now()), scheduled: scheduled}
+	}
+	if delay <= 0 {
+		return func() tea.Msg { return fetch(false) }
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return fetch(true) })
+}
+
+// windowCmd loads up to wantBefore/wantAfter entries around anchor. With a
+// query, the window comes from note.search.fts so matches carry snippets; a
+// query matching nothing is retried with misspelled terms corrected, and the
+// corrected query is returned for the model to adopt.
+func windowCmd(ctx context.Context, namespace string, tagsAny, tagsAll []string, since, until, query string, anchor api.Entry, wantBefore, wantAfter int, status string) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
 		if wantBefore < 0 {
@@ -130,9 +277,10 @@ func windowCmd(ctx context.Context, namespace string, tagsAny, tagsAll []string,
 		if err != nil {
 			return windowResultMsg{err: err, dur: time.Since(start)}
 		}
-		limit := wantBefore + wantAfter + 1
-		if anchor.ID == "" {
-			resp, err := ipc.Request(ctx, sock, ipc.Message{
+		snippets := map[string]api.Snippet{}
+		search := "note.search.fts"
+		fetch := func(limit int, cursor string, reverse bool) (ipc.Response, error) {
+			msg := ipc.Message{
 				Name:      "note.list",
 				Namespace: namespace,
 				TagsAny:   tagsAny,
@@ -140,15 +288,45 @@ func windowCmd(ctx context.Context, namespace string, tagsAny, tagsAll []string,
 				Since:     since,
 				Until:     until,
 				Limit:     limit,
-			})
+				Cursor:    cursor,
+				Reverse:   reverse,
+			}
+			if strings.TrimSpace(query) != "" {
+				msg.Name = search
+				msg.Title = query
+			}
+			resp, err := ipc.Request(ctx, sock, msg)
 			if err != nil {
-				return windowResultMsg{err: err, dur: time.Since(start)}
+				return resp, err
 			}
 			if !resp.OK {
-				return windowResultMsg{err: fmt.Errorf("list failed: %s", resp.Msg), dur: time.Since(start)}
+				return resp, fmt.Errorf("list failed: %s", resp.Msg)
+			}
+			for _, h := range resp.Hits {
+				if sn, ok := format.BestSnippet(h); ok {
+					snippets[h.Entry.ID] = sn
+				}
+			}
+			return resp, nil
+		}
+		limit := wantBefore + wantAfter + 1
+		if anchor.ID == "" {
+			resp, err := fetch(limit, "", false)
+			if err != nil {
+				return windowResultMsg{err: err, dur: time.Since(start)}
+			}
+			corrected := ""
+			if len(resp.Entries) == 0 && strings.TrimSpace(query) != "" {
+				search = "note.search.fuzzy"
+				if fz, err := fetch(limit, "", false); err == nil && fz.Corrected != "" {
+					resp, corrected = fz, fz.Corrected
+					status = fmt.Sprintf("No matches; did you mean: %s (showing results)", corrected)
+				}
 			}
 			return windowResultMsg{
 				entries:      resp.Entries,
+				snippets:     snippets,
+				corrected:    corrected,
 				anchorID:     "",
 				anchorIdx:    0,
 				canFetchPrev: false,
@@ -158,46 +336,21 @@ func windowCmd(ctx context.Context, namespace string, tagsAny, tagsAll []string,
 			}
 		}
 		cursor := encodeCursor(anchor)
-		newer, err := ipc.Request(ctx, sock, ipc.Message{
-			Name:      "note.list",
-			Namespace: namespace,
-			TagsAny:   tagsAny,
-			TagsAll:   tagsAll,
-			Since:     since,
-			Until:     until,
-			Limit:     wantBefore,
-			Cursor:    cursor,
-			Reverse:   true,
-		})
+		newer, err := fetch(wantBefore, cursor, true)
 		if err != nil {
 			return windowResultMsg{err: err, dur: time.Since(start)}
 		}
-		if !newer.OK {
-			return windowResultMsg{err: fmt.Errorf("list failed: %s", newer.Msg), dur: time.Since(start)}
-		}
-		older, err := ipc.Request(ctx, sock, ipc.Message{
-			Name:      "note.list",
-			Namespace: namespace,
-			TagsAny:   tagsAny,
-			TagsAll:   tagsAll,
-			Since:     since,
-			Until:     until,
-			Limit:     wantAfter,
-			Cursor:    cursor,
-			Reverse:   false,
-		})
+		older, err := fetch(wantAfter, cursor, false)
 		if err != nil {
 			return windowResultMsg{err: err, dur: time.Since(start)}
 		}
-		if !older.OK {
-			return windowResultMsg{err: fmt.Errorf("list failed: %s", older.Msg), dur: time.Since(start)}
-		}
 		entries := make([]api.Entry, 0, len(newer.Entries)+1+len(older.Entries))
 		entries = append(entries, newer.Entries...)
 		entries = append(entries, anchor)
 		entries = append(entries, older.Entries...)
 		return windowResultMsg{
 			entries:      entries,
+			snippets:     snippets,
 			anchorID:     anchor.ID,
 			anchorIdx:    len(newer.Entries),
 			canFetchPrev: newer.Page.Prev != "",
@@ -231,7 +384,7 @@ func deleteCmd(ctx context.Context, id string, idx int) tea.Cmd {
 }
 
 // editCmd opens the editor suspended and saves changes via IPC.
-func editCmd(ctx context.Context, id, namespace string, idx int) tea.Cmd {
+func editCmd(ctx context.Context, id, namespace, format string, idx int) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
 		// Resolve socket
@@ -255,46 +408,78 @@ func editCmd(ctx context.Context, id, namespace string, idx int) tea.Cmd {
 		if err != nil {
 			return editResultMsg{idx: idx, id: id, err: err, dur: time.Since(start)}
 		}
-		initial := []byte(editor.ComposeContent(cur.Title, cur.Tags, cur.Body))
+		initial := []byte(editor.Compose(format, editor.Note{Title: cur.Title, Tags: cur.Tags, Namespace: namespace, CreatedAt: cur.CreatedAt, Fields: cur.Fields, Body: cur.Body}))
 		if err := editor.PrepareAt(path, initial); err != nil {
 			return editResultMsg{idx: idx, id: id, err: err, dur: time.Since(start)}
 		}
-		// Determine how to launch the editor. If VISUAL/EDITOR is set, use a shell
-		// so flags like "--wait" are honored. Otherwise, fallback to preferred editor.
-		vis := os.Getenv("VISUAL")
-		if vis == "" {
-			vis = os.Getenv("EDITOR")
-		}
-		if strings.TrimSpace(vis) != "" {
-			return editPrepMsg{
-				ctx:        ctx,
-				idx:        idx,
-				id:         id,
-				path:       path,
-				initial:    initial,
-				useShell:   true,
-				shellCmd:   vis,
-				curID:      cur.ID,
-				curVersion: cur.Version,
-				sock:       sock,
-				start:      start,
-			}
-		}
-		ed, err := editor.PreferredEditor()
-		if err != nil {
-			return editResultMsg{idx: idx, id: id, err: err, dur: time.Since(start)}
-		}
-		return editPrepMsg{
+		return withEditor(editPrepMsg{
 			ctx:        ctx,
 			idx:        idx,
 			id:         id,
 			path:       path,
 			initial:    initial,
-			editorPath: ed,
 			curID:      cur.ID,
 			curVersion: cur.Version,
+			curCreated: cur.CreatedAt,
 			sock:       sock,
 			start:      start,
+			namespace:  namespace,
+			format:     format,
+		})
+	}
+}
+
+// templateNoteCmd renders t and opens the editor on it for a new note in
+// namespace. Prompts render empty; they are answered in the editor.
+func templateNoteCmd(ctx context.Context, t templates.Template, namespace, format string) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		host, _ := os.Hostname()
+		n, err := templates.Render(t, templates.Vars{Name: t.Name, Namespace: namespace, Hostname: host, Now: start, Clipboard: clipboard.ReadAll}, nil)
+		if err != nil {
+			return editResultMsg{idx: -1, err: err, dur: time.Since(start)}
 		}
+		sock, err := ipc.SocketPath()
+		if err != nil {
+			return editResultMsg{idx: -1, err: err, dur: time.Since(start)}
+		}
+		path, err := editor.PathForID(api.NewID(), namespace)
+		if err != nil {
+			return editResultMsg{idx: -1, err: err, dur: time.Since(start)}
+		}
+		initial := []byte(editor.Compose(format, editor.Note{Title: n.Title, Tags: n.Tags, Namespace: namespace, Fields: n.Fields, Body: n.Body}))
+		if err := editor.PrepareAt(path, initial); err != nil {
+			return editResultMsg{idx: -1, err: err, dur: time.Since(start)}
+		}
+		return withEditor(editPrepMsg{
+			ctx:       ctx,
+			idx:       -1,
+			path:      path,
+			initial:   initial,
+			sock:      sock,
+			start:     start,
+			namespace: namespace,
+			format:    format,
+		})
+	}
+}
+
+// withEditor decides how to launch the editor for p. If VISUAL/EDITOR is
+// set, use a shell so flags like "--wait" are honored. Otherwise, fallback
+// to preferred editor.
+func withEditor(p editPrepMsg) tea.Msg {
+	vis := os.Getenv("VISUAL")
+	if vis == "" {
+		vis = os.Getenv("EDITOR")
+	}
+	if strings.TrimSpace(vis) != "" {
+		p.useShell, p.shellCmd = true, vis
+		return p
+	}
+	ed, err := editor.PreferredEditor()
+	if err != nil {
+		return editResultMsg{idx: p.idx, id: p.id, err: err, dur: time.Since(p.start)}
 	}
+	p.editorPath = ed
+	return p
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
@@ -19,6 +20,21 @@ func joinTags(tags []string) string {
 	return s
 }
 
+// tagsCell is the Tags column of e: its tags, then its custom fields as
+// name=value.
+func tagsCell(e api.Entry) string {
+	s := joinTags(e.Tags)
+	for i, k := range api.FieldNames(e.Fields) {
+		if i == 0 && s != "" {
+			s += " · "
+		} else if i > 0 {
+			s += " "
+		}
+		s += k + "=" + api.FormatField(e.Fields[k])
+	}
+	return s
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -57,3 +73,45 @@ func indexOfEntryID(entries []api.Entry, id string) int {
 	}
 	return -1
 }
+
+// syncSummary condenses per-remote sync state into a short footer line.
+func syncSummary(sts []ipc.SyncStatus, now time.Time) string {
+	if len(sts) == 0 {
+		return ""
+	}
+	var last time.Time
+	var pending int64
+	for _, st := range sts {
+		pending += st.Pending
+		if st.Failures > 0 {
+			line := fmt.Sprintf("sync: %s failing", st.Name)
+			if st.NextRun.After(now) {
+				line += ", retry in " + shortDuration(st.NextRun.Sub(now))
+			}
+			return line
+		}
+		if st.LastSuccess.After(last) {
+			last = st.LastSuccess
+		}
+	}
+	line := "sync: pending"
+	if !last.IsZero() {
+		line = "sync: ok " + shortDuration(now.Sub(last)) + " ago"
+	}
+	if pending > 0 {
+		line += fmt.Sprintf(", %d queued", pending)
+	}
+	return line
+}
+
+// shortDuration renders d with a single unit (e.g. 45s, 3m, 2h).
+func shortDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+}
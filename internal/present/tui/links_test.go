@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func TestNoteModalShowsBacklinks(t *testing.T) {
+	m := model{entries: makeEntries(2)}
+	m.initTable()
+	m.modal = newNoteModal(m.entries[0], 100, 40)
+	m.showModal = true
+
+	e := m.entries[0]
+	next, _ := m.Update(showNoteResultMsg{entry: &e, backlinks: []api.Entry{{ID: "src-1", Title: "Standup notes"}}})
+	m = next.(model)
+	require.Contains(t, m.modal.content, "Backlinks (1)")
+	require.Contains(t, m.modal.content, "Standup notes")
+
+	next, _ = m.Update(showNoteResultMsg{entry: &e})
+	m = next.(model)
+	require.NotContains(t, m.modal.content, "Backlinks")
+}
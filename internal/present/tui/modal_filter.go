@@ -12,6 +12,7 @@ import (
 
 // filterModal is a foreground modal with inputs to filter the list view.
 type filterModal struct {
+	query     textinput.Model
 	tagsAny   textinput.Model
 	tagsAll   textinput.Model
 	since     textinput.Model
@@ -25,12 +26,13 @@ type filterModal struct {
 	focus     int
 }
 
-func newFilterModal(tagsAny, tagsAll, since, until, namespace string, termW, termH int) *filterModal {
+func newFilterModal(q, tagsAny, tagsAll, since, until, namespace string, termW, termH int) *filterModal {
 	m := &filterModal{
 		padX:      2,
 		padY:      1,
 		namespace: namespace,
 	}
+	m.query = newFilterInput("query: ", `title:standup -tag:draft "phrase" OR deploy*`, q)
 	m.tagsAny = newFilterInput("tags-any: ", "work,ginkgo", tagsAny)
 	m.tagsAll = newFilterInput("tags-all: ", "work,ginkgo", tagsAll)
 	m.since = newFilterInput("since: ", "2h | 2025-10-26T14:30", since)
@@ -85,6 +87,7 @@ func (m *filterModal) resizeForTerm(termW, termH int) {
 	if innerW < minW {
 		innerW = minW
 	}
+	m.query.Width = max(minW, innerW-lipgloss.Width(m.query.Prompt))
 	m.tagsAny.Width = max(minW, innerW-lipgloss.Width(m.tagsAny.Prompt))
 	m.tagsAll.Width = max(minW, innerW-lipgloss.Width(m.tagsAll.Prompt))
 	m.since.Width = max(minW, innerW-lipgloss.Width(m.since.Prompt))
@@ -93,7 +96,7 @@ func (m *filterModal) resizeForTerm(termW, termH int) {
 
 func (m *filterModal) setFocus(idx int) {
 	m.focus = idx
-	inputs := []*textinput.Model{&m.tagsAny, &m.tagsAll, &m.since, &m.until}
+	inputs := []*textinput.Model{&m.query, &m.tagsAny, &m.tagsAll, &m.since, &m.until}
 	for i, in := range inputs {
 		if i == idx {
 			in.Focus()
@@ -103,8 +106,8 @@ func (m *filterModal) setFocus(idx int) {
 	}
 }
 
-func (m *filterModal) values() (string, string, string, string) {
-	return m.tagsAny.Value(), m.tagsAll.Value(), m.since.Value(), m.until.Value()
+func (m *filterModal) values() (string, string, string, string, string) {
+	return m.query.Value(), m.tagsAny.Value(), m.tagsAll.Value(), m.since.Value(), m.until.Value()
 }
 
 func (m *filterModal) update(msg tea.Msg) (*filterModal, tea.Cmd) {
@@ -115,22 +118,24 @@ func (m *filterModal) update(msg tea.Msg) (*filterModal, tea.Cmd) {
 	case tea.KeyMsg:
 		switch x.String() {
 		case "tab", "down":
-			m.setFocus((m.focus + 1) % 4)
+			m.setFocus((m.focus + 1) % 5)
 			return m, nil
 		case "shift+tab", "up":
-			m.setFocus((m.focus + 3) % 4)
+			m.setFocus((m.focus + 4) % 5)
 			return m, nil
 		}
 	}
 	var cmd tea.Cmd
 	switch m.focus {
 	case 0:
-		m.tagsAny, cmd = m.tagsAny.Update(msg)
+		m.query, cmd = m.query.Update(msg)
 	case 1:
-		m.tagsAll, cmd = m.tagsAll.Update(msg)
+		m.tagsAny, cmd = m.tagsAny.Update(msg)
 	case 2:
-		m.since, cmd = m.since.Update(msg)
+		m.tagsAll, cmd = m.tagsAll.Update(msg)
 	case 3:
+		m.since, cmd = m.since.Update(msg)
+	case 4:
 		m.until, cmd = m.until.Update(msg)
 	}
 	return m, cmd
@@ -147,6 +152,7 @@ func (m *filterModal) View() string {
 	body := strings.Join([]string{
 		header,
 		"",
+		m.query.View(),
 		m.tagsAny.View(),
 		m.tagsAll.View(),
 		m.since.View(),
@@ -2,9 +2,11 @@ package tui
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	lipglossv2 "github.com/charmbracelet/lipgloss/v2"
 
 	"github.com/mithrel/ginkgo/internal/present/format"
@@ -76,12 +78,21 @@ func (m *noteModal) resizeForTerm(termW, termH int) {
 	m.vp.SetContent(m.content)
 }
 
-// setEntry renders the entry using the shared pretty renderer and sets it.
-func (m *noteModal) setEntry(e api.Entry) {
+// setEntry renders the entry using the shared pretty renderer, followed by
+// the notes linking to it, and sets it.
+func (m *noteModal) setEntry(e api.Entry, backlinks []api.Entry) {
 	m.e = e
 	var buf bytes.Buffer
 
 	_ = format.WritePrettyEntry(&buf, e)
+	if len(backlinks) > 0 {
+		head := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+		dim := lipgloss.NewStyle().Faint(true)
+		_, _ = fmt.Fprintf(&buf, "\n  %s\n", head.Render(fmt.Sprintf("Backlinks (%d)", len(backlinks))))
+		for _, b := range backlinks {
+			_, _ = fmt.Fprintf(&buf, "  ← %s %s\n", b.Title, dim.Render(b.ID))
+		}
+	}
 	m.setContent(buf.String())
 }
 
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	lipglossv2 "github.com/charmbracelet/lipgloss/v2"
+)
+
+// pickerSize sizes a picker box from the terminal: width and height are the
+// given fraction of it, clamped to [min, max]. Narrow terminals get nearly
+// the full width.
+type pickerSize struct {
+	minW, maxW int
+	fracW      float64
+	maxH       int
+	fracH      float64
+}
+
+// picker is a foreground modal listing items with a movable cursor. The
+// modals built on it set title, empty (shown when there are no items), help
+// and render, which draws one item; selected items are drawn without faint
+// parts since the highlight restyles the whole line.
+type picker[T any] struct {
+	items  []T
+	cursor int
+	offset int
+	width  int
+	height int
+	box    lipglossv2.Style
+	size   pickerSize
+
+	title  string
+	empty  string
+	help   string
+	render func(item T, selected bool) string
+}
+
+func (m *picker[T]) resizeForTerm(termW, termH int) {
+	if termW <= 0 || termH <= 0 {
+		termW, termH = 80, 24
+	}
+	w := min(m.size.maxW, max(m.size.minW, int(float64(termW)*m.size.fracW)))
+	if termW < 80 {
+		w = max(30, termW-4)
+	}
+	h := min(m.size.maxH, max(8, int(float64(termH)*m.size.fracH)))
+	m.width, m.height = w, h
+	m.box = lipglossv2.NewStyle().
+		Width(w).
+		Height(h).
+		Padding(1, 2).
+		Border(lipglossv2.RoundedBorder()).
+		BorderForeground(lipglossv2.Color("63"))
+	m.clampOffset()
+}
+
+// rows is the number of items visible at once: the box minus borders,
+// padding, header and help lines.
+func (m *picker[T]) rows() int {
+	return max(1, m.height-2-2-4)
+}
+
+func (m *picker[T]) clampOffset() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.rows() {
+		m.offset = m.cursor - m.rows() + 1
+	}
+}
+
+// setItems replaces the list and moves the cursor back to the top.
+func (m *picker[T]) setItems(items []T) {
+	m.items, m.cursor, m.offset = items, 0, 0
+}
+
+// selected returns the highlighted item, if any.
+func (m *picker[T]) selected() (T, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.items) {
+		var zero T
+		return zero, false
+	}
+	return m.items[m.cursor], true
+}
+
+func (m *picker[T]) update(msg tea.Msg) (*picker[T], tea.Cmd) {
+	switch x := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resizeForTerm(x.Width, x.Height)
+	case tea.KeyMsg:
+		switch x.String() {
+		case "up", "k", "shift+tab":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j", "tab":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		}
+		m.clampOffset()
+	}
+	return m, nil
+}
+
+func (m *picker[T]) View() string {
+	innerW := m.width - 2 - 4
+	faint := lipgloss.NewStyle().Faint(true)
+	lines := []string{lipgloss.NewStyle().Bold(true).MaxWidth(innerW).Render(m.title), ""}
+	if len(m.items) == 0 {
+		lines = append(lines, faint.Render(m.empty))
+	}
+	sel := lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	end := min(len(m.items), m.offset+m.rows())
+	for i := m.offset; i < end; i++ {
+		line := lipgloss.NewStyle().MaxWidth(innerW).Render(m.render(m.items[i], i == m.cursor))
+		if i == m.cursor {
+			line = sel.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", faint.Render(m.help))
+	return m.box.Render(strings.Join(lines, "\n"))
+}
+
+func (m *picker[T]) Init() tea.Cmd                           { return nil }
+func (m *picker[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return m.update(msg) }
+
+// namespaceTitle is title, naming namespace when there is one.
+func namespaceTitle(title, namespace string) string {
+	if strings.TrimSpace(namespace) == "" {
+		return title
+	}
+	return title + " (namespace: " + namespace + ")"
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// relatedModal is a foreground pane listing notes similar to one entry.
+type relatedModal struct {
+	picker[api.SearchHit]
+	source api.Entry
+}
+
+func newRelatedModal(source api.Entry, termW, termH int) *relatedModal {
+	m := &relatedModal{source: source, picker: picker[api.SearchHit]{
+		size:  pickerSize{minW: 42, maxW: 100, fracW: 0.7, maxH: 24, fracH: 0.6},
+		title: "Related to: " + source.Title,
+		empty: "Loading…",
+		help:  "enter=inspect • esc/r=close • ↑/↓=move",
+		render: func(h api.SearchHit, _ bool) string {
+			return strings.TrimRight(fmt.Sprintf("%3.0f%%  %s  %s", h.Score*100, h.Entry.Title, joinTags(h.Entry.Tags)), " ")
+		},
+	}}
+	m.resizeForTerm(termW, termH)
+	return m
+}
+
+func (m *relatedModal) setHits(hits []api.SearchHit) {
+	m.setItems(hits)
+	m.empty = "No related notes."
+}
+
+// selected returns the highlighted note, if any.
+func (m *relatedModal) selected() (api.Entry, bool) {
+	h, ok := m.picker.selected()
+	return h.Entry, ok
+}
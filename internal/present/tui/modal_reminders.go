@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// remindersModal lists upcoming reminders; enter opens the note.
+type remindersModal struct {
+	picker[api.Reminder]
+}
+
+func newRemindersModal(reminders []api.Reminder, namespace string, termW, termH int) *remindersModal {
+	m := &remindersModal{picker[api.Reminder]{
+		items: reminders,
+		size:  pickerSize{minW: 42, maxW: 90, fracW: 0.6, maxH: 22, fracH: 0.5},
+		title: namespaceTitle("Reminders", namespace),
+		empty: "No upcoming reminders. Set one with: ginkgo-cli note remind <id> <when>",
+		help:  "enter=open note • esc/ctrl+q=close • ↑/↓=move",
+		render: func(r api.Reminder, selected bool) string {
+			title := r.Title
+			if title == "" {
+				title = r.NoteID
+			}
+			device := r.Device
+			if !selected {
+				device = lipgloss.NewStyle().Faint(true).Render(device)
+			}
+			return r.At.Local().Format("2006-01-02 15:04") + "  " + title + "  " + device
+		},
+	}}
+	m.resizeForTerm(termW, termH)
+	return m
+}
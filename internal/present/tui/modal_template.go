@@ -0,0 +1,21 @@
+package tui
+
+import "github.com/mithrel/ginkgo/internal/templates"
+
+// templateModal picks a template for a new note; enter opens the editor.
+type templateModal struct {
+	picker[templates.Template]
+}
+
+func newTemplateModal(ts []templates.Template, namespace string, termW, termH int) *templateModal {
+	m := &templateModal{picker[templates.Template]{
+		items:  ts,
+		size:   pickerSize{minW: 36, maxW: 70, fracW: 0.5, maxH: 20, fracH: 0.5},
+		title:  namespaceTitle("New note from template", namespace),
+		empty:  "No templates. See: ginkgo-cli template --help",
+		help:   "enter=edit new note • esc/ctrl+q=close • ↑/↓=move",
+		render: func(t templates.Template, _ bool) string { return t.Name },
+	}}
+	m.resizeForTerm(termW, termH)
+	return m
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mithrel/ginkgo/internal/present/format"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// viewModal is a foreground picker listing saved views.
+type viewModal struct {
+	picker[api.View]
+}
+
+func newViewModal(views []api.View, current, namespace string, termW, termH int) *viewModal {
+	m := &viewModal{picker[api.View]{
+		items: views,
+		size:  pickerSize{minW: 42, maxW: 90, fracW: 0.6, maxH: 22, fracH: 0.5},
+		title: namespaceTitle("Views", namespace),
+		empty: "No saved views. Create one with: ginkgo-cli view save <name> ...",
+		help:  "enter=apply • esc/ctrl+q=cancel • ↑/↓=move",
+		render: func(v api.View, selected bool) string {
+			if selected {
+				return v.Name + "  " + format.ViewSummary(v)
+			}
+			return v.Name + "  " + lipgloss.NewStyle().Faint(true).Render(format.ViewSummary(v))
+		},
+	}}
+	for i, v := range views {
+		if strings.EqualFold(v.Name, current) {
+			m.cursor = i
+		}
+	}
+	m.resizeForTerm(termW, termH)
+	return m
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func TestRelatedPane(t *testing.T) {
+	m := model{entries: makeEntries(3)}
+	m.initTable()
+
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = next.(model)
+	require.NotNil(t, cmd)
+	require.True(t, m.showRelated)
+	src := m.relatedModal.source.ID
+
+	// Results for another note (a stale request) are ignored.
+	next, _ = m.Update(relatedResultMsg{id: "zz", hits: []api.SearchHit{{Entry: api.Entry{ID: "x"}}}})
+	m = next.(model)
+	require.Empty(t, m.relatedModal.items)
+
+	hits := []api.SearchHit{{Entry: api.Entry{ID: "b", Title: "B"}, Score: 0.8}, {Entry: api.Entry{ID: "c", Title: "C"}, Score: 0.3}}
+	next, _ = m.Update(relatedResultMsg{id: src, hits: hits})
+	m = next.(model)
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = next.(model)
+	next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	require.NotNil(t, cmd)
+	require.False(t, m.showRelated)
+	require.True(t, m.showModal)
+}
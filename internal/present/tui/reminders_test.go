@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func TestRemindersListOpensNote(t *testing.T) {
+	m := model{entries: makeEntries(3)}
+	m.initTable()
+
+	at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	reminders := []api.Reminder{
+		{NoteID: "a", Namespace: "default", At: at, Title: "call back"},
+		{NoteID: "b", Namespace: "work", At: at.Add(time.Hour), Title: "review"},
+	}
+	next, _ := m.Update(remindersResultMsg{reminders: reminders})
+	m = next.(model)
+	require.True(t, m.showReminders)
+	require.Contains(t, m.View(), "call back")
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = next.(model)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	require.NotNil(t, cmd)
+	require.False(t, m.showReminders)
+	require.True(t, m.showModal)
+	require.Equal(t, "b", m.modal.e.ID)
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/ipc"
+)
+
+func TestSyncSummary(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		sts  []ipc.SyncStatus
+		want string
+	}{
+		{"none", nil, ""},
+		{"never", []ipc.SyncStatus{{Name: "origin"}}, "sync: pending"},
+		{"ok", []ipc.SyncStatus{{Name: "origin", LastSuccess: now.Add(-3 * time.Minute), Pending: 2}}, "sync: ok 3m ago, 2 queued"},
+		{"failing", []ipc.SyncStatus{
+			{Name: "a", LastSuccess: now.Add(-time.Minute)},
+			{Name: "b", Failures: 2, NextRun: now.Add(40 * time.Second)},
+		}, "sync: b failing, retry in 40s"},
+	}
+	for _, tc := range cases {
+		if got := syncSummary(tc.sts, now); got != tc.want {
+			t.Fatalf("%s: got %q want %q", tc.name, got, tc.want)
+		}
+	}
+}
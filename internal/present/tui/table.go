@@ -19,12 +19,15 @@ import (
 	"github.com/mithrel/ginkgo/internal/editor"
 	"github.com/mithrel/ginkgo/internal/ipc"
 	"github.com/mithrel/ginkgo/internal/present/format"
+	"github.com/mithrel/ginkgo/internal/query"
+	"github.com/mithrel/ginkgo/internal/templates"
 	"github.com/mithrel/ginkgo/internal/util"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
 // RenderTable opens an interactive Bubble Tea table to browse entries.
-func RenderTable(ctx context.Context, entries []api.Entry, headers bool, initialStatus string, initialDuration time.Duration, filterTagsAny, filterTagsAll, filterSince, filterUntil, namespace string, bufferRatio float64) error {
+// loadTemplates supplies the templates offered by the "t" picker.
+func RenderTable(ctx context.Context, entries []api.Entry, headers bool, initialStatus string, initialDuration time.Duration, filterTagsAny, filterTagsAll, filterSince, filterUntil, filterQuery, namespace, editorFormat string, bufferRatio float64, loadTemplates func() ([]templates.Template, error)) error {
 	m := model{
 		ctx:          ctx,
 		entries:      entries,
@@ -37,8 +40,11 @@ func RenderTable(ctx context.Context, entries []api.Entry, headers bool, initial
 		tagsAll:      filterTagsAll,
 		since:        filterSince,
 		until:        filterUntil,
+		query:        filterQuery,
 		namespace:    namespace,
+		editorFormat: editorFormat,
 		bufferRatio:  bufferRatio,
+		loadTpl:      loadTemplates,
 	}
 	m.initTable()
 	if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 && height > 0 {
@@ -83,6 +89,16 @@ type model struct {
 	modal         *noteModal
 	showFilter    bool
 	filterModal   *filterModal
+	showViews     bool
+	showRelated   bool
+	relatedModal  *relatedModal
+	viewModal     *viewModal
+	showReminders bool
+	remindModal   *remindersModal
+	showTemplates bool
+	tplModal      *templateModal
+	loadTpl       func() ([]templates.Template, error)
+	viewName      string
 	headers       bool
 	width         int
 	height        int
@@ -94,7 +110,10 @@ type model struct {
 	tagsAll       string
 	since         string
 	until         string
+	query         string
+	snippets      map[string]api.Snippet
 	namespace     string
+	editorFormat  string
 	pageSize      int
 	bufferSize    int
 	bufferRatio   float64
@@ -103,6 +122,7 @@ type model struct {
 	canFetchNext  bool
 	loaded        bool
 	loadingWindow bool
+	syncLine      string
 }
 
 func (m *model) initTable() {
@@ -144,7 +164,7 @@ func (m *model) updateRows(prepended int) {
 		rows = append(rows, table.Row{
 			e.ID,
 			e.Title,
-			joinTags(e.Tags),
+			tagsCell(e),
 			created,
 		})
 	}
@@ -155,7 +175,9 @@ func (m *model) updateRows(prepended int) {
 	}
 }
 
-func (m model) Init() tea.Cmd { return initialWindowSizeCmd() }
+func (m model) Init() tea.Cmd {
+	return tea.Batch(initialWindowSizeCmd(), syncStatusCmd(m.ctx, 0), syncStatusCmd(m.ctx, syncStatusInterval))
+}
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	m.updateKeyStates()
@@ -172,7 +194,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		if msg.entry != nil && m.modal != nil {
-			m.modal.setEntry(*msg.entry)
+			m.modal.setEntry(*msg.entry, msg.backlinks)
 			m.status = "Loaded note"
 			m.lastDuration = msg.dur
 		}
@@ -215,7 +237,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		// If updated is nil, consider it a no-op
-		if msg.updated != nil && msg.idx >= 0 && msg.idx < len(m.entries) {
+		if msg.updated != nil && msg.created {
+			m.entries = append([]api.Entry{*msg.updated}, m.entries...)
+			m.updateRows(0)
+			m.table.SetCursor(0)
+			m.status = fmt.Sprintf("Added %s", msg.updated.ID)
+		} else if msg.updated != nil && msg.idx >= 0 && msg.idx < len(m.entries) {
 			m.entries[msg.idx] = *msg.updated
 			m.updateRows(0)
 			m.table.SetCursor(msg.idx)
@@ -253,7 +280,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				res.dur = time.Since(mp.start)
 				return res
 			}
-			if bytes.Equal(out, mp.initial) {
+			// A note from a template is saved even when left as rendered.
+			if bytes.Equal(out, mp.initial) && (mp.curID != "" || time.Since(mp.start) < 500*time.Millisecond) {
 				// Editor returned without changes. If it returned very quickly,
 				// it's likely a GUI editor without a wait flag. Keep temp file
 				// so the user doesn't lose the buffer and show a helpful hint.
@@ -266,9 +294,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				res.dur = dur
 				return res
 			}
+			n, perr := editor.Parse(mp.format, string(out))
+			if perr == nil && n.Namespace != "" && n.Namespace != mp.namespace {
+				perr = n.Errorf("namespace", "namespace: must stay %q here", mp.namespace)
+			}
+			if perr == nil {
+				_, perr = api.NormalizeFields(n.Fields)
+			}
+			if perr != nil {
+				// Keep the file so the fix is not lost.
+				res.err = fmt.Errorf("%w; file kept at %s", perr, mp.path)
+				res.dur = time.Since(mp.start)
+				return res
+			}
 			// Changes detected; remove temp after reading
 			_ = os.Remove(mp.path)
-			title, tags, body := editor.ParseEditedNote(string(out))
+			title, tags, body := n.Title, n.Tags, n.Body
 			if title == "" && strings.TrimSpace(body) == "" {
 				res.dur = time.Since(mp.start)
 				return res
@@ -276,14 +317,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if title == "" {
 				title = editor.FirstLine(body)
 			}
-			save, serr := ipc.Request(mp.ctx, mp.sock, ipc.Message{
+			req := ipc.Message{
 				Name:      "note.edit",
 				ID:        mp.curID,
 				IfVersion: mp.curVersion,
 				Title:     title,
 				Body:      body,
 				Tags:      tags,
-			})
+				CreatedAt: n.NewCreatedAt(mp.curCreated),
+				Fields:    n.Fields,
+				SetFields: n.Fields != nil,
+			}
+			if mp.curID == "" {
+				req = ipc.Message{Name: "note.add", Title: title, Body: body, Tags: tags, Namespace: mp.namespace, CreatedAt: n.CreatedAt, Fields: n.Fields}
+				res.created = true
+			}
+			save, serr := ipc.Request(mp.ctx, mp.sock, req)
 			if serr != nil {
 				res.err = serr
 				res.dur = time.Since(mp.start)
@@ -311,6 +360,72 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.lastDuration = msg.dur
 		m.updateKeyStates()
+		return m, syncStatusCmd(m.ctx, 0)
+	case relatedResultMsg:
+		if !m.showRelated || m.relatedModal == nil || m.relatedModal.source.ID != msg.id {
+			return m, nil
+		}
+		m.lastDuration = msg.dur
+		if msg.err != nil {
+			m.showRelated = false
+			m.status = fmt.Sprintf("Related failed: %v", msg.err)
+			m.updateKeyStates()
+			return m, nil
+		}
+		m.relatedModal.setHits(msg.hits)
+		m.status = fmt.Sprintf("%d related", len(msg.hits))
+		m.updateKeyStates()
+		return m, nil
+	case viewsResultMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Views failed: %v", msg.err)
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, nil
+		}
+		if m.showModal || m.showFilter {
+			return m, nil
+		}
+		m.viewModal = newViewModal(msg.views, m.viewName, m.namespace, m.width, m.height)
+		m.showViews = true
+		m.status = ""
+		m.updateKeyStates()
+		return m, nil
+	case remindersResultMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Reminders failed: %v", msg.err)
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, nil
+		}
+		if m.showModal || m.showFilter {
+			return m, nil
+		}
+		m.remindModal = newRemindersModal(msg.reminders, m.namespace, m.width, m.height)
+		m.showReminders = true
+		m.status = ""
+		m.updateKeyStates()
+		return m, nil
+	case templatesResultMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Templates failed: %v", msg.err)
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, nil
+		}
+		if m.showModal || m.showFilter {
+			return m, nil
+		}
+		m.tplModal = newTemplateModal(msg.templates, m.namespace, m.width, m.height)
+		m.showTemplates = true
+		m.status = ""
+		m.updateKeyStates()
+		return m, nil
+	case syncStatusMsg:
+		m.syncLine = msg.line
+		if msg.scheduled {
+			return m, syncStatusCmd(m.ctx, syncStatusInterval)
+		}
 		return m, nil
 	case windowResultMsg:
 		if msg.err != nil {
@@ -321,6 +436,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.entries = msg.entries
+		m.snippets = msg.snippets
+		if msg.corrected != "" {
+			m.query = msg.corrected
+		}
 		m.canFetchPrev = msg.canFetchPrev
 		m.canFetchNext = msg.canFetchNext
 		m.loaded = true
@@ -363,13 +482,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterModal, cmd = m.filterModal.update(msg)
 			_ = cmd
 		}
+		if m.showViews && m.viewModal != nil {
+			m.viewModal.update(msg)
+		}
+		if m.showRelated && m.relatedModal != nil {
+			m.relatedModal.update(msg)
+		}
+		if m.showReminders && m.remindModal != nil {
+			m.remindModal.update(msg)
+		}
+		if m.showTemplates && m.tplModal != nil {
+			m.tplModal.update(msg)
+		}
 		m.applyLayout()
 		m.updateRows(0)
 		if !m.loaded && m.viewSize > 0 {
 			m.status = "Loading..."
 			side := m.windowSide()
 			m.updateKeyStates()
-			return m, windowCmd(m.ctx, m.namespace, splitCSV(m.tagsAny), splitCSV(m.tagsAll), m.since, m.until, api.Entry{}, side, side, "Loaded")
+			return m, windowCmd(m.ctx, m.namespace, splitCSV(m.tagsAny), splitCSV(m.tagsAll), m.since, m.until, m.query, api.Entry{}, side, side, "Loaded")
 		}
 		m.updateKeyStates()
 		return m, nil
@@ -402,24 +533,37 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateKeyStates()
 				return m, nil
 			case "ctrl+x":
+				m.viewName = ""
 				m.tagsAny = ""
 				m.tagsAll = ""
 				m.since = ""
 				m.until = ""
+				m.query = ""
+				m.applyLayout()
 				m.showFilter = false
 				m.status = "Clearing filters..."
 				m.loaded = false
 				side := m.windowSide()
 				m.updateKeyStates()
-				return m, windowCmd(m.ctx, m.namespace, nil, nil, "", "", api.Entry{}, side, side, "Filters cleared")
+				return m, windowCmd(m.ctx, m.namespace, nil, nil, "", "", "", api.Entry{}, side, side, "Filters cleared")
 			case "enter":
-				tagsAny, tagsAll, since, until := m.filterModal.values()
+				q, tagsAny, tagsAll, since, until := m.filterModal.values()
 				normalizedSince, normalizedUntil, err := util.NormalizeTimeRange(since, until)
 				if err != nil {
 					m.status = fmt.Sprintf("Filter error: %v", err)
 					m.lastDuration = 0
 					return m, nil
 				}
+				if strings.TrimSpace(q) != "" {
+					if _, err := query.Parse(q); err != nil {
+						m.status = fmt.Sprintf("Filter error: %v", err)
+						m.lastDuration = 0
+						return m, nil
+					}
+				}
+				m.query = q
+				m.viewName = ""
+				m.applyLayout()
 				m.tagsAny = tagsAny
 				m.tagsAll = tagsAll
 				m.since = since
@@ -429,7 +573,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loaded = false
 				side := m.windowSide()
 				m.updateKeyStates()
-				return m, windowCmd(m.ctx, m.namespace, splitCSV(tagsAny), splitCSV(tagsAll), normalizedSince, normalizedUntil, api.Entry{}, side, side, "Filters applied")
+				return m, windowCmd(m.ctx, m.namespace, splitCSV(tagsAny), splitCSV(tagsAll), normalizedSince, normalizedUntil, q, api.Entry{}, side, side, "Filters applied")
 			default:
 				var cmd tea.Cmd
 				m.filterModal, cmd = m.filterModal.update(msg)
@@ -437,6 +581,113 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 		}
+		if m.showRelated && m.relatedModal != nil {
+			switch msg.String() {
+			case "esc", "ctrl+q", "q", "r":
+				m.showRelated = false
+				m.updateKeyStates()
+				return m, nil
+			case "enter", "i":
+				sel, ok := m.relatedModal.selected()
+				if !ok {
+					return m, nil
+				}
+				m.showRelated = false
+				m.modal = newNoteModal(sel, m.width, m.height)
+				m.showModal = true
+				m.status = "Loading note…"
+				m.lastDuration = 0
+				m.updateKeyStates()
+				return m, showNoteCmd(m.ctx, sel.ID, sel.Namespace)
+			default:
+				m.relatedModal.update(msg)
+				m.updateKeyStates()
+				return m, nil
+			}
+		}
+		if m.showReminders && m.remindModal != nil {
+			switch msg.String() {
+			case "esc", "ctrl+q", "q", "R":
+				m.showReminders = false
+				m.updateKeyStates()
+				return m, nil
+			case "enter", "i":
+				r, ok := m.remindModal.selected()
+				if !ok {
+					return m, nil
+				}
+				m.showReminders = false
+				m.modal = newNoteModal(api.Entry{ID: r.NoteID, Namespace: r.Namespace, Title: r.Title}, m.width, m.height)
+				m.showModal = true
+				m.status = "Loading note…"
+				m.lastDuration = 0
+				m.updateKeyStates()
+				return m, showNoteCmd(m.ctx, r.NoteID, r.Namespace)
+			default:
+				m.remindModal.update(msg)
+				m.updateKeyStates()
+				return m, nil
+			}
+		}
+		if m.showTemplates && m.tplModal != nil {
+			switch msg.String() {
+			case "esc", "ctrl+q", "q", "t":
+				m.showTemplates = false
+				m.updateKeyStates()
+				return m, nil
+			case "enter":
+				t, ok := m.tplModal.selected()
+				if !ok {
+					return m, nil
+				}
+				m.showTemplates = false
+				m.status = "Opening editor…"
+				m.lastDuration = 0
+				m.updateKeyStates()
+				return m, templateNoteCmd(m.ctx, t, m.namespace, m.editorFormat)
+			default:
+				m.tplModal.update(msg)
+				m.updateKeyStates()
+				return m, nil
+			}
+		}
+		if m.showViews && m.viewModal != nil {
+			switch msg.String() {
+			case "esc", "ctrl+q", "q", "v":
+				m.showViews = false
+				m.updateKeyStates()
+				return m, nil
+			case "enter":
+				v, ok := m.viewModal.selected()
+				m.showViews = false
+				if !ok {
+					m.updateKeyStates()
+					return m, nil
+				}
+				normalizedSince, normalizedUntil, err := util.NormalizeTimeRange(v.Since, v.Until)
+				if err != nil {
+					m.status = fmt.Sprintf("View error: %v", err)
+					m.lastDuration = 0
+					return m, nil
+				}
+				m.viewName = v.Name
+				m.query = v.Query
+				m.tagsAny = strings.Join(v.TagsAny, ",")
+				m.tagsAll = strings.Join(v.TagsAll, ",")
+				m.since = v.Since
+				m.until = v.Until
+				m.applyLayout()
+				m.status = fmt.Sprintf("Loading view %s...", v.Name)
+				m.loaded = false
+				side := m.windowSide()
+				m.updateKeyStates()
+				return m, windowCmd(m.ctx, m.namespace, v.TagsAny, v.TagsAll, normalizedSince, normalizedUntil, v.Query, api.Entry{}, side, side, "View "+v.Name)
+			default:
+				m.viewModal.update(msg)
+				m.updateKeyStates()
+				return m, nil
+			}
+		}
 		switch msg.String() {
 		case "q", "esc", "ctrl+c", "ctrl+q":
 			if m.showModal {
@@ -447,7 +698,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateKeyStates()
 			return m, tea.Quit
 		case "?":
-			if m.showModal || m.showFilter {
+			if m.showModal || m.showFilter || m.showViews || m.showRelated || m.showReminders || m.showTemplates {
 				m.updateKeyStates()
 				return m, nil
 			}
@@ -489,7 +740,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				sel := m.entries[idx]
 				m.status = fmt.Sprintf("Editing %s…", sel.ID)
 				m.updateKeyStates()
-				return m, editCmd(m.ctx, sel.ID, sel.Namespace, idx)
+				return m, editCmd(m.ctx, sel.ID, sel.Namespace, m.editorFormat, idx)
 			}
 			m.updateKeyStates()
 			return m, nil
@@ -502,10 +753,50 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.updateKeyStates()
 				return m, nil
 			}
-			m.filterModal = newFilterModal(m.tagsAny, m.tagsAll, m.since, m.until, m.namespace, m.width, m.height)
+			m.filterModal = newFilterModal(m.query, m.tagsAny, m.tagsAll, m.since, m.until, m.namespace, m.width, m.height)
 			m.showFilter = true
 			m.updateKeyStates()
 			return m, nil
+		case "r":
+			idx := m.table.Cursor()
+			if m.showModal || idx < 0 || idx >= len(m.entries) {
+				m.updateKeyStates()
+				return m, nil
+			}
+			sel := m.entries[idx]
+			m.relatedModal = newRelatedModal(sel, m.width, m.height)
+			m.showRelated = true
+			m.status = "Finding related notes…"
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, relatedCmd(m.ctx, sel.ID, sel.Namespace)
+		case "v":
+			if m.showModal {
+				m.updateKeyStates()
+				return m, nil
+			}
+			m.status = "Loading views..."
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, listViewsCmd(m.ctx, m.namespace)
+		case "R":
+			if m.showModal {
+				m.updateKeyStates()
+				return m, nil
+			}
+			m.status = "Loading reminders..."
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, listRemindersCmd(m.ctx, m.namespace)
+		case "t":
+			if m.showModal {
+				m.updateKeyStates()
+				return m, nil
+			}
+			m.status = "Loading templates..."
+			m.lastDuration = 0
+			m.updateKeyStates()
+			return m, listTemplatesCmd(m.loadTpl)
 		case "d":
 			idx := m.table.Cursor()
 			if idx >= 0 && idx < len(m.entries) {
@@ -530,6 +821,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m model) renderFooter() string {
 	left := " press ? for help"
+	if m.syncLine != "" {
+		left += " • " + m.syncLine
+	}
 
 	var right string
 	if m.status != "" {
@@ -550,6 +844,34 @@ func (m model) renderFooter() string {
 	return left + strings.Repeat(" ", space) + right
 }
 
+// snippetLines is the height reserved for the match snippet line, shown
+// while a search query is active.
+func (m model) snippetLines() int {
+	if strings.TrimSpace(m.query) == "" {
+		return 0
+	}
+	return 1
+}
+
+// renderSnippet shows the selected entry's best match with terms highlighted.
+func (m model) renderSnippet() string {
+	idx := m.table.Cursor()
+	if idx < 0 || idx >= len(m.entries) {
+		return ""
+	}
+	sn, ok := m.snippets[m.entries[idx].ID]
+	if !ok {
+		return lipgloss.NewStyle().Faint(true).Render(" (no text match)")
+	}
+	mark := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212"))
+	text := format.Highlight(sn, func(s string) string { return mark.Render(s) })
+	line := " " + sn.Field + ": " + strings.Join(strings.Fields(text), " ")
+	if w := m.table.Width(); w > 0 {
+		line = lipgloss.NewStyle().MaxWidth(w).Render(line)
+	}
+	return line
+}
+
 func (m model) View() string {
 	if m.showHelp {
 		helpView, w, h := m.helpModalView()
@@ -564,16 +886,44 @@ func (m model) View() string {
 		if m.showFilter && m.filterModal != nil {
 			return m.renderOverlay(base, m.filterModal.View(), m.filterModal.width, m.filterModal.height)
 		}
+		if m.showViews && m.viewModal != nil {
+			return m.renderOverlay(base, m.viewModal.View(), m.viewModal.width, m.viewModal.height)
+		}
+		if m.showRelated && m.relatedModal != nil {
+			return m.renderOverlay(base, m.relatedModal.View(), m.relatedModal.width, m.relatedModal.height)
+		}
+		if m.showReminders && m.remindModal != nil {
+			return m.renderOverlay(base, m.remindModal.View(), m.remindModal.width, m.remindModal.height)
+		}
+		if m.showTemplates && m.tplModal != nil {
+			return m.renderOverlay(base, m.tplModal.View(), m.tplModal.width, m.tplModal.height)
+		}
 		return base
 	}
 
-	base := m.table.View() + "\n" + m.renderFooter()
+	base := m.table.View() + "\n"
+	if m.snippetLines() > 0 {
+		base += m.renderSnippet() + "\n"
+	}
+	base += m.renderFooter()
 	if m.showModal && m.modal != nil {
 		return m.renderOverlay(base, m.modal.View(), m.modal.width, m.modal.height)
 	}
 	if m.showFilter && m.filterModal != nil {
 		return m.renderOverlay(base, m.filterModal.View(), m.filterModal.width, m.filterModal.height)
 	}
+	if m.showViews && m.viewModal != nil {
+		return m.renderOverlay(base, m.viewModal.View(), m.viewModal.width, m.viewModal.height)
+	}
+	if m.showRelated && m.relatedModal != nil {
+		return m.renderOverlay(base, m.relatedModal.View(), m.relatedModal.width, m.relatedModal.height)
+	}
+	if m.showReminders && m.remindModal != nil {
+		return m.renderOverlay(base, m.remindModal.View(), m.remindModal.width, m.remindModal.height)
+	}
+	if m.showTemplates && m.tplModal != nil {
+		return m.renderOverlay(base, m.tplModal.View(), m.tplModal.width, m.tplModal.height)
+	}
 	return base
 }
 
@@ -581,7 +931,7 @@ func (m *model) applyLayout() {
 	if m.width <= 0 || m.height <= 0 {
 		return
 	}
-	h := max(6, m.height-1)
+	h := max(6, m.height-1-m.snippetLines())
 	m.table.SetHeight(h)
 	m.table.SetWidth(m.width)
 	m.pageSize = max(5, m.table.Height())
@@ -655,6 +1005,7 @@ func (m *model) updateKeyStates() {
 	hasEntries := len(m.entries) > 0
 	m.keys.Show.SetEnabled(hasEntries)
 	m.keys.Edit.SetEnabled(hasEntries)
+	m.keys.Related.SetEnabled(hasEntries)
 	m.keys.Delete.SetEnabled(hasEntries)
 }
 
@@ -692,7 +1043,7 @@ func clampBufferRatio(r float64) float64 {
 }
 
 func (m *model) needsWindowRefetch() bool {
-	if m.showModal || m.showFilter || m.loadingWindow {
+	if m.showModal || m.showFilter || m.showViews || m.showRelated || m.showReminders || m.showTemplates || m.loadingWindow {
 		return false
 	}
 	if len(m.entries) == 0 {
@@ -730,7 +1081,7 @@ func (m *model) maybeRefetchWindow() tea.Cmd {
 	m.lastDuration = 0
 	anchor := m.entries[cur]
 	side := m.windowSide()
-	return windowCmd(m.ctx, m.namespace, splitCSV(m.tagsAny), splitCSV(m.tagsAll), m.since, m.until, anchor, side, side, "Loaded window")
+	return windowCmd(m.ctx, m.namespace, splitCSV(m.tagsAny), splitCSV(m.tagsAll), m.since, m.until, m.query, anchor, side, side, "Loaded window")
 }
 
 // columnsFor returns columns with or without titles based on headers flag.
@@ -752,15 +1103,19 @@ func (m *model) columnsFor(headers bool, idW, titleW, tagsW, createdW int) []tab
 }
 
 type keyMap struct {
-	Up     key.Binding
-	Down   key.Binding
-	Show   key.Binding
-	Edit   key.Binding
-	Delete key.Binding
-	Sync   key.Binding
-	Filter key.Binding
-	Help   key.Binding
-	Quit   key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Show      key.Binding
+	Edit      key.Binding
+	Delete    key.Binding
+	Sync      key.Binding
+	Filter    key.Binding
+	Views     key.Binding
+	Related   key.Binding
+	Reminders key.Binding
+	Template  key.Binding
+	Help      key.Binding
+	Quit      key.Binding
 }
 
 func newKeyMap() keyMap {
@@ -793,6 +1148,22 @@ func newKeyMap() keyMap {
 			key.WithKeys("f"),
 			key.WithHelp("f", "filter"),
 		),
+		Related: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "related notes"),
+		),
+		Views: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "saved views"),
+		),
+		Reminders: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "reminders"),
+		),
+		Template: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "new from template"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -810,8 +1181,8 @@ func (k keyMap) ShortHelp() []key.Binding {
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Show, k.Edit},
-		{k.Delete, k.Filter, k.Sync},
+		{k.Up, k.Down, k.Show, k.Related, k.Edit},
+		{k.Delete, k.Filter, k.Views, k.Reminders, k.Template, k.Sync},
 		{k.Help, k.Quit},
 	}
 }
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/internal/templates"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func TestTemplatePickerAddsNote(t *testing.T) {
+	m := model{entries: makeEntries(2)}
+	m.initTable()
+
+	ts := []templates.Template{{Name: "retro"}, {Name: "standup"}}
+	next, _ := m.Update(templatesResultMsg{templates: ts})
+	m = next.(model)
+	require.True(t, m.showTemplates)
+	require.Contains(t, m.View(), "standup")
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = next.(model)
+	sel, ok := m.tplModal.selected()
+	require.True(t, ok)
+	require.Equal(t, "standup", sel.Name)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	require.NotNil(t, cmd)
+	require.False(t, m.showTemplates)
+
+	// The saved note is prepended and selected.
+	added := api.Entry{ID: "new", Title: "Standup"}
+	next, _ = m.Update(editResultMsg{idx: -1, updated: &added, created: true})
+	m = next.(model)
+	require.Len(t, m.entries, 3)
+	require.Equal(t, "new", m.entries[0].ID)
+	require.Equal(t, 0, m.table.Cursor())
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+func TestViewPickerApplies(t *testing.T) {
+	m := model{entries: makeEntries(3), query: "old"}
+	m.initTable()
+
+	views := []api.View{
+		{Name: "inbox", TagsAny: []string{"todo"}},
+		{Name: "meetings", TagsAll: []string{"work", "meeting"}, Since: "7d"},
+	}
+	next, _ := m.Update(viewsResultMsg{views: views})
+	m = next.(model)
+	require.True(t, m.showViews)
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = next.(model)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(model)
+	require.NotNil(t, cmd)
+	require.False(t, m.showViews)
+	require.Equal(t, "meetings", m.viewName)
+	require.Equal(t, "work,meeting", m.tagsAll)
+	require.Equal(t, "7d", m.since)
+	require.Empty(t, m.query)
+	require.Empty(t, m.tagsAny)
+
+	// Reopening starts on the active view.
+	next, _ = m.Update(viewsResultMsg{views: views})
+	v, ok := next.(model).viewModal.selected()
+	require.True(t, ok)
+	require.Equal(t, "meetings", v.Name)
+}
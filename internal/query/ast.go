@@ -0,0 +1,217 @@
+// Package query parses the note search language into an AST.
+//
+// A query is a sequence of terms joined implicitly by AND, with OR binding
+// looser than AND and parentheses for grouping:
+//
+//	title:standup tag:work -tag:draft created:>2025-01 "exact phrase" OR deploy*
+//
+// Bare words and "phrases" match title, body and tags; title: and body:
+// restrict a term to one column; a trailing * makes a prefix match. tag:
+// matches an exact tag (or a prefix with *). created: and updated: compare
+// dates with >, >=, <, <= or = against a year, month, day, minute, RFC3339
+// time, today/yesterday, or a relative age like 7d, 2w, 3mo, 12h.
+// field:mood>=4 compares a custom field with =, !=, >, >=, < or <= (numbers
+// and durations like 45m numerically, other values as text); field:mood
+// matches notes that have the field. A leading - (or NOT) negates a term or
+// group.
+//
+// The package only builds the tree; storage backends lower it to their own
+// query form.
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Node is an element of a parsed query.
+type Node interface {
+	// Pos is the byte offset of the node in the source query.
+	Pos() int
+	String() string
+}
+
+// Term is a word or phrase matched against note text.
+type Term struct {
+	At int
+	// Field is "" (title, body and tags), "title" or "body".
+	Field  string
+	Text   string
+	Phrase bool
+	Prefix bool
+}
+
+// Tag matches notes carrying a tag, or any tag with the given prefix.
+type Tag struct {
+	At     int
+	Name   string
+	Prefix bool
+}
+
+// Op is a date or field comparison operator.
+type Op string
+
+const (
+	OpEq Op = "="
+	OpNe Op = "!="
+	OpGt Op = ">"
+	OpGe Op = ">="
+	OpLt Op = "<"
+	OpLe Op = "<="
+)
+
+// Date compares a timestamp column with a point or period in time. From and
+// To bound the period [From, To); they are equal for an instant.
+type Date struct {
+	At    int
+	Field string // "created" or "updated"
+	Op    Op
+	From  time.Time
+	To    time.Time
+	Raw   string
+}
+
+// Field compares a custom field of a note with Value, or matches notes
+// having the field when Op is empty.
+type Field struct {
+	At    int
+	Name  string
+	Op    Op
+	Value string
+}
+
+// Not negates its operand.
+type Not struct {
+	At   int
+	Node Node
+}
+
+// And matches when every operand matches.
+type And struct {
+	At    int
+	Nodes []Node
+}
+
+// Or matches when any operand matches.
+type Or struct {
+	At    int
+	Nodes []Node
+}
+
+func (n *Term) Pos() int  { return n.At }
+func (n *Tag) Pos() int   { return n.At }
+func (n *Date) Pos() int  { return n.At }
+func (n *Field) Pos() int { return n.At }
+func (n *Not) Pos() int   { return n.At }
+func (n *And) Pos() int   { return n.At }
+func (n *Or) Pos() int    { return n.At }
+
+func (n *Term) String() string {
+	s := n.Text
+	if n.Phrase {
+		s = quote(s)
+	}
+	if n.Prefix {
+		s += "*"
+	}
+	if n.Field != "" {
+		s = n.Field + ":" + s
+	}
+	return s
+}
+
+func (n *Tag) String() string {
+	s := "tag:" + n.Name
+	if n.Prefix {
+		s += "*"
+	}
+	return s
+}
+
+func (n *Date) String() string {
+	op := string(n.Op)
+	if n.Op == OpEq {
+		op = ""
+	}
+	return n.Field + ":" + op + n.Raw
+}
+
+func (n *Field) String() string { return "field:" + n.Name + string(n.Op) + n.Value }
+
+func (n *Not) String() string { return "-" + group(n.Node) }
+
+func (n *And) String() string { return join(n.Nodes, " ") }
+
+func (n *Or) String() string { return join(n.Nodes, " OR ") }
+
+// Conjuncts returns the operands of a top-level And, or n itself.
+func Conjuncts(n Node) []Node {
+	if a, ok := n.(*And); ok {
+		return a.Nodes
+	}
+	return []Node{n}
+}
+
+// Terms returns the text terms of n that are not negated, in source order.
+func Terms(n Node) []*Term {
+	var out []*Term
+	var walk func(Node)
+	walk = func(n Node) {
+		switch x := n.(type) {
+		case *Term:
+			out = append(out, x)
+		case *And:
+			for _, c := range x.Nodes {
+				walk(c)
+			}
+		case *Or:
+			for _, c := range x.Nodes {
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return out
+}
+
+func join(nodes []Node, sep string) string {
+	parts := make([]string, 0, len(nodes))
+	for _, c := range nodes {
+		parts = append(parts, group(c))
+	}
+	return strings.Join(parts, sep)
+}
+
+// group parenthesizes compound operands so String round-trips through Parse.
+func group(n Node) string {
+	switch n.(type) {
+	case *And, *Or:
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// SyntaxError reports a malformed query and where the problem was found.
+type SyntaxError struct {
+	// Pos is the byte offset into the query.
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query: %s at column %d", e.Msg, e.Pos+1)
+}
+
+// Context renders the query with a caret under the error position.
+func (e *SyntaxError) Context(src string) string {
+	pos := e.Pos
+	if pos > len(src) {
+		pos = len(src)
+	}
+	return src + "\n" + strings.Repeat(" ", len([]rune(src[:pos]))) + "^"
+}
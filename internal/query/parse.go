@@ -0,0 +1,450 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tWord
+	tPhrase
+	tField
+	tLParen
+	tRParen
+	tNeg
+	tOr
+	tAnd
+	tNot
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	text string
+	// adjacent is set on the token immediately following a field prefix.
+	adjacent bool
+}
+
+var fields = map[string]bool{
+	"title":   true,
+	"body":    true,
+	"tag":     true,
+	"created": true,
+	"updated": true,
+	"field":   true,
+}
+
+// lex splits src into tokens. Words run until whitespace, a parenthesis or
+// a quote; a word made of letters followed by ':' is a field prefix.
+func lex(src string) ([]token, error) {
+	var out []token
+	i := 0
+	afterField := false
+	for i < len(src) {
+		r := rune(src[i])
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			i++
+			afterField = false
+			continue
+		}
+		adj := afterField
+		afterField = false
+		switch {
+		case r == '(':
+			out = append(out, token{kind: tLParen, pos: i})
+			i++
+			continue
+		case r == ')':
+			out = append(out, token{kind: tRParen, pos: i})
+			i++
+			continue
+		case r == '"':
+			start := i
+			var b strings.Builder
+			i++
+			closed := false
+			for i < len(src) {
+				if src[i] == '"' {
+					if i+1 < len(src) && src[i+1] == '"' {
+						b.WriteByte('"')
+						i += 2
+						continue
+					}
+					i++
+					closed = true
+					break
+				}
+				b.WriteByte(src[i])
+				i++
+			}
+			if !closed {
+				return nil, &SyntaxError{Pos: start, Msg: "unterminated phrase"}
+			}
+			text := b.String()
+			if i < len(src) && src[i] == '*' {
+				text += "*"
+				i++
+			}
+			out = append(out, token{kind: tPhrase, pos: start, text: text, adjacent: adj})
+			continue
+		case r == '-' && !adj:
+			if i+1 >= len(src) || strings.ContainsRune(" \t\r\n)", rune(src[i+1])) {
+				return nil, &SyntaxError{Pos: i, Msg: "'-' must be followed by a term"}
+			}
+			out = append(out, token{kind: tNeg, pos: i})
+			i++
+			continue
+		}
+		start := i
+		for i < len(src) && !strings.ContainsRune(" \t\r\n()\"", rune(src[i])) {
+			if src[i] == ':' && !adj && isIdent(src[start:i]) {
+				break
+			}
+			i++
+		}
+		word := src[start:i]
+		if i < len(src) && src[i] == ':' && !adj {
+			name := strings.ToLower(word)
+			if !fields[name] {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unknown field %q (quote the term to search for it literally)", word)}
+			}
+			out = append(out, token{kind: tField, pos: start, text: name})
+			i++
+			afterField = true
+			continue
+		}
+		kind := tWord
+		if !adj {
+			switch word {
+			case "OR":
+				kind = tOr
+			case "AND":
+				kind = tAnd
+			case "NOT":
+				kind = tNot
+			}
+		}
+		out = append(out, token{kind: kind, pos: start, text: word, adjacent: adj})
+	}
+	out = append(out, token{kind: tEOF, pos: len(src)})
+	return out, nil
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+type parser struct {
+	toks []token
+	i    int
+	now  time.Time
+}
+
+// Parse parses a query relative to the current time.
+func Parse(src string) (Node, error) {
+	return ParseAt(src, time.Now())
+}
+
+// ParseAt parses a query, resolving relative dates against now.
+func ParseAt(src string, now time.Time) (Node, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks, now: now}
+	if p.peek().kind == tEOF {
+		return nil, &SyntaxError{Pos: 0, Msg: "empty query"}
+	}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tEOF {
+		if t.kind == tRParen {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected ')'"}
+		}
+		return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected token"}
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []Node{first}
+	for p.peek().kind == tOr {
+		op := p.next()
+		if k := p.peek().kind; k == tEOF || k == tRParen || k == tOr {
+			return nil, &SyntaxError{Pos: op.pos, Msg: "OR must be followed by a term"}
+		}
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return &Or{At: first.Pos(), Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	var nodes []Node
+	for {
+		t := p.peek()
+		switch t.kind {
+		case tEOF, tRParen, tOr:
+			if len(nodes) == 0 {
+				return nil, &SyntaxError{Pos: t.pos, Msg: "expected a term"}
+			}
+			if len(nodes) == 1 {
+				return nodes[0], nil
+			}
+			return &And{At: nodes[0].Pos(), Nodes: nodes}, nil
+		case tAnd:
+			p.next()
+			if len(nodes) == 0 {
+				return nil, &SyntaxError{Pos: t.pos, Msg: "AND must follow a term"}
+			}
+			if k := p.peek().kind; k == tEOF || k == tRParen || k == tOr || k == tAnd {
+				return nil, &SyntaxError{Pos: t.pos, Msg: "AND must be followed by a term"}
+			}
+			continue
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	t := p.peek()
+	if t.kind == tNeg || t.kind == tNot {
+		p.next()
+		if k := p.peek().kind; k == tEOF || k == tRParen || k == tOr || k == tAnd {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "negation must be followed by a term"}
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{At: t.pos, Node: n}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tRParen {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "unclosed '('"}
+		}
+		p.next()
+		return n, nil
+	case tWord, tPhrase:
+		return termFrom(t, "")
+	case tField:
+		v := p.peek()
+		if !v.adjacent || (v.kind != tWord && v.kind != tPhrase) {
+			return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("missing value for %s:", t.text)}
+		}
+		p.next()
+		switch t.text {
+		case "tag":
+			name, prefix := strings.TrimSuffix(v.text, "*"), strings.HasSuffix(v.text, "*")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				return nil, &SyntaxError{Pos: v.pos, Msg: "empty tag"}
+			}
+			return &Tag{At: t.pos, Name: name, Prefix: prefix}, nil
+		case "created", "updated":
+			return p.parseDate(t, v)
+		case "field":
+			return parseField(t, v)
+		default:
+			n, err := termFrom(v, t.text)
+			if err != nil {
+				return nil, err
+			}
+			n.(*Term).At = t.pos
+			return n, nil
+		}
+	case tRParen:
+		return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected ')'"}
+	case tEOF:
+		return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected end of query"}
+	}
+	return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected token"}
+}
+
+func termFrom(t token, field string) (Node, error) {
+	text := t.text
+	prefix := strings.HasSuffix(text, "*")
+	if prefix {
+		text = strings.TrimSuffix(text, "*")
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, &SyntaxError{Pos: t.pos, Msg: "empty term"}
+	}
+	if strings.Contains(text, "*") && t.kind == tWord {
+		return nil, &SyntaxError{Pos: t.pos + strings.Index(text, "*"), Msg: "'*' is only allowed at the end of a term"}
+	}
+	return &Term{At: t.pos, Field: field, Text: text, Phrase: t.kind == tPhrase, Prefix: prefix}, nil
+}
+
+// parseDate reads an optional comparison operator and a date value.
+func (p *parser) parseDate(field, v token) (Node, error) {
+	raw := v.text
+	op := OpEq
+	for _, cand := range []Op{OpGe, OpLe, OpGt, OpLt, OpEq} {
+		if strings.HasPrefix(raw, string(cand)) {
+			op = cand
+			raw = raw[len(cand):]
+			break
+		}
+	}
+	valPos := v.pos + len(v.text) - len(raw)
+	from, to, err := parseDateValue(raw, p.now)
+	if err != nil {
+		return nil, &SyntaxError{Pos: valPos, Msg: err.Error()}
+	}
+	// A bare instant reads as "since".
+	if op == OpEq && from.Equal(to) {
+		op = OpGe
+	}
+	return &Date{At: field.pos, Field: field.text, Op: op, From: from, To: to, Raw: raw}, nil
+}
+
+// parseField reads a field name, then optionally an operator and a value:
+// mood, mood>=4, project=ginkgo, duration<1h.
+func parseField(field, v token) (Node, error) {
+	raw := v.text
+	end := strings.IndexAny(raw, "=!<>")
+	if end < 0 {
+		end = len(raw)
+	}
+	name := strings.ToLower(raw[:end])
+	if !api.ValidFieldName(name) {
+		return nil, &SyntaxError{Pos: v.pos, Msg: fmt.Sprintf("invalid field name %q", raw[:end])}
+	}
+	rest := raw[end:]
+	if rest == "" {
+		return &Field{At: field.pos, Name: name}, nil
+	}
+	for _, cand := range []Op{OpGe, OpLe, OpNe, OpGt, OpLt, OpEq} {
+		if strings.HasPrefix(rest, string(cand)) {
+			value := rest[len(cand):]
+			if value == "" {
+				return nil, &SyntaxError{Pos: v.pos + len(raw), Msg: fmt.Sprintf("missing value for field %s", name)}
+			}
+			if strings.ContainsAny(value[:1], "=!<>") {
+				return nil, &SyntaxError{Pos: v.pos + end + len(cand), Msg: "expected =, !=, >, >=, < or <="}
+			}
+			return &Field{At: field.pos, Name: name, Op: cand, Value: value}, nil
+		}
+	}
+	return nil, &SyntaxError{Pos: v.pos + end, Msg: "expected =, !=, >, >=, < or <="}
+}
+
+// parseDateValue returns the period a date value denotes. Calendar values
+// are read in local time.
+func parseDateValue(s string, now time.Time) (time.Time, time.Time, error) {
+	if s == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("missing date")
+	}
+	loc := now.Location()
+	day := func(t time.Time) (time.Time, time.Time) {
+		d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		return d, d.AddDate(0, 0, 1)
+	}
+	switch strings.ToLower(s) {
+	case "today":
+		f, t := day(now)
+		return f, t, nil
+	case "yesterday":
+		f, t := day(now.AddDate(0, 0, -1))
+		return f, t, nil
+	}
+	if t, ok := relativeAge(s, now); ok {
+		return t, t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, t, nil
+	}
+	periods := []struct {
+		layout string
+		next   func(time.Time) time.Time
+	}{
+		{"2006-01-02T15:04", func(t time.Time) time.Time { return t.Add(time.Minute) }},
+		{"2006-01-02", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+		{"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+		{"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+	}
+	for _, p := range periods {
+		if t, err := time.ParseInLocation(p.layout, s, loc); err == nil {
+			return t, p.next(t), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// relativeAge parses ages such as 30m, 12h, 7d, 2w and 3mo.
+func relativeAge(s string, now time.Time) (time.Time, bool) {
+	units := []struct {
+		suffix string
+		apply  func(int) time.Time
+	}{
+		{"mo", func(n int) time.Time { return now.AddDate(0, -n, 0) }},
+		{"w", func(n int) time.Time { return now.AddDate(0, 0, -7*n) }},
+		{"d", func(n int) time.Time { return now.AddDate(0, 0, -n) }},
+		{"h", func(n int) time.Time { return now.Add(-time.Duration(n) * time.Hour) }},
+		{"m", func(n int) time.Time { return now.Add(-time.Duration(n) * time.Minute) }},
+	}
+	for _, u := range units {
+		if !strings.HasSuffix(s, u.suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(s, u.suffix))
+		if err != nil || n < 0 {
+			return time.Time{}, false
+		}
+		return u.apply(n), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,91 @@
+package query
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct{ in, want string }{
+		{`deploy`, `deploy`},
+		{`title:standup tag:work -tag:draft`, `title:standup tag:work -tag:draft`},
+		{`"exact phrase" OR deploy*`, `"exact phrase" OR deploy*`},
+		{`a b OR c`, `(a b) OR c`},
+		{`a (b OR c)`, `a (b OR c)`},
+		{`NOT tag:Draft AND body:"x ""y"""`, `-tag:draft body:"x ""y"""`},
+		{`created:>2025-01 updated:<=7d`, `created:>2025-01 updated:<=7d`},
+		{`created:2025-01-02`, `created:2025-01-02`},
+		{`created:7d`, `created:>=7d`},
+		{`10:30 foo-bar`, `10:30 foo-bar`},
+		{`field:Mood>=4 -field:project=ginkgo field:done`, `field:mood>=4 -field:project=ginkgo field:done`},
+	}
+	for _, c := range cases {
+		n, err := ParseAt(c.in, now)
+		if err != nil {
+			t.Fatalf("%q: %v", c.in, err)
+		}
+		if got := n.String(); got != c.want {
+			t.Fatalf("%q: got %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	n, err := ParseAt(`created:>2025-01 created:yesterday`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := Conjuncts(n)
+	d := parts[0].(*Date)
+	if d.Op != OpGt || !d.From.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || !d.To.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("month period: %+v", d)
+	}
+	y := parts[1].(*Date)
+	if y.Op != OpEq || !y.From.Equal(time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("yesterday: %+v", y)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		in  string
+		pos int
+	}{
+		{``, 0},
+		{`"open`, 0},
+		{`foo (bar`, 4},
+		{`foo)`, 3},
+		{`titel:x`, 0},
+		{`title: x`, 0},
+		{`a OR`, 2},
+		{`created:>soon`, 9},
+		{`fo*o`, 2},
+		{`- foo`, 0},
+		{`field:2x=1`, 6},
+		{`field:mood>=`, 12},
+		{`field:mood!4`, 10},
+		{`field:mood>>4`, 11},
+	}
+	for _, c := range cases {
+		_, err := Parse(c.in)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("%q: expected syntax error, got %v", c.in, err)
+		}
+		if se.Pos != c.pos {
+			t.Fatalf("%q: error %q at %d, want %d", c.in, se.Msg, se.Pos, c.pos)
+		}
+	}
+}
+
+func TestSyntaxErrorContext(t *testing.T) {
+	src := `foo (bar`
+	_, err := Parse(src)
+	se := err.(*SyntaxError)
+	if got, want := se.Context(src), "foo (bar\n    ^"; got != want {
+		t.Fatalf("context = %q, want %q", got, want)
+	}
+}
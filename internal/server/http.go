@@ -1,34 +1,53 @@
 package server
 
 import (
+	"bufio"
 	"crypto/ed25519"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 
+	"google.golang.org/protobuf/encoding/protodelim"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/mithrel/ginkgo/internal/compress"
 	gcrypto "github.com/mithrel/ginkgo/internal/crypto"
 	"github.com/mithrel/ginkgo/internal/db"
 	pbmsg "github.com/mithrel/ginkgo/internal/ipc/pb"
+	"github.com/mithrel/ginkgo/internal/metrics"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
+const (
+	// ContentTypeProto is a single protobuf message body.
+	ContentTypeProto = "application/x-protobuf"
+	// ContentTypeProtoDelimited is a stream of varint length-prefixed protobuf messages.
+	ContentTypeProtoDelimited = "application/x-protobuf-delimited"
+	// PullCompleteTrailer ends a pull stream that carried every remaining
+	// event; a stream without it was cut short.
+	PullCompleteTrailer = "Ginkgo-Pull-Complete"
+
+	defaultMaxBodyBytes = 32 << 20
+	// pullChunkSize bounds how many events are loaded per query while streaming.
+	pullChunkSize = 500
+)
+
 // Server serves HTTP replication endpoints backed by a Store.
 type Server struct {
-	cfg   *viper.Viper
-	store *db.Store
+	cfg     *viper.Viper
+	store   *db.Store
+	limiter *rateLimiter
 }
 
 func New(cfg *viper.Viper, store *db.Store) *Server {
-	return &Server{cfg: cfg, store: store}
+	return &Server{cfg: cfg, store: store, limiter: newRateLimiter()}
 }
 
 // Router returns an http.Handler with registered routes.
@@ -37,8 +56,11 @@ func (s *Server) Router() http.Handler {
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("ok"))
 	})
-	mux.HandleFunc("/v1/replicate/push", s.auth(s.handlePush))
-	mux.HandleFunc("/v1/replicate/pull", s.auth(s.handlePull))
+	if s.cfg.GetBool("metrics.enabled") {
+		mux.Handle("/metrics", metrics.Handler())
+	}
+	mux.HandleFunc("/v1/replicate/push", s.auth(s.rateLimit(s.handlePush)))
+	mux.HandleFunc("/v1/replicate/pull", s.auth(s.rateLimit(s.handlePull)))
 	return mux
 }
 
@@ -59,9 +81,18 @@ func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	b, err := io.ReadAll(r.Body)
+	w.Header().Set("Accept-Encoding", compress.AcceptHeader())
+	b, err := s.readBody(w, r)
 	if err != nil {
-		http.Error(w, "failed to read body", http.StatusBadRequest)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			http.Error(w, fmt.Sprintf("push body exceeds server limit of %d bytes; lower sync.batch_size", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+		case errors.Is(err, errUnsupportedEncoding):
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+		default:
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+		}
 		return
 	}
 	var batch pbmsg.PushBatch
@@ -70,6 +101,8 @@ func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	out := make([]*pbmsg.ItemStatus, 0, len(batch.Events))
+	quotas := s.newQuotaTracker()
+	maxEvent := s.maxEventBytes()
 	var last time.Time
 	for _, pev := range batch.Events {
 		st := &pbmsg.ItemStatus{Id: pev.GetId(), Ok: true}
@@ -82,17 +115,40 @@ func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
 		}
 		evType := api.EventType(strings.ToLower(pev.GetType()))
 		if pev.GetPayloadType() == "" || len(pev.GetPayload()) == 0 {
+			metrics.PushRejections.WithLabelValues("missing_payload").Inc()
 			st.Ok = false
 			st.Msg = "missing payload"
 			out = append(out, st)
 			continue
 		}
+		if len(pev.GetPayload()) > maxEvent {
+			metrics.PushRejections.WithLabelValues("payload_too_large").Inc()
+			st.Ok = false
+			st.Msg = fmt.Sprintf("payload exceeds server limit of %d bytes", maxEvent)
+			out = append(out, st)
+			continue
+		}
 		if err := s.verifyRepEventSignature(pev); err != nil {
+			metrics.PushRejections.WithLabelValues("signature").Inc()
 			st.Ok = false
 			st.Msg = err.Error()
 			out = append(out, st)
 			continue
 		}
+		reason, err := quotas.admit(r.Context(), pev.GetNamespaceId(), len(pev.GetPayload()))
+		if err != nil {
+			http.Error(w, "quota check failed", http.StatusInternalServerError)
+			return
+		}
+		if reason != "" {
+			// A full quota does not clear on retry; rejecting the event for
+			// good keeps it from blocking other namespaces' events.
+			metrics.PushRejections.WithLabelValues("quota").Inc()
+			st.Ok = false
+			st.Msg = reason
+			out = append(out, st)
+			continue
+		}
 		ev := api.Event{
 			Time:        evTime,
 			Type:        evType,
@@ -105,15 +161,64 @@ func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
 			Sig:         append([]byte(nil), pev.GetSig()...),
 		}
 		if err := s.store.Events.Append(r.Context(), ev); err != nil {
+			metrics.PushRejections.WithLabelValues("store_error").Inc()
 			st.Ok = false
 			st.Msg = err.Error()
 		}
 		out = append(out, st)
 	}
 	resp := &pbmsg.PushResult{Items: out, Next: &pbmsg.Cursor{After: timestamppb.New(last)}}
-	w.Header().Set("Content-Type", "application/x-protobuf")
 	enc, _ := proto.Marshal(resp)
-	_, _ = w.Write(enc)
+	writeProtoBody(w, r, enc)
+}
+
+var errUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// maxBodyBytes returns the configured request body cap (server.max_body_bytes).
+func (s *Server) maxBodyBytes() int64 {
+	if n := s.cfg.GetInt64("server.max_body_bytes"); n > 0 {
+		return n
+	}
+	return defaultMaxBodyBytes
+}
+
+// readBody reads a request body honouring Content-Encoding. The size cap
+// applies both to the bytes on the wire and to the decompressed payload.
+func (s *Server) readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	limit := s.maxBodyBytes()
+	enc := compress.Normalize(r.Header.Get("Content-Encoding"))
+	if !compress.IsSupported(enc) {
+		return nil, fmt.Errorf("%w %q", errUnsupportedEncoding, enc)
+	}
+	body := http.MaxBytesReader(w, r.Body, limit)
+	dec, err := compress.NewReader(enc, body)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	b, err := io.ReadAll(io.LimitReader(dec, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > limit {
+		return nil, &http.MaxBytesError{Limit: limit}
+	}
+	return b, nil
+}
+
+// writeProtoBody writes an encoded protobuf response, compressing it when the
+// client advertised a supported coding.
+func writeProtoBody(w http.ResponseWriter, r *http.Request, b []byte) {
+	w.Header().Set("Content-Type", ContentTypeProto)
+	w.Header().Add("Vary", "Accept-Encoding")
+	enc := compress.Negotiate(r.Header.Get("Accept-Encoding"))
+	if enc != compress.Identity {
+		if out, err := compress.Encode(enc, b); err == nil {
+			w.Header().Set("Content-Encoding", enc)
+			b = out
+		}
+	}
+	_, _ = w.Write(b)
 }
 
 func (s *Server) verifyRepEventSignature(pev *pbmsg.RepEvent) error {
@@ -204,11 +309,11 @@ func (s *Server) handlePull(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	limit := 256
-	if ls := strings.TrimSpace(q.Get("limit")); ls != "" {
-		if n, err := strconv.Atoi(ls); err == nil && n > 0 {
-			limit = n
-		}
+	limit := s.pullLimit(q.Get("limit"))
+	w.Header().Set("Accept-Encoding", compress.AcceptHeader())
+	if strings.Contains(r.Header.Get("Accept"), ContentTypeProtoDelimited) {
+		s.streamPull(w, r, after)
+		return
 	}
 	evs, nextCur, err := s.store.Events.List(r.Context(), api.Cursor{After: after}, limit)
 	if err != nil {
@@ -217,23 +322,72 @@ func (s *Server) handlePull(w http.ResponseWriter, r *http.Request) {
 	}
 	out := make([]*pbmsg.RepEvent, 0, len(evs))
 	for _, e := range evs {
-		out = append(out, &pbmsg.RepEvent{
-			Time:        timestamppb.New(e.Time),
-			Type:        string(e.Type),
-			Id:          e.ID,
-			NamespaceId: e.Namespace,
-			PayloadType: e.PayloadType,
-			Payload:     e.Payload,
-			OriginLabel: e.OriginLabel,
-			SignerId:    e.SignerID,
-			Sig:         e.Sig,
-		})
+		out = append(out, toRepEvent(e))
 	}
 	resp := &pbmsg.PullResult{Events: out}
 	if !nextCur.After.IsZero() {
 		resp.Next = &pbmsg.Cursor{After: timestamppb.New(nextCur.After)}
 	}
-	w.Header().Set("Content-Type", "application/x-protobuf")
 	b, _ := proto.Marshal(resp)
-	_, _ = w.Write(b)
+	writeProtoBody(w, r, b)
+}
+
+// streamPull writes every event after the cursor as length-delimited
+// RepEvent frames, loading them from the store in bounded chunks, and sets
+// PullCompleteTrailer once the last one is written. The limit query
+// parameter pages protobuf responses only.
+func (s *Server) streamPull(w http.ResponseWriter, r *http.Request, after time.Time) {
+	w.Header().Set("Content-Type", ContentTypeProtoDelimited)
+	w.Header().Add("Vary", "Accept-Encoding")
+	w.Header().Set("Trailer", PullCompleteTrailer)
+	enc := compress.Negotiate(r.Header.Get("Accept-Encoding"))
+	if enc != compress.Identity {
+		w.Header().Set("Content-Encoding", enc)
+	}
+	cw, err := compress.NewWriter(enc, w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	bw := bufio.NewWriter(cw)
+	complete := false
+	defer func() {
+		ferr := bw.Flush()
+		cerr := cw.Close()
+		if complete && ferr == nil && cerr == nil {
+			w.Header().Set(PullCompleteTrailer, "1")
+		}
+	}()
+	cur := api.Cursor{After: after}
+	for {
+		evs, next, err := s.store.Events.List(r.Context(), cur, pullChunkSize)
+		if err != nil {
+			// Headers are already sent; the missing trailer signals failure.
+			return
+		}
+		for _, e := range evs {
+			if _, err := protodelim.MarshalTo(bw, toRepEvent(e)); err != nil {
+				return
+			}
+		}
+		if len(evs) < pullChunkSize || next.After.IsZero() {
+			complete = true
+			return
+		}
+		cur = next
+	}
+}
+
+func toRepEvent(e api.Event) *pbmsg.RepEvent {
+	return &pbmsg.RepEvent{
+		Time:        timestamppb.New(e.Time),
+		Type:        string(e.Type),
+		Id:          e.ID,
+		NamespaceId: e.Namespace,
+		PayloadType: e.PayloadType,
+		Payload:     e.Payload,
+		OriginLabel: e.OriginLabel,
+		SignerId:    e.SignerID,
+		Sig:         e.Sig,
+	}
 }
@@ -2,6 +2,9 @@ package server
 
 import (
 	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +13,7 @@ import (
 
 	gcrypto "github.com/mithrel/ginkgo/internal/crypto"
 	pbmsg "github.com/mithrel/ginkgo/internal/ipc/pb"
+	"github.com/mithrel/ginkgo/internal/metrics"
 )
 
 func TestVerifyRepEventSignature(t *testing.T) {
@@ -60,3 +64,24 @@ func TestVerifyRepEventSignature(t *testing.T) {
 		t.Fatalf("expected missing signature error")
 	}
 }
+
+func TestMetricsEndpoint(t *testing.T) {
+	get := func(enabled bool) *httptest.ResponseRecorder {
+		cfg := viper.New()
+		cfg.Set("metrics.enabled", enabled)
+		rec := httptest.NewRecorder()
+		New(cfg, nil).Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec
+	}
+	if rec := get(false); rec.Code != http.StatusNotFound {
+		t.Fatalf("disabled: got %d, want 404", rec.Code)
+	}
+	metrics.PushRejections.WithLabelValues("signature").Inc()
+	rec := get(true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("enabled: got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `ginkgo_server_push_rejections_total{reason="signature"}`) {
+		t.Fatalf("missing push rejection metric in output")
+	}
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/db"
+)
+
+const (
+	defaultRateLimitRPS   = 10
+	defaultRateLimitBurst = 40
+	defaultMaxEventBytes  = 4 << 20
+	defaultMaxPullLimit   = 2000
+)
+
+// bucket is a token bucket refilled continuously at the limiter rate.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps one token bucket per bearer token.
+type rateLimiter struct {
+	mu      gosync.Mutex
+	buckets map[string]*bucket
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: map[string]*bucket{}}
+}
+
+// allow takes one token from key's bucket. When the bucket is empty it
+// reports how long until a token is available.
+func (l *rateLimiter) allow(key string, rps float64, burst int, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rps)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / rps * float64(time.Second))
+	return false, wait
+}
+
+// rateLimit rejects requests beyond server.rate_limit.rps (with a burst of
+// server.rate_limit.burst) per bearer token with 429 and Retry-After.
+// A non-positive rate disables limiting.
+func (s *Server) rateLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rps := float64(defaultRateLimitRPS)
+		if s.cfg.IsSet("server.rate_limit.rps") {
+			rps = s.cfg.GetFloat64("server.rate_limit.rps")
+		}
+		if rps <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		burst := defaultRateLimitBurst
+		if n := s.cfg.GetInt("server.rate_limit.burst"); n > 0 {
+			burst = n
+		}
+		ok, wait := s.limiter.allow(tokenKey(r), rps, burst, time.Now())
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// tokenKey identifies the caller by a digest of its bearer token so raw
+// secrets are not kept as map keys.
+func tokenKey(r *http.Request) string {
+	tok := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	sum := sha256.Sum256([]byte(tok))
+	return hex.EncodeToString(sum[:8])
+}
+
+// maxEventBytes caps a single event payload (server.max_event_bytes).
+func (s *Server) maxEventBytes() int {
+	if n := s.cfg.GetInt("server.max_event_bytes"); n > 0 {
+		return n
+	}
+	return defaultMaxEventBytes
+}
+
+// pullLimit is the page size of a pull: the client's limit query parameter,
+// clamped to server.max_pull_limit so a single response cannot load the
+// whole event log.
+func (s *Server) pullLimit(v string) int {
+	max := defaultMaxPullLimit
+	if n := s.cfg.GetInt("server.max_pull_limit"); n > 0 {
+		max = n
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 && n < max {
+		return n
+	}
+	return max
+}
+
+// quota holds storage limits for one namespace; zero means unlimited.
+type quota struct {
+	MaxEvents int64
+	MaxBytes  int64
+}
+
+// quotaFor reads namespaces.<ns>.max_events / max_bytes, falling back to
+// server.quota.max_events / max_bytes.
+func (s *Server) quotaFor(ns string) quota {
+	q := quota{
+		MaxEvents: s.cfg.GetInt64("server.quota.max_events"),
+		MaxBytes:  s.cfg.GetInt64("server.quota.max_bytes"),
+	}
+	base := "namespaces." + ns + "."
+	if s.cfg.IsSet(base + "max_events") {
+		q.MaxEvents = s.cfg.GetInt64(base + "max_events")
+	}
+	if s.cfg.IsSet(base + "max_bytes") {
+		q.MaxBytes = s.cfg.GetInt64(base + "max_bytes")
+	}
+	return q
+}
+
+// quotaTracker checks pushed events against namespace quotas, loading current
+// usage once per namespace and accounting for events accepted in this batch.
+type quotaTracker struct {
+	s     *Server
+	usage map[string]*db.Usage
+}
+
+func (s *Server) newQuotaTracker() *quotaTracker {
+	return &quotaTracker{s: s, usage: map[string]*db.Usage{}}
+}
+
+// admit reports why an event of size bytes cannot be stored in ns, or "" when
+// it fits. Admitted events are counted towards later checks.
+func (t *quotaTracker) admit(ctx context.Context, ns string, size int) (string, error) {
+	q := t.s.quotaFor(ns)
+	if q.MaxEvents <= 0 && q.MaxBytes <= 0 {
+		return "", nil
+	}
+	u, ok := t.usage[ns]
+	if !ok {
+		ur, isReporter := t.s.store.Events.(db.UsageReporter)
+		if !isReporter {
+			return "", nil
+		}
+		got, err := ur.NamespaceUsage(ctx, ns)
+		if err != nil {
+			return "", err
+		}
+		u = &got
+		t.usage[ns] = u
+	}
+	if q.MaxEvents > 0 && u.Events+1 > q.MaxEvents {
+		return fmt.Sprintf("namespace %q quota exceeded: %d events max", ns, q.MaxEvents), nil
+	}
+	if q.MaxBytes > 0 && u.Bytes+int64(size) > q.MaxBytes {
+		return fmt.Sprintf("namespace %q quota exceeded: %d bytes max", ns, q.MaxBytes), nil
+	}
+	u.Events++
+	u.Bytes += int64(size)
+	return "", nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestRateLimiterBucket(t *testing.T) {
+	l := newRateLimiter()
+	now := time.Unix(1000, 0)
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.allow("a", 2, 3, now); !ok {
+			t.Fatalf("request %d within burst was denied", i)
+		}
+	}
+	ok, wait := l.allow("a", 2, 3, now)
+	if ok {
+		t.Fatalf("expected denial after burst")
+	}
+	if wait != 500*time.Millisecond {
+		t.Fatalf("wait = %s, want 500ms", wait)
+	}
+	if ok, _ := l.allow("b", 2, 3, now); !ok {
+		t.Fatalf("other tokens must have their own bucket")
+	}
+	if ok, _ := l.allow("a", 2, 3, now.Add(wait)); !ok {
+		t.Fatalf("expected refill after wait")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("server.rate_limit.rps", 0.5)
+	cfg.Set("server.rate_limit.burst", 1)
+	srv := &Server{cfg: cfg, limiter: newRateLimiter()}
+	h := srv.rateLimit(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	do := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/replicate/pull", nil)
+		req.Header.Set("Authorization", "Bearer tok")
+		h(rec, req)
+		return rec
+	}
+	if rec := do(); rec.Code != http.StatusOK {
+		t.Fatalf("first request: got %d", rec.Code)
+	}
+	rec := do()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request: got %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After = %q, want 2", got)
+	}
+}
+
+func TestPullLimitClamp(t *testing.T) {
+	cfg := viper.New()
+	srv := &Server{cfg: cfg}
+	for in, want := range map[string]int{"": 2000, "abc": 2000, "-5": 2000, "10": 10, "100000000": 2000} {
+		if got := srv.pullLimit(in); got != want {
+			t.Fatalf("pullLimit(%q) = %d, want %d", in, got, want)
+		}
+	}
+	cfg.Set("server.max_pull_limit", 50)
+	if got := srv.pullLimit("100000000"); got != 50 {
+		t.Fatalf("clamped limit = %d, want 50", got)
+	}
+	if got := srv.pullLimit("20"); got != 20 {
+		t.Fatalf("limit under the cap = %d, want 20", got)
+	}
+}
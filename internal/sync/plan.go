@@ -0,0 +1,307 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mithrel/ginkgo/internal/db"
+	pbmsg "github.com/mithrel/ginkgo/internal/ipc/pb"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Plan actions reported by a dry run.
+const (
+	ActionPush      = "push"
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionDelete    = "delete"
+	ActionUnchanged = "unchanged"
+	ActionError     = "error"
+)
+
+// PlanEvent is one event a sync pass would push or apply.
+type PlanEvent struct {
+	Time      time.Time
+	Type      string
+	ID        string
+	Namespace string
+	Title     string
+	Action    string
+	Err       string
+}
+
+// RemotePlan summarizes what syncing with one remote would do. Events holds
+// at most the requested number of samples; the counters cover everything.
+type RemotePlan struct {
+	Name      string
+	URL       string
+	Push      int
+	Create    int
+	Update    int
+	Delete    int
+	Unchanged int
+	Errors    int
+	Events    []PlanEvent
+}
+
+func (p *RemotePlan) add(ev PlanEvent, limit int) {
+	switch ev.Action {
+	case ActionPush:
+		p.Push++
+	case ActionCreate:
+		p.Create++
+	case ActionUpdate:
+		p.Update++
+	case ActionDelete:
+		p.Delete++
+	case ActionUnchanged:
+		p.Unchanged++
+	case ActionError:
+		p.Errors++
+	}
+	if limit <= 0 || len(p.Events) < limit {
+		p.Events = append(p.Events, ev)
+	}
+}
+
+// Plan computes, without writing anything, which local events would be pushed
+// to each enabled remote and what applying the remote's events would change
+// locally. Incoming payloads are decrypted and compared by Entry.Hash.
+func (s *Service) Plan(ctx context.Context, onlyRemote string, limit int) ([]RemotePlan, error) {
+	// A named remote is planned even while disabled so it can be checked
+	// before being switched on.
+	names := s.enabledRemotes("")
+	if onlyRemote != "" {
+		names = []string{onlyRemote}
+	}
+	out := make([]RemotePlan, 0, len(names))
+	for _, name := range names {
+		rc, err := s.getRemoteConfig(name)
+		if err != nil {
+			return nil, err
+		}
+		plan := RemotePlan{Name: name, URL: rc.URL}
+		pushAfter, pullAfter := s.loadCursors(name)
+
+		cur := api.Cursor{After: pushAfter}
+		for {
+			evs, next, err := s.store.Events.List(ctx, cur, rc.BatchSize)
+			if err != nil {
+				return nil, fmt.Errorf("list events: %w", err)
+			}
+			for _, ev := range evs {
+				pe := PlanEvent{Time: ev.Time, Type: string(ev.Type), ID: ev.ID, Namespace: ev.Namespace, Action: ActionPush}
+				if ev.Entry != nil {
+					pe.Title = ev.Entry.Title
+				}
+				plan.add(pe, limit)
+			}
+			if len(evs) < rc.BatchSize {
+				break
+			}
+			cur = next
+		}
+
+		// Simulated state so repeated events for one ID diff against each other:
+		// the value is the entry hash, or "" once deleted.
+		state := map[string]string{}
+		sink := pullSink{consume: func(ctx context.Context, in []*pbmsg.RepEvent) error {
+			for _, pev := range in {
+				pe, err := s.planPulled(ctx, pev, state)
+				if err != nil {
+					return err
+				}
+				plan.add(pe, limit)
+			}
+			return nil
+		}}
+		if err := s.pullRemote(ctx, rc, pullAfter, sink); err != nil {
+			return nil, err
+		}
+		out = append(out, plan)
+	}
+	return out, nil
+}
+
+// planPulled classifies one incoming event against local (or simulated) state.
+// Decode failures are reported on the event rather than aborting the plan.
+func (s *Service) planPulled(ctx context.Context, pev *pbmsg.RepEvent, state map[string]string) (PlanEvent, error) {
+	pe := PlanEvent{Type: pev.GetType(), ID: pev.GetId(), Namespace: pev.GetNamespaceId()}
+	if t := pev.GetTime(); t != nil {
+		pe.Time = t.AsTime()
+	}
+	ev, err := s.repEventToAPI(pev)
+	if err != nil {
+		pe.Action = ActionError
+		pe.Err = err.Error()
+		return pe, nil
+	}
+	if ev.Namespace != "" {
+		pe.Namespace = ev.Namespace
+	}
+
+	if ev.Type == api.EventViewUpsert || ev.Type == api.EventViewDelete {
+		return s.planPulledView(ctx, ev, pe, state)
+	}
+	if ev.Type == api.EventReminderUpsert || ev.Type == api.EventReminderDelete {
+		return s.planPulledReminder(ctx, ev, pe, state)
+	}
+
+	prev, known := state[ev.ID]
+	if !known {
+		local, err := s.store.Entries.GetEntry(ctx, ev.ID)
+		switch err {
+		case nil:
+			prev = local.Hash()
+		case db.ErrNotFound:
+			prev = ""
+		default:
+			return PlanEvent{}, err
+		}
+	}
+
+	switch ev.Type {
+	case api.EventUpsert:
+		if ev.Entry == nil {
+			pe.Action = ActionUnchanged
+			return pe, nil
+		}
+		pe.Title = ev.Entry.Title
+		h := ev.Entry.Hash()
+		switch prev {
+		case "":
+			pe.Action = ActionCreate
+		case h:
+			pe.Action = ActionUnchanged
+		default:
+			pe.Action = ActionUpdate
+		}
+		state[ev.ID] = h
+	case api.EventDelete:
+		if prev == "" {
+			pe.Action = ActionUnchanged
+		} else {
+			pe.Action = ActionDelete
+		}
+		state[ev.ID] = ""
+	default:
+		pe.Action = ActionUnchanged
+	}
+	return pe, nil
+}
+
+// planPulledView classifies a saved view event. Views share the simulated
+// state map with entries under a "view:" key so names cannot clash with IDs.
+func (s *Service) planPulledView(ctx context.Context, ev api.Event, pe PlanEvent, state map[string]string) (PlanEvent, error) {
+	pe.Title = ev.ID
+	var at string
+	if ev.View != nil {
+		at = viewStamp(ev.View.UpdatedAt)
+	}
+	return planPulledStamped(pe, state, "view:"+ev.Namespace+"/"+strings.ToLower(ev.ID), ev.Type == api.EventViewDelete, at, func() (string, error) {
+		local, err := s.store.Views.GetView(ctx, ev.Namespace, ev.ID)
+		if err != nil {
+			return "", err
+		}
+		return viewStamp(local.UpdatedAt), nil
+	})
+}
+
+// planPulledReminder classifies a reminder event under a "reminder:" key.
+func (s *Service) planPulledReminder(ctx context.Context, ev api.Event, pe PlanEvent, state map[string]string) (PlanEvent, error) {
+	var at string
+	if ev.Reminder != nil {
+		at = viewStamp(ev.Reminder.UpdatedAt)
+		pe.Title = "reminder " + ev.Reminder.At.Local().Format("2006-01-02 15:04")
+	}
+	return planPulledStamped(pe, state, "reminder:"+ev.ID, ev.Type == api.EventReminderDelete, at, func() (string, error) {
+		local, err := s.store.Reminders.GetReminder(ctx, ev.ID)
+		if err != nil {
+			return "", err
+		}
+		return viewStamp(local.UpdatedAt), nil
+	})
+}
+
+// planPulledStamped classifies a last-writer-wins event: deleted, or an
+// upsert stamped at (empty when the event carries nothing). local returns
+// the stamp of the stored copy.
+func planPulledStamped(pe PlanEvent, state map[string]string, key string, deleted bool, at string, local func() (string, error)) (PlanEvent, error) {
+	prev, known := state[key]
+	if !known {
+		stamp, err := local()
+		switch err {
+		case nil:
+			prev = stamp
+		case db.ErrNotFound:
+			prev = ""
+		default:
+			return PlanEvent{}, err
+		}
+	}
+	if deleted {
+		if prev == "" {
+			pe.Action = ActionUnchanged
+		} else {
+			pe.Action = ActionDelete
+		}
+		state[key] = ""
+		return pe, nil
+	}
+	if at == "" {
+		pe.Action = ActionUnchanged
+		return pe, nil
+	}
+	switch {
+	case prev == "":
+		pe.Action = ActionCreate
+	case at > prev:
+		pe.Action = ActionUpdate
+	default:
+		pe.Action = ActionUnchanged
+	}
+	if at > prev {
+		state[key] = at
+	}
+	return pe, nil
+}
+
+// viewStamp renders t so that string order matches time order.
+func viewStamp(t time.Time) string {
+	return fmt.Sprintf("%020d", t.UnixNano())
+}
+
+// Replay re-pulls events after from and re-applies them, e.g. after fixing a
+// namespace key. Saved cursors are left untouched. With remote empty, every
+// enabled remote is replayed. It returns the number of events applied.
+func (s *Service) Replay(ctx context.Context, remote string, from time.Time) (int, error) {
+	names := s.enabledRemotes(remote)
+	if remote != "" && len(names) == 0 {
+		return 0, fmt.Errorf("remote %s is not configured or disabled", remote)
+	}
+	total := 0
+	sink := pullSink{consume: func(ctx context.Context, in []*pbmsg.RepEvent) error {
+		if err := s.applyPullBatch(ctx, in); err != nil {
+			return err
+		}
+		total += len(in)
+		return nil
+	}}
+	// The pull cursor is exclusive; step back so events at from are included.
+	after := from
+	if !after.IsZero() {
+		after = after.Add(-time.Nanosecond)
+	}
+	for _, name := range names {
+		rc, err := s.getRemoteConfig(name)
+		if err != nil {
+			return total, err
+		}
+		if err := s.pullRemote(ctx, rc, after, sink); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,181 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RemoteStatus is a snapshot of replication health for one remote.
+type RemoteStatus struct {
+	Name         string
+	URL          string
+	LastAttempt  time.Time
+	LastSuccess  time.Time
+	LastError    string
+	LastErrorAt  time.Time
+	Failures     int
+	PushedEvents int64
+	PulledEvents int64
+	PushedBytes  int64
+	PulledBytes  int64
+	Pending      int
+	// Backoff and NextRun come from the background loop; zero when idle.
+	Backoff time.Duration
+	NextRun time.Time
+}
+
+// remoteStats accumulates per-remote counters for the lifetime of the Service.
+type remoteStats struct {
+	lastAttempt  time.Time
+	lastSuccess  time.Time
+	lastError    string
+	lastErrorAt  time.Time
+	failures     int
+	pushedEvents int64
+	pulledEvents int64
+	pushedBytes  int64
+	pulledBytes  int64
+	// retryUntil is set from a remote's Retry-After; passes are skipped until then.
+	retryUntil time.Time
+}
+
+// statsLocked returns the stats record for name. Callers must hold s.mu.
+func (s *Service) statsLocked(name string) *remoteStats {
+	st, ok := s.stats[name]
+	if !ok {
+		st = &remoteStats{}
+		s.stats[name] = st
+	}
+	return st
+}
+
+func (s *Service) recordAttempt(name string, err error) {
+	now := time.Now().UTC()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.statsLocked(name)
+	st.lastAttempt = now
+	if err != nil {
+		st.lastError = err.Error()
+		st.lastErrorAt = now
+		st.failures++
+		var ra *RetryAfterError
+		if errors.As(err, &ra) {
+			st.retryUntil = now.Add(ra.Wait)
+		}
+		return
+	}
+	st.lastSuccess = now
+	st.failures = 0
+}
+
+// rateLimited returns a RetryAfterError while name is inside a Retry-After window.
+func (s *Service) rateLimited(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.stats[name]
+	if !ok {
+		return nil
+	}
+	if wait := time.Until(st.retryUntil); wait > 0 {
+		return &RetryAfterError{Remote: name, Wait: wait}
+	}
+	return nil
+}
+
+func (s *Service) recordPush(name string, events, bytes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.statsLocked(name)
+	st.pushedEvents += int64(events)
+	st.pushedBytes += int64(bytes)
+}
+
+func (s *Service) recordPull(name string, events int, bytes int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.statsLocked(name)
+	st.pulledEvents += int64(events)
+	st.pulledBytes += bytes
+}
+
+// setSchedule records the delay chosen by RunBackground before its next pass.
+func (s *Service) setSchedule(backoff time.Duration, next time.Time) {
+	s.mu.Lock()
+	s.backoff = backoff
+	s.nextRun = next
+	s.mu.Unlock()
+}
+
+// Status reports per-remote replication state for enabled remotes, optionally
+// restricted to onlyRemote. Pending counts local events not yet pushed.
+func (s *Service) Status(ctx context.Context, onlyRemote string) ([]RemoteStatus, error) {
+	queue, err := s.Queue(ctx, 1, onlyRemote)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]RemoteStatus, 0, len(queue))
+	for _, q := range queue {
+		rs := RemoteStatus{Name: q.Name, URL: q.URL, Pending: q.Pending, NextRun: s.nextRun}
+		if st, ok := s.stats[q.Name]; ok {
+			rs.LastAttempt = st.lastAttempt
+			rs.LastSuccess = st.lastSuccess
+			rs.LastError = st.lastError
+			rs.LastErrorAt = st.lastErrorAt
+			rs.Failures = st.failures
+			rs.PushedEvents = st.pushedEvents
+			rs.PulledEvents = st.pulledEvents
+			rs.PushedBytes = st.pushedBytes
+			rs.PulledBytes = st.pulledBytes
+			if st.failures > 0 {
+				rs.Backoff = s.backoff
+			}
+		}
+		out = append(out, rs)
+	}
+	return out, nil
+}
+
+// countingReader tallies bytes read from the wire.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// RetryAfterError reports that a remote rate-limited us (HTTP 429) and how
+// long it asked us to wait.
+type RetryAfterError struct {
+	Remote string
+	Wait   time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("remote %s rate limited; retry after %s", e.Remote, e.Wait)
+}
+
+// newRetryAfterError parses a Retry-After header (delay seconds or HTTP
+// date). Missing or malformed values default to one second.
+func newRetryAfterError(remote, header string, now time.Time) *RetryAfterError {
+	wait := time.Second
+	header = strings.TrimSpace(header)
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		wait = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(header); err == nil && t.After(now) {
+		wait = t.Sub(now)
+	}
+	return &RetryAfterError{Remote: remote, Wait: wait}
+}
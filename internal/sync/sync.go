@@ -1,12 +1,14 @@
 package sync
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -17,18 +19,22 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	gosync "sync"
 	"time"
 
 	"github.com/spf13/viper"
 
 	"golang.org/x/crypto/chacha20poly1305"
+	"google.golang.org/protobuf/encoding/protodelim"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/mithrel/ginkgo/internal/compress"
 	gcrypto "github.com/mithrel/ginkgo/internal/crypto"
 	"github.com/mithrel/ginkgo/internal/db"
 	pbmsg "github.com/mithrel/ginkgo/internal/ipc/pb"
 	"github.com/mithrel/ginkgo/internal/keys"
+	"github.com/mithrel/ginkgo/internal/metrics"
 	"github.com/mithrel/ginkgo/pkg/api"
 )
 
@@ -36,6 +42,17 @@ type Service struct {
 	cfg        *viper.Viper
 	store      *db.Store
 	httpClient *http.Client
+	// streamClient allows pull streams longer than httpClient's requests
+	// (see streamTimeout).
+	streamClient *http.Client
+
+	mu gosync.Mutex
+	// remoteEncodings caches the Accept-Encoding each remote advertised.
+	remoteEncodings map[string]string
+	// stats, backoff and nextRun back Status.
+	stats   map[string]*remoteStats
+	backoff time.Duration
+	nextRun time.Time
 }
 
 const (
@@ -43,6 +60,14 @@ const (
 	payloadTypePlainV1 = "plain_v1"
 	// payloadTypeEncV1 stores encrypted payloads (opaque to the server).
 	payloadTypeEncV1 = "enc_v1"
+
+	contentTypeProto          = "application/x-protobuf"
+	contentTypeProtoDelimited = "application/x-protobuf-delimited"
+	// pullCompleteTrailer marks a pull stream the server finished.
+	pullCompleteTrailer = "Ginkgo-Pull-Complete"
+	// streamTimeout bounds a whole pull stream. Streams checkpoint as they
+	// go, so one cut off resumes on the next sync.
+	streamTimeout = 5 * time.Minute
 )
 
 type remoteConfig struct {
@@ -50,6 +75,8 @@ type remoteConfig struct {
 	URL       string
 	Token     string
 	BatchSize int
+	// Compression is "auto", "zstd", "gzip" or "none".
+	Compression string
 }
 
 func New(cfg *viper.Viper, store *db.Store) *Service {
@@ -59,6 +86,9 @@ func New(cfg *viper.Viper, store *db.Store) *Service {
 		httpClient: &http.Client{
 			Timeout: 20 * time.Second,
 		},
+		streamClient:    &http.Client{Timeout: streamTimeout},
+		remoteEncodings: map[string]string{},
+		stats:           map[string]*remoteStats{},
 	}
 }
 
@@ -72,12 +102,29 @@ func (s *Service) remoteEnabled(name string) bool {
 	return url != ""
 }
 
+// enabledRemotes returns the sorted names of enabled remotes, restricted to
+// only when it is non-empty.
+func (s *Service) enabledRemotes(only string) []string {
+	remotes := s.cfg.GetStringMap("remotes")
+	names := make([]string, 0, len(remotes))
+	for name := range remotes {
+		if only != "" && name != only {
+			continue
+		}
+		if s.remoteEnabled(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (s *Service) SyncNow(ctx context.Context) error {
 	remotes := s.cfg.GetStringMap("remotes")
 	if len(remotes) == 0 {
 		return nil
 	}
-	var firstErr error
+	var errs []error
 
 	for name := range remotes {
 		if !s.remoteEnabled(name) {
@@ -87,29 +134,42 @@ func (s *Service) SyncNow(ctx context.Context) error {
 		// Load config once per remote
 		rc, err := s.getRemoteConfig(name)
 		if err != nil {
-			if firstErr == nil {
-				firstErr = err
-			}
+			s.recordAttempt(name, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := s.rateLimited(name); err != nil {
+			errs = append(errs, err)
 			continue
 		}
 
 		pushAfter, pullAfter := s.loadCursors(name)
 		log.Printf("sync: %s starting. pushAfter=%v pullAfter=%v", name, pushAfter, pullAfter)
 
-		if err := s.pushRemote(ctx, rc, pushAfter); err != nil {
+		var remoteErr error
+		start := time.Now()
+		err = s.pushRemote(ctx, rc, pushAfter)
+		metrics.SyncDuration.WithLabelValues(name, "push", metrics.Result(err)).Observe(time.Since(start).Seconds())
+		if err != nil {
 			log.Printf("sync: %s push failed: %v", name, err)
-			if firstErr == nil {
-				firstErr = err
-			}
+			remoteErr = err
 		}
-		if err := s.pullRemote(ctx, rc, pullAfter); err != nil {
+		start = time.Now()
+		err = s.pullRemote(ctx, rc, pullAfter, pullSink{consume: s.applyPullBatch, checkpoint: true})
+		metrics.SyncDuration.WithLabelValues(name, "pull", metrics.Result(err)).Observe(time.Since(start).Seconds())
+		if err != nil {
 			log.Printf("sync: %s pull failed: %v", name, err)
-			if firstErr == nil {
-				firstErr = err
+			if remoteErr == nil {
+				remoteErr = err
 			}
 		}
+		s.recordAttempt(name, remoteErr)
+		if remoteErr != nil {
+			errs = append(errs, remoteErr)
+		}
 	}
-	return firstErr
+	return errors.Join(errs...)
 }
 
 func (s *Service) getRemoteConfig(name string) (remoteConfig, error) {
@@ -128,16 +188,24 @@ func (s *Service) getRemoteConfig(name string) (remoteConfig, error) {
 	if batchSize <= 0 {
 		batchSize = 256
 	}
+	comp := strings.ToLower(strings.TrimSpace(s.cfg.GetString(base + "compression")))
+	if comp == "" {
+		comp = strings.ToLower(strings.TrimSpace(s.cfg.GetString("sync.compression")))
+	}
+	if comp == "" {
+		comp = "auto"
+	}
 
 	return remoteConfig{
-		Name:      name,
-		URL:       u,
-		Token:     token,
-		BatchSize: batchSize,
+		Name:        name,
+		URL:         u,
+		Token:       token,
+		BatchSize:   batchSize,
+		Compression: comp,
 	}, nil
 }
 
-func (s *Service) execRequest(ctx context.Context, method, url, token, contentType string, body []byte) ([]byte, int, error) {
+func (s *Service) doRequest(ctx context.Context, client *http.Client, method, url, token string, header http.Header, body []byte) (*http.Response, error) {
 	var r io.Reader
 	if body != nil {
 		r = bytes.NewReader(body)
@@ -145,56 +213,213 @@ func (s *Service) execRequest(ctx context.Context, method, url, token, contentTy
 
 	req, err := http.NewRequestWithContext(ctx, method, url, r)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-
-	req.Header.Set("Authorization", "Bearer "+token)
-	if contentType != "" {
-		req.Header.Set("Content-Type", contentType)
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
 	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return client.Do(req)
+}
 
-	resp, err := s.httpClient.Do(req)
+// execRequest performs a request and returns the decoded response body.
+// A 429 response is returned as a *RetryAfterError.
+func (s *Service) execRequest(ctx context.Context, rc remoteConfig, method, url string, header http.Header, body []byte) ([]byte, int, error) {
+	resp, err := s.doRequest(ctx, s.httpClient, method, url, rc.Token, header, body)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer resp.Body.Close()
+	s.noteRemoteEncodings(rc.Name, resp)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, resp.StatusCode, newRetryAfterError(rc.Name, resp.Header.Get("Retry-After"), time.Now())
+	}
 
-	respBody, _ := io.ReadAll(resp.Body)
+	rd, err := compress.NewReader(resp.Header.Get("Content-Encoding"), resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	defer rd.Close()
+	respBody, _ := io.ReadAll(rd)
 	return respBody, resp.StatusCode, nil
 }
 
-func (s *Service) pushRemote(ctx context.Context, rc remoteConfig, pushAfter time.Time) error {
-	evs, nextCur, err := s.store.Events.List(ctx, api.Cursor{After: pushAfter}, rc.BatchSize)
-	if err != nil {
-		return fmt.Errorf("list events: %w", err)
+// noteRemoteEncodings records the codings a remote advertises so later
+// pushes can be compressed.
+func (s *Service) noteRemoteEncodings(name string, resp *http.Response) {
+	accept := resp.Header.Get("Accept-Encoding")
+	if accept == "" {
+		return
 	}
-	if len(evs) == 0 {
-		return nil
+	s.mu.Lock()
+	s.remoteEncodings[name] = compress.Negotiate(accept)
+	s.mu.Unlock()
+}
+
+// pushEncoding picks the Content-Encoding for push bodies. In auto mode the
+// first push is sent uncompressed until the remote advertises support.
+func (s *Service) pushEncoding(rc remoteConfig) string {
+	switch rc.Compression {
+	case "none":
+		return compress.Identity
+	case "auto":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if enc, ok := s.remoteEncodings[rc.Name]; ok {
+			return enc
+		}
+		return compress.Identity
+	default:
+		return compress.Normalize(rc.Compression)
 	}
+}
 
-	pbBatch, err := s.eventsToProto(evs)
-	if err != nil {
-		return err
+// pushRemote pushes events after pushAfter in BatchSize batches. Events the
+// remote rejects for good are reported once the rest have been pushed.
+func (s *Service) pushRemote(ctx context.Context, rc remoteConfig, pushAfter time.Time) error {
+	var rejected []error
+	for {
+		evs, nextCur, err := s.store.Events.List(ctx, api.Cursor{After: pushAfter}, rc.BatchSize)
+		if err != nil {
+			return fmt.Errorf("list events: %w", err)
+		}
+		if len(evs) == 0 {
+			return errors.Join(rejected...)
+		}
+
+		pbBatch, err := s.eventsToProto(evs)
+		if err != nil {
+			return err
+		}
+		body, err := proto.Marshal(pbBatch)
+		if err != nil {
+			return err
+		}
+		res, n, err := s.pushBody(ctx, rc, body)
+		if err != nil {
+			return err
+		}
+		s.recordPush(rc.Name, len(evs), n)
+
+		accepted, rejectErr := pushOutcome(rc.Name, res)
+		if accepted < len(evs) {
+			// Hold the cursor before the first retryable rejection.
+			if accepted > 0 {
+				s.savePushAfter(rc.Name, evs[accepted-1].Time)
+			}
+			return errors.Join(append(rejected, rejectErr)...)
+		}
+		newPush := nextCur.After
+		if newPush.IsZero() {
+			newPush = evs[len(evs)-1].Time
+		}
+		s.savePushAfter(rc.Name, newPush)
+		if rejectErr != nil {
+			rejected = append(rejected, rejectErr)
+		}
+		if len(evs) < rc.BatchSize {
+			return errors.Join(rejected...)
+		}
+		pushAfter = newPush
+	}
+}
+
+// pushOutcome inspects per-item statuses. It returns how many leading events
+// may be considered done (stopping at the first retryable rejection) and an
+// error summarizing any rejections.
+func pushOutcome(remote string, res *pbmsg.PushResult) (int, error) {
+	items := res.GetItems()
+	accepted := len(items)
+	var rejected []string
+	for i, it := range items {
+		if it.GetOk() {
+			continue
+		}
+		rejected = append(rejected, fmt.Sprintf("%s: %s", it.GetId(), it.GetMsg()))
+		if it.GetRetry() && i < accepted {
+			accepted = i
+		}
+	}
+	if len(rejected) == 0 {
+		return accepted, nil
 	}
-	body, _ := proto.Marshal(pbBatch)
+	return accepted, fmt.Errorf("remote %s rejected %d events: %s", remote, len(rejected), strings.Join(rejected, "; "))
+}
 
-	respBody, code, err := s.execRequest(ctx, http.MethodPost, rc.URL+"/v1/replicate/push", rc.Token, "application/x-protobuf", body)
+// pushBody sends one encoded PushBatch, falling back to an uncompressed body
+// when the remote rejects the chosen coding. It returns the remote's result
+// and the bytes sent.
+func (s *Service) pushBody(ctx context.Context, rc remoteConfig, body []byte) (*pbmsg.PushResult, int, error) {
+	enc := s.pushEncoding(rc)
+	payload := body
+	if enc != compress.Identity {
+		var err error
+		if payload, err = compress.Encode(enc, body); err != nil {
+			return nil, 0, err
+		}
+	}
+	header := http.Header{}
+	header.Set("Content-Type", contentTypeProto)
+	if rc.Compression != "none" {
+		header.Set("Accept-Encoding", compress.AcceptHeader())
+	}
+	if enc != compress.Identity {
+		header.Set("Content-Encoding", enc)
+	}
+	respBody, code, err := s.execRequest(ctx, rc, http.MethodPost, rc.URL+"/v1/replicate/push", header, payload)
 	if err != nil {
-		return err
+		return nil, 0, err
+	}
+	if code == http.StatusUnsupportedMediaType && enc != compress.Identity {
+		s.mu.Lock()
+		s.remoteEncodings[rc.Name] = compress.Identity
+		s.mu.Unlock()
+		header.Del("Content-Encoding")
+		payload = body
+		respBody, code, err = s.execRequest(ctx, rc, http.MethodPost, rc.URL+"/v1/replicate/push", header, body)
+		if err != nil {
+			return nil, 0, err
+		}
 	}
 	if code >= 300 {
-		return fmt.Errorf("remote %s push failed: %s", rc.Name, strings.TrimSpace(string(respBody)))
+		return nil, 0, fmt.Errorf("remote %s push failed: %s", rc.Name, strings.TrimSpace(string(respBody)))
+	}
+	var res pbmsg.PushResult
+	if err := proto.Unmarshal(respBody, &res); err != nil {
+		return nil, 0, fmt.Errorf("remote %s push result: %w", rc.Name, err)
 	}
+	return &res, len(payload), nil
+}
+
+// pullSink consumes pulled events. With checkpoint set, the pull cursor is
+// saved and transfer stats are recorded as batches are consumed; dry runs and
+// replays leave both alone.
+type pullSink struct {
+	consume    func(context.Context, []*pbmsg.RepEvent) error
+	checkpoint bool
+}
 
-	newPush := nextCur.After
-	if newPush.IsZero() && len(evs) > 0 {
-		newPush = evs[len(evs)-1].Time
+func (s *Service) pullRemote(ctx context.Context, rc remoteConfig, pullAfter time.Time, sink pullSink) error {
+	for {
+		next, n, streamed, err := s.pullOnce(ctx, rc, pullAfter, sink)
+		if err != nil {
+			return err
+		}
+		// A stream carries every remaining event; paged responses are
+		// repeated until the remote has nothing left to send.
+		if streamed || n == 0 || !next.After(pullAfter) {
+			return nil
+		}
+		pullAfter = next
 	}
-	s.savePushAfter(rc.Name, newPush)
-	return nil
 }
 
-func (s *Service) pullRemote(ctx context.Context, rc remoteConfig, pullAfter time.Time) error {
+// pullOnce issues a single pull request and returns the advanced cursor. Remotes that support it answer with
+// a length-delimited stream which is applied in BatchSize chunks; others
+// answer with a page of up to BatchSize events.
+func (s *Service) pullOnce(ctx context.Context, rc remoteConfig, pullAfter time.Time, sink pullSink) (time.Time, int, bool, error) {
 	q := url.Values{}
 	q.Set("limit", strconv.Itoa(rc.BatchSize))
 	if !pullAfter.IsZero() {
@@ -204,28 +429,68 @@ func (s *Service) pullRemote(ctx context.Context, rc remoteConfig, pullAfter tim
 	pullURL := rc.URL + "/v1/replicate/pull?" + q.Encode()
 	log.Printf("sync: pulling %s", pullURL)
 
-	respBody, code, err := s.execRequest(ctx, http.MethodGet, pullURL, rc.Token, "", nil)
+	header := http.Header{}
+	header.Set("Accept", contentTypeProtoDelimited+", "+contentTypeProto)
+	if rc.Compression != "none" {
+		header.Set("Accept-Encoding", compress.AcceptHeader())
+	}
+	resp, err := s.doRequest(ctx, s.streamClient, http.MethodGet, pullURL, rc.Token, header, nil)
 	if err != nil {
-		return err
+		return pullAfter, 0, false, err
 	}
+	defer resp.Body.Close()
+	s.noteRemoteEncodings(rc.Name, resp)
 
-	if code == http.StatusNotImplemented {
-		return nil
+	if resp.StatusCode == http.StatusNotImplemented {
+		return pullAfter, 0, false, nil
 	}
-	if code >= 300 {
-		return fmt.Errorf("remote %s pull failed: %s", rc.Name, strings.TrimSpace(string(respBody)))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return pullAfter, 0, false, newRetryAfterError(rc.Name, resp.Header.Get("Retry-After"), time.Now())
+	}
+	wire := &countingReader{r: resp.Body}
+	rd, err := compress.NewReader(resp.Header.Get("Content-Encoding"), wire)
+	if err != nil {
+		return pullAfter, 0, false, err
+	}
+	defer rd.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(rd, 4096))
+		return pullAfter, 0, false, fmt.Errorf("remote %s pull failed: %s", rc.Name, strings.TrimSpace(string(msg)))
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), contentTypeProtoDelimited) {
+		n, err := s.applyPullStream(ctx, rc, rd, sink)
+		if err == nil {
+			// Trailers arrive after the body; read it to the end first.
+			_, _ = io.Copy(io.Discard, wire)
+			if resp.Trailer.Get(pullCompleteTrailer) == "" {
+				err = fmt.Errorf("remote %s pull stream ended early after %d events", rc.Name, n)
+			}
+		}
+		log.Printf("sync: streamed %d events from %s", n, rc.Name)
+		if sink.checkpoint {
+			s.recordPull(rc.Name, n, wire.n)
+		}
+		return pullAfter, n, true, err
 	}
 
+	respBody, err := io.ReadAll(rd)
+	if err != nil {
+		return pullAfter, 0, false, err
+	}
 	var pr pbmsg.PullResult
 	if err := proto.Unmarshal(respBody, &pr); err != nil {
-		return err
+		return pullAfter, 0, false, err
 	}
 	log.Printf("sync: pulled %d events from %s", len(pr.Events), rc.Name)
+	if sink.checkpoint {
+		s.recordPull(rc.Name, len(pr.Events), wire.n)
+	}
 	if len(pr.Events) == 0 {
-		return nil
+		return pullAfter, 0, false, nil
 	}
-	if err := s.applyPullBatch(ctx, pr.Events); err != nil {
-		return err
+	if err := sink.consume(ctx, pr.Events); err != nil {
+		return pullAfter, 0, false, err
 	}
 
 	// Determine next cursor
@@ -238,10 +503,51 @@ func (s *Service) pullRemote(ctx context.Context, rc remoteConfig, pullAfter tim
 		}
 	}
 
-	if !cur.IsZero() {
+	if sink.checkpoint && !cur.IsZero() {
 		s.savePullAfter(rc.Name, cur)
 	}
-	return nil
+	return cur, len(pr.Events), false, nil
+}
+
+// applyPullStream reads RepEvent frames and hands them to sink in BatchSize
+// chunks, advancing the pull cursor after each chunk so an interrupted stream
+// resumes where it stopped.
+func (s *Service) applyPullStream(ctx context.Context, rc remoteConfig, r io.Reader, sink pullSink) (int, error) {
+	br := bufio.NewReader(r)
+	batch := make([]*pbmsg.RepEvent, 0, rc.BatchSize)
+	total := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sink.consume(ctx, batch); err != nil {
+			return err
+		}
+		if t := batch[len(batch)-1].GetTime(); sink.checkpoint && t != nil {
+			s.savePullAfter(rc.Name, t.AsTime())
+		}
+		total += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		var ev pbmsg.RepEvent
+		if err := protodelim.UnmarshalFrom(br, &ev); err != nil {
+			if errors.Is(err, io.EOF) {
+				return total, flush()
+			}
+			if ferr := flush(); ferr != nil {
+				return total, ferr
+			}
+			return total, fmt.Errorf("remote %s pull stream: %w", rc.Name, err)
+		}
+		batch = append(batch, &ev)
+		if len(batch) >= rc.BatchSize {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+	}
 }
 
 // eventsToProto handles verbose mapping logic
@@ -254,6 +560,12 @@ func (s *Service) eventsToProto(evs []api.Event) (*pbmsg.PushBatch, error) {
 		if ns == "" && e.Entry != nil {
 			ns = e.Entry.Namespace
 		}
+		if ns == "" && e.View != nil {
+			ns = e.View.Namespace
+		}
+		if ns == "" && e.Reminder != nil {
+			ns = e.Reminder.Namespace
+		}
 		payloadType := e.PayloadType
 		payload := e.Payload
 		if payloadType == "" || len(payload) == 0 {
@@ -330,14 +642,9 @@ func (s *Service) repEventToAPI(pev *pbmsg.RepEvent) (api.Event, error) {
 	switch ev.PayloadType {
 	case payloadTypePlainV1:
 		if len(ev.Payload) > 0 {
-			entry, ns, err := decodePlainPayload(ev.Type, ev.Payload)
-			if err != nil {
+			if err := decodePlainPayload(&ev, ev.Payload); err != nil {
 				return api.Event{}, err
 			}
-			ev.Entry = entry
-			if ev.Namespace == "" {
-				ev.Namespace = ns
-			}
 		}
 	case payloadTypeEncV1:
 		if len(ev.Payload) > 0 {
@@ -345,14 +652,9 @@ func (s *Service) repEventToAPI(pev *pbmsg.RepEvent) (api.Event, error) {
 			if err != nil {
 				return api.Event{}, err
 			}
-			entry, ns, err := decodePlainPayload(ev.Type, plain)
-			if err != nil {
+			if err := decodePlainPayload(&ev, plain); err != nil {
 				return api.Event{}, err
 			}
-			ev.Entry = entry
-			if ev.Namespace == "" {
-				ev.Namespace = ns
-			}
 		}
 	}
 	return ev, nil
@@ -371,6 +673,7 @@ func (s *Service) applyPullBatch(ctx context.Context, in []*pbmsg.RepEvent) erro
 	if err := s.store.ApplyReplicationBatch(ctx, evs); err != nil && err != db.ErrConflict && err != db.ErrNotFound {
 		return err
 	}
+	metrics.EventsApplied.Add(float64(len(evs)))
 	return nil
 }
 
@@ -382,7 +685,19 @@ func encodePlainPayload(ev api.Event, ns string) (string, []byte, error) {
 		}
 		b, err := json.Marshal(ev.Entry)
 		return payloadTypePlainV1, b, err
-	case api.EventDelete:
+	case api.EventViewUpsert:
+		if ev.View == nil {
+			return "", nil, fmt.Errorf("%s view upsert requires view", payloadTypePlainV1)
+		}
+		b, err := json.Marshal(ev.View)
+		return payloadTypePlainV1, b, err
+	case api.EventReminderUpsert:
+		if ev.Reminder == nil {
+			return "", nil, fmt.Errorf("%s reminder upsert requires reminder", payloadTypePlainV1)
+		}
+		b, err := json.Marshal(ev.Reminder)
+		return payloadTypePlainV1, b, err
+	case api.EventDelete, api.EventViewDelete, api.EventReminderDelete:
 		dp := struct {
 			ID        string `json:"id"`
 			Namespace string `json:"namespace"`
@@ -394,26 +709,45 @@ func encodePlainPayload(ev api.Event, ns string) (string, []byte, error) {
 	}
 }
 
-func decodePlainPayload(evType api.EventType, payload []byte) (*api.Entry, string, error) {
-	switch evType {
+// decodePlainPayload fills ev.Entry, ev.View or ev.Reminder from a plain_v1
+// payload and defaults ev.Namespace to the namespace it carries.
+func decodePlainPayload(ev *api.Event, payload []byte) error {
+	var ns string
+	switch ev.Type {
 	case api.EventUpsert:
 		var e api.Entry
 		if err := json.Unmarshal(payload, &e); err != nil {
-			return nil, "", err
+			return err
+		}
+		ev.Entry, ns = &e, e.Namespace
+	case api.EventViewUpsert:
+		var v api.View
+		if err := json.Unmarshal(payload, &v); err != nil {
+			return err
+		}
+		ev.View, ns = &v, v.Namespace
+	case api.EventReminderUpsert:
+		var r api.Reminder
+		if err := json.Unmarshal(payload, &r); err != nil {
+			return err
 		}
-		return &e, e.Namespace, nil
-	case api.EventDelete:
+		ev.Reminder, ns = &r, r.Namespace
+	case api.EventDelete, api.EventViewDelete, api.EventReminderDelete:
 		var dp struct {
 			ID        string `json:"id"`
 			Namespace string `json:"namespace"`
 		}
 		if err := json.Unmarshal(payload, &dp); err != nil {
-			return nil, "", err
+			return err
 		}
-		return nil, dp.Namespace, nil
+		ns = dp.Namespace
 	default:
-		return nil, "", fmt.Errorf("unknown event type %q", evType)
+		return fmt.Errorf("unknown event type %q", ev.Type)
 	}
+	if ev.Namespace == "" {
+		ev.Namespace = ns
+	}
+	return nil
 }
 
 type encryptedPayloadV1 struct {
@@ -713,7 +1047,8 @@ func (s *Service) RunBackground(ctx context.Context) {
 	}()
 	next := base
 	for {
-		if err := s.SyncNow(ctx); err != nil {
+		err := s.SyncNow(ctx)
+		if err != nil {
 			step := fib()
 			max := s.cfg.GetDuration("sync.max_backoff")
 			if max == 0 {
@@ -727,6 +1062,12 @@ func (s *Service) RunBackground(ctx context.Context) {
 			next = base
 			fib = func() func() int { a, b := 1, 1; return func() int { a, b = b, a+b; return a } }()
 		}
+		// Never retry sooner than a rate-limited remote asked us to.
+		var ra *RetryAfterError
+		if errors.As(err, &ra) && ra.Wait > next {
+			next = ra.Wait
+		}
+		s.setSchedule(next, time.Now().Add(next))
 		select {
 		case <-ctx.Done():
 			return
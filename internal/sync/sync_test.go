@@ -3,6 +3,9 @@ package sync_test
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
@@ -198,3 +201,457 @@ func TestSyncE2EEPayload(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "Secret", got.Title)
 }
+
+func TestSyncPagedCompressed(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_paged")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	client1Store := setupDB(t, "client1_paged")
+	client1Sync := setupSyncServiceWithConfig(t, client1Store, ts.URL, token, t.TempDir(), func(v *viper.Viper) {
+		v.Set("sync.compression", "zstd")
+	})
+	client2Store := setupDB(t, "client2_paged")
+	client2Sync := setupSyncServiceWithConfig(t, client2Store, ts.URL, token, t.TempDir(), func(v *viper.Viper) {
+		v.Set("sync.compression", "gzip")
+	})
+
+	// More notes than sync.batch_size (10) to exercise multiple push batches.
+	const total = 35
+	now := time.Now()
+	for i := 0; i < total; i++ {
+		e := api.Entry{ID: fmt.Sprintf("n%02d", i), Title: fmt.Sprintf("Note %d", i), Body: "Body", CreatedAt: now, UpdatedAt: now}
+		_, err := client1Store.Entries.CreateEntry(ctx, e)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, client1Sync.SyncNow(ctx))
+	evs, _, err := serverStore.Events.List(ctx, api.Cursor{}, 0)
+	require.NoError(t, err)
+	require.Len(t, evs, total)
+
+	require.NoError(t, client2Sync.SyncNow(ctx))
+	entries, _, err := client2Store.Entries.ListEntries(ctx, api.ListQuery{Limit: 100})
+	require.NoError(t, err)
+	require.Len(t, entries, total)
+}
+
+func TestSyncPushBodyTooLarge(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_limit")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	srvCfg.Set("server.max_body_bytes", 64)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	clientStore := setupDB(t, "client_limit")
+	clientSync := setupSyncServiceWithConfig(t, clientStore, ts.URL, token, t.TempDir(), func(v *viper.Viper) {
+		v.Set("sync.compression", "none")
+	})
+
+	e := api.Entry{ID: "big", Title: "Big", Body: string(make([]byte, 512)), CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	_, err := clientStore.Entries.CreateEntry(ctx, e)
+	require.NoError(t, err)
+
+	err = clientSync.SyncNow(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sync.batch_size")
+}
+
+func TestSyncStatus(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_status")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	clientStore := setupDB(t, "client_status")
+	clientSync := setupSyncService(t, clientStore, ts.URL, token, t.TempDir())
+
+	sts, err := clientSync.Status(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, sts, 1)
+	require.True(t, sts[0].LastAttempt.IsZero())
+
+	_, err = clientStore.Entries.CreateEntry(ctx, api.Entry{ID: "s1", Title: "S", Body: "B", CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	require.NoError(t, err)
+	sts, err = clientSync.Status(ctx, "origin")
+	require.NoError(t, err)
+	require.Equal(t, 1, sts[0].Pending)
+
+	require.NoError(t, clientSync.SyncNow(ctx))
+	sts, err = clientSync.Status(ctx, "origin")
+	require.NoError(t, err)
+	require.Equal(t, "origin", sts[0].Name)
+	require.False(t, sts[0].LastSuccess.IsZero())
+	require.Zero(t, sts[0].Failures)
+	require.Zero(t, sts[0].Pending)
+	require.EqualValues(t, 1, sts[0].PushedEvents)
+	require.Positive(t, sts[0].PushedBytes)
+	require.EqualValues(t, 1, sts[0].PulledEvents)
+	require.Positive(t, sts[0].PulledBytes)
+
+	// A bad token makes the next pass fail and records the error.
+	badSync := setupSyncService(t, clientStore, ts.URL, "wrong", t.TempDir())
+	require.Error(t, badSync.SyncNow(ctx))
+	sts, err = badSync.Status(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, sts[0].Failures)
+	require.NotEmpty(t, sts[0].LastError)
+	require.True(t, sts[0].LastSuccess.IsZero())
+}
+
+func TestSyncPlanAndReplay(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_plan")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	client1Store := setupDB(t, "client1_plan")
+	client1Sync := setupSyncService(t, client1Store, ts.URL, token, t.TempDir())
+	client2Store := setupDB(t, "client2_plan")
+	client2Sync := setupSyncServiceWithConfig(t, client2Store, ts.URL, token, t.TempDir(), func(v *viper.Viper) {
+		// Not enabled yet: a dry run must still be possible by name.
+		v.Set("remotes.origin.enabled", false)
+	})
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	shared := api.Entry{ID: "shared", Title: "Shared", Body: "Same", CreatedAt: at, UpdatedAt: at}
+	_, err := client1Store.Entries.CreateEntry(ctx, shared)
+	require.NoError(t, err)
+	_, err = client1Store.Entries.CreateEntry(ctx, api.Entry{ID: "remote_only", Title: "Remote", Body: "R", CreatedAt: at, UpdatedAt: at})
+	require.NoError(t, err)
+	require.NoError(t, client1Sync.SyncNow(ctx))
+
+	_, err = client2Store.Entries.CreateEntry(ctx, shared)
+	require.NoError(t, err)
+	_, err = client2Store.Entries.CreateEntry(ctx, api.Entry{ID: "local_only", Title: "Local", Body: "L", CreatedAt: at, UpdatedAt: at})
+	require.NoError(t, err)
+
+	plans, err := client2Sync.Plan(ctx, "origin", 0)
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	p := plans[0]
+	require.Equal(t, 2, p.Push)
+	require.Equal(t, 1, p.Create)
+	require.Equal(t, 1, p.Unchanged)
+	require.Zero(t, p.Update+p.Delete+p.Errors)
+
+	// Nothing was written by the dry run.
+	_, err = client2Store.Entries.GetEntry(ctx, "remote_only")
+	require.ErrorIs(t, err, db.ErrNotFound)
+	evs, _, err := serverStore.Events.List(ctx, api.Cursor{}, 0)
+	require.NoError(t, err)
+	require.Len(t, evs, 2)
+
+	client2Enabled := setupSyncService(t, client2Store, ts.URL, token, t.TempDir())
+	n, err := client2Enabled.Replay(ctx, "origin", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	got, err := client2Store.Entries.GetEntry(ctx, "remote_only")
+	require.NoError(t, err)
+	require.Equal(t, "Remote", got.Title)
+}
+
+func TestSyncNamespaceQuota(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_quota")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	srvCfg.Set("namespaces.work.max_events", 1)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	clientStore := setupDB(t, "client_quota")
+	clientSync := setupSyncService(t, clientStore, ts.URL, token, t.TempDir())
+
+	now := time.Now()
+	for _, id := range []string{"q1", "q2"} {
+		_, err := clientStore.Entries.CreateEntry(ctx, api.Entry{ID: id, Title: id, Body: "B", Namespace: "work", CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+
+	err := clientSync.SyncNow(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "quota exceeded")
+	evs, _, err := serverStore.Events.List(ctx, api.Cursor{}, 0)
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+
+	// The rejection is final: the next sync neither resends q2 nor
+	// duplicates q1.
+	srvCfg.Set("namespaces.work.max_events", 10)
+	require.NoError(t, clientSync.SyncNow(ctx))
+	evs, _, err = serverStore.Events.List(ctx, api.Cursor{}, 0)
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+	require.Equal(t, "q1", evs[0].ID)
+}
+
+func TestSyncQuotaRejectionSparesOtherNamespaces(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_quota_ns")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	srvCfg.Set("namespaces.work.max_events", 1)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	clientStore := setupDB(t, "client_quota_ns")
+	clientSync := setupSyncService(t, clientStore, ts.URL, token, t.TempDir())
+	create := func(id, ns string) {
+		now := time.Now()
+		_, err := clientStore.Entries.CreateEntry(ctx, api.Entry{ID: id, Namespace: ns, Title: id, Body: "B", CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	stored := func() map[string]int {
+		evs, _, err := serverStore.Events.List(ctx, api.Cursor{}, 0)
+		require.NoError(t, err)
+		seen := map[string]int{}
+		for _, ev := range evs {
+			seen[ev.ID]++
+		}
+		return seen
+	}
+
+	// One batch mixing namespaces: w2 exceeds work's quota, h1 and h2 in
+	// home come after it.
+	create("w1", "work")
+	create("h1", "home")
+	create("w2", "work")
+	create("h2", "home")
+	err := clientSync.SyncNow(ctx)
+	require.ErrorContains(t, err, "quota exceeded")
+	require.Equal(t, map[string]int{"w1": 1, "h1": 1, "h2": 1}, stored())
+
+	// The rejection does not stop later pushes.
+	create("h3", "home")
+	require.NoError(t, clientSync.SyncNow(ctx))
+	require.Equal(t, map[string]int{"w1": 1, "h1": 1, "h2": 1, "h3": 1}, stored())
+}
+
+func TestSyncHonoursRetryAfter(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_ratelimit")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	srvCfg.Set("server.rate_limit.rps", 0.01)
+	srvCfg.Set("server.rate_limit.burst", 1)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	clientStore := setupDB(t, "client_ratelimit")
+	clientSync := setupSyncService(t, clientStore, ts.URL, token, t.TempDir())
+	_, err := clientStore.Entries.CreateEntry(ctx, api.Entry{ID: "r1", Title: "R", Body: "B", CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	require.NoError(t, err)
+
+	// The push uses the only token; the pull is rejected with 429.
+	err = clientSync.SyncNow(ctx)
+	var ra *sync.RetryAfterError
+	require.ErrorAs(t, err, &ra)
+	require.Greater(t, ra.Wait, 90*time.Second)
+
+	// Within the window the client does not contact the remote again.
+	err = clientSync.SyncNow(ctx)
+	require.ErrorAs(t, err, &ra)
+	sts, err := clientSync.Status(ctx, "origin")
+	require.NoError(t, err)
+	require.Equal(t, 1, sts[0].Failures)
+	require.Contains(t, sts[0].LastError, "rate limited")
+}
+
+func TestSyncViews(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+	serverStore := setupDB(t, "server")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	client1Store := setupDB(t, "client1")
+	client1Sync := setupSyncService(t, client1Store, ts.URL, token, t.TempDir())
+	client2Store := setupDB(t, "client2")
+	client2Sync := setupSyncService(t, client2Store, ts.URL, token, t.TempDir())
+
+	_, err := client1Store.Views.SaveView(ctx, api.View{Name: "meetings", Namespace: "default", TagsAll: []string{"work", "meeting"}, Since: "7d"})
+	require.NoError(t, err)
+	require.NoError(t, client1Sync.SyncNow(ctx))
+
+	plans, err := client2Sync.Plan(ctx, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, plans[0].Create)
+	require.Equal(t, api.EventViewUpsert, api.EventType(plans[0].Events[0].Type))
+
+	require.NoError(t, client2Sync.SyncNow(ctx))
+	got, err := client2Store.Views.GetView(ctx, "default", "meetings")
+	require.NoError(t, err)
+	require.Equal(t, []string{"work", "meeting"}, got.TagsAll)
+	require.Equal(t, "7d", got.Since)
+
+	require.NoError(t, client2Store.Views.DeleteView(ctx, "default", "meetings"))
+	require.NoError(t, client2Sync.SyncNow(ctx))
+	require.NoError(t, client1Sync.SyncNow(ctx))
+	_, err = client1Store.Views.GetView(ctx, "default", "meetings")
+	require.ErrorIs(t, err, db.ErrNotFound)
+}
+
+func TestSyncReminders(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+	serverStore := setupDB(t, "server")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	ts := httptest.NewServer(server.New(srvCfg, serverStore).Router())
+	defer ts.Close()
+
+	client1Store := setupDB(t, "client1")
+	client1Sync := setupSyncService(t, client1Store, ts.URL, token, t.TempDir())
+	client2Store := setupDB(t, "client2")
+	client2Sync := setupSyncService(t, client2Store, ts.URL, token, t.TempDir())
+
+	now := time.Now().UTC()
+	e, err := client1Store.Entries.CreateEntry(ctx, api.Entry{ID: api.NewID(), Version: 1, Title: "call back", Namespace: "default", CreatedAt: now, UpdatedAt: now})
+	require.NoError(t, err)
+	at := now.Add(24 * time.Hour).Truncate(time.Second)
+	_, err = client1Store.Reminders.SetReminder(ctx, api.Reminder{NoteID: e.ID, At: at, Device: "laptop"})
+	require.NoError(t, err)
+	require.NoError(t, client1Sync.SyncNow(ctx))
+
+	plans, err := client2Sync.Plan(ctx, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, plans[0].Create)
+
+	require.NoError(t, client2Sync.SyncNow(ctx))
+	got, err := client2Store.Reminders.GetReminder(ctx, e.ID)
+	require.NoError(t, err)
+	require.True(t, at.Equal(got.At))
+	require.Equal(t, "laptop", got.Device)
+	require.Equal(t, "call back", got.Title)
+
+	require.NoError(t, client2Store.Reminders.DeleteReminder(ctx, e.ID))
+	require.NoError(t, client2Sync.SyncNow(ctx))
+	require.NoError(t, client1Sync.SyncNow(ctx))
+	_, err = client1Store.Reminders.GetReminder(ctx, e.ID)
+	require.ErrorIs(t, err, db.ErrNotFound)
+}
+
+// proxyPull serves the real router but lets rewrite change each pull
+// response before it reaches the client.
+func proxyPull(t *testing.T, h http.Handler, rewrite func(r *http.Request, rec *httptest.ResponseRecorder, w http.ResponseWriter)) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/replicate/pull" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		rewrite(r, rec, w)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestSyncPagedPullSendsLimit(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_pagedpull")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	router := server.New(srvCfg, serverStore).Router()
+	var limits []string
+	// Without the delimited Accept the server answers with pages.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/replicate/pull" {
+			limits = append(limits, r.URL.Query().Get("limit"))
+			r.Header.Set("Accept", server.ContentTypeProto)
+		}
+		router.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	client1Store := setupDB(t, "client1_pagedpull")
+	client1Sync := setupSyncService(t, client1Store, ts.URL, token, t.TempDir())
+	const total = 25
+	now := time.Now()
+	for i := 0; i < total; i++ {
+		_, err := client1Store.Entries.CreateEntry(ctx, api.Entry{ID: fmt.Sprintf("p%02d", i), Title: "Note", CreatedAt: now, UpdatedAt: now})
+		require.NoError(t, err)
+	}
+	require.NoError(t, client1Sync.SyncNow(ctx))
+
+	client2Store := setupDB(t, "client2_pagedpull")
+	limits = nil
+	require.NoError(t, setupSyncService(t, client2Store, ts.URL, token, t.TempDir()).SyncNow(ctx))
+	entries, _, err := client2Store.Entries.ListEntries(ctx, api.ListQuery{Limit: 100})
+	require.NoError(t, err)
+	require.Len(t, entries, total)
+	// Pages of sync.batch_size (10), then an empty one.
+	require.Equal(t, []string{"10", "10", "10", "10"}, limits)
+}
+
+func TestSyncPullStreamCutShort(t *testing.T) {
+	ctx := context.Background()
+	token := "test-token"
+
+	serverStore := setupDB(t, "server_cut")
+	srvCfg := viper.New()
+	srvCfg.Set("auth.token", token)
+	router := server.New(srvCfg, serverStore).Router()
+	complete := true
+	// The proxy forwards the stream, and its trailer only when complete.
+	ts := proxyPull(t, router, func(r *http.Request, rec *httptest.ResponseRecorder, w http.ResponseWriter) {
+		res := rec.Result()
+		for k, vs := range res.Header {
+			w.Header()[k] = vs
+		}
+		w.Header().Del("Trailer")
+		if complete {
+			w.Header().Set("Trailer", server.PullCompleteTrailer)
+		}
+		w.WriteHeader(res.StatusCode)
+		_, _ = io.Copy(w, res.Body)
+		if v := res.Trailer.Get(server.PullCompleteTrailer); complete && v != "" {
+			w.Header().Set(server.PullCompleteTrailer, v)
+		}
+	})
+
+	client1Store := setupDB(t, "client1_cut")
+	_, err := client1Store.Entries.CreateEntry(ctx, api.Entry{ID: "c1", Title: "Note", CreatedAt: time.Now(), UpdatedAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, setupSyncService(t, client1Store, ts.URL, token, t.TempDir()).SyncNow(ctx))
+
+	client2Store := setupDB(t, "client2_cut")
+	client2Sync := setupSyncServiceWithConfig(t, client2Store, ts.URL, token, t.TempDir(), func(v *viper.Viper) {
+		v.Set("sync.compression", "none")
+	})
+	complete = false
+	err = client2Sync.SyncNow(ctx)
+	require.ErrorContains(t, err, "ended early")
+	complete = true
+	require.NoError(t, client2Sync.SyncNow(ctx))
+	_, err = client2Store.Entries.GetEntry(ctx, "c1")
+	require.NoError(t, err)
+}
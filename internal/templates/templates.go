@@ -0,0 +1,230 @@
+// Package templates loads and renders note templates.
+//
+// A template is written in the editor's format (Title:, Tags:, '---', then
+// the Markdown body); a template without '---' is all body. It is a
+// text/template with these values:
+//
+//	.Name .Namespace .Hostname  the template, target namespace and host
+//	.Date .Time .Weekday .Now   the current date ("2006-01-02"), time
+//	                            ("15:04"), weekday name and time.Time
+//	{{prompt "Question?"}}      an answer asked for before rendering
+//	{{stdin}} {{clipboard}}     piped input and the clipboard contents
+//
+// Templates come from files in the templates directory (<name>.md) or from
+// notes tagged with the template tag, whose title is the template's name.
+// Files win over notes of the same name.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/mithrel/ginkgo/internal/editor"
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+// Ext is the file extension of template files.
+const Ext = ".md"
+
+// Template is a named template source.
+type Template struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	// Origin is the file path, or "note <id>" for a note template.
+	Origin string `json:"origin"`
+}
+
+// Note is a rendered template. Fields are the custom fields of a
+// frontmatter template.
+type Note struct {
+	Title  string
+	Tags   []string
+	Fields map[string]any
+	Body   string
+}
+
+// Vars are the values a template renders with. Stdin and Clipboard are
+// called at most once, and only when the template uses them; nil reads as
+// empty.
+type Vars struct {
+	Name      string
+	Namespace string
+	Hostname  string
+	Now       time.Time
+	Stdin     func() (string, error)
+	Clipboard func() (string, error)
+}
+
+// Dir is the templates directory: templates.dir, else "templates" next to
+// the config file in use, else in the default config directory.
+func Dir(cfg *viper.Viper) string {
+	if d := strings.TrimSpace(cfg.GetString("templates.dir")); d != "" {
+		return d
+	}
+	if f := cfg.ConfigFileUsed(); f != "" {
+		return filepath.Join(filepath.Dir(f), "templates")
+	}
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" {
+		home, _ := os.UserHomeDir()
+		xdg = filepath.Join(home, ".config")
+	}
+	return filepath.Join(xdg, "ginkgo", "templates")
+}
+
+// LoadDir reads the <name>.md templates in dir. A missing dir has none.
+func LoadDir(dir string) ([]Template, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Ext))
+	if err != nil {
+		return nil, err
+	}
+	var out []Template
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Template{Name: strings.TrimSuffix(filepath.Base(p), Ext), Source: string(b), Origin: p})
+	}
+	return out, nil
+}
+
+// FromNotes turns notes into templates named by their titles.
+func FromNotes(entries []api.Entry) []Template {
+	var out []Template
+	for _, e := range entries {
+		if name := strings.TrimSpace(e.Title); name != "" {
+			out = append(out, Template{Name: name, Source: e.Body, Origin: "note " + e.ID})
+		}
+	}
+	return out
+}
+
+// Merge combines template sets sorted by name; for names that occur more
+// than once (case-insensitively) the earliest set wins.
+func Merge(sets ...[]Template) []Template {
+	seen := map[string]bool{}
+	var out []Template
+	for _, set := range sets {
+		for _, t := range set {
+			key := strings.ToLower(t.Name)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i].Name) < strings.ToLower(out[j].Name) })
+	return out
+}
+
+// Find returns the template called name, ignoring case.
+func Find(ts []Template, name string) (Template, bool) {
+	for _, t := range ts {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// Prompts returns the questions of t's prompt calls in order, without
+// reading stdin or the clipboard.
+func Prompts(t Template, v Vars) ([]string, error) {
+	var qs []string
+	seen := map[string]bool{}
+	v.Stdin, v.Clipboard = nil, nil
+	_, err := execute(t, v, func(q string) string {
+		if !seen[q] {
+			seen[q] = true
+			qs = append(qs, q)
+		}
+		return ""
+	})
+	return qs, err
+}
+
+// Render executes t with answers for its prompts (missing answers are
+// empty) and splits the result into title, tags and body, read from YAML
+// frontmatter when the result starts with one.
+func Render(t Template, v Vars, answers map[string]string) (Note, error) {
+	out, err := execute(t, v, func(q string) string { return answers[q] })
+	if err != nil {
+		return Note{}, err
+	}
+	if editor.DetectFormat(out) == editor.FormatFrontmatter {
+		n, err := editor.ParseFrontmatter(out)
+		if err != nil {
+			return Note{}, fmt.Errorf("template %s: %w", t.Name, err)
+		}
+		out := Note{Title: n.Title, Tags: n.Tags, Body: n.Body}
+		if len(n.Fields) > 0 {
+			out.Fields = n.Fields
+		}
+		return out, nil
+	}
+	if !hasSeparator(out) {
+		return Note{Body: strings.TrimSpace(out)}, nil
+	}
+	title, tags, body := editor.ParseEditedNote(out)
+	return Note{Title: title, Tags: tags, Body: body}, nil
+}
+
+func execute(t Template, v Vars, prompt func(string) string) (string, error) {
+	funcs := template.FuncMap{
+		"prompt":    prompt,
+		"stdin":     once(v.Stdin),
+		"clipboard": once(v.Clipboard),
+	}
+	tpl, err := template.New(t.Name).Funcs(funcs).Option("missingkey=error").Parse(t.Source)
+	if err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	data := map[string]any{
+		"Name":      t.Name,
+		"Namespace": v.Namespace,
+		"Hostname":  v.Hostname,
+		"Now":       v.Now,
+		"Date":      v.Now.Format("2006-01-02"),
+		"Time":      v.Now.Format("15:04"),
+		"Weekday":   v.Now.Weekday().String(),
+	}
+	var b bytes.Buffer
+	if err := tpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	return b.String(), nil
+}
+
+// once wraps read so that it runs at most once.
+func once(read func() (string, error)) func() (string, error) {
+	var done bool
+	var s string
+	var err error
+	return func() (string, error) {
+		if !done && read != nil {
+			s, err = read()
+			s = strings.TrimRight(s, "\n")
+		}
+		done = true
+		return s, err
+	}
+}
+
+func hasSeparator(s string) bool {
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "---" {
+			return true
+		}
+	}
+	return false
+}
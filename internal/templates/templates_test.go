@@ -0,0 +1,83 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mithrel/ginkgo/pkg/api"
+)
+
+const standup = `Title: Standup {{.Date}}
+Tags: standup, {{.Namespace}}
+---
+## Yesterday
+{{prompt "What did you do yesterday?"}}
+
+## Today
+{{prompt "What will you do today?"}}
+{{prompt "What did you do yesterday?"}}
+{{stdin}}`
+
+func TestRenderWithPrompts(t *testing.T) {
+	tpl := Template{Name: "standup", Source: standup}
+	reads := 0
+	v := Vars{
+		Namespace: "work",
+		Now:       time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC),
+		Stdin:     func() (string, error) { reads++; return "piped\n", nil },
+	}
+
+	qs, err := Prompts(tpl, v)
+	require.NoError(t, err)
+	require.Equal(t, []string{"What did you do yesterday?", "What will you do today?"}, qs)
+	require.Zero(t, reads)
+
+	n, err := Render(tpl, v, map[string]string{"What did you do yesterday?": "fixed sync"})
+	require.NoError(t, err)
+	require.Equal(t, "Standup 2025-03-10", n.Title)
+	require.Equal(t, []string{"standup", "work"}, n.Tags)
+	require.Equal(t, "## Yesterday\nfixed sync\n\n## Today\n\nfixed sync\npiped", n.Body)
+	require.Equal(t, 1, reads)
+
+	// Without a separator the whole template is body.
+	n, err = Render(Template{Name: "plain", Source: "Notes from {{.Weekday}}"}, v, nil)
+	require.NoError(t, err)
+	require.Empty(t, n.Title)
+	require.Equal(t, "Notes from Monday", n.Body)
+
+	// A template may be written with YAML frontmatter.
+	n, err = Render(Template{Name: "fm", Source: "---\ntitle: Retro {{.Date}}\ntags: [retro]\nmood: 3\n---\nWent well"}, v, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Retro 2025-03-10", n.Title)
+	require.Equal(t, []string{"retro"}, n.Tags)
+	require.Equal(t, map[string]any{"mood": 3}, n.Fields)
+	require.Equal(t, "Went well", n.Body)
+
+	_, err = Render(Template{Name: "bad", Source: "{{.Nope}}"}, v, nil)
+	require.ErrorContains(t, err, "template bad")
+}
+
+func TestLoadAndMerge(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "standup.md"), []byte("file"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("x"), 0o600))
+	files, err := LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	missing, err := LoadDir(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	notes := FromNotes([]api.Entry{{ID: "n1", Title: "Standup", Body: "note"}, {ID: "n2", Title: "retro", Body: "r"}})
+	all := Merge(files, notes)
+	require.Len(t, all, 2)
+	require.Equal(t, "retro", all[0].Name)
+	got, ok := Find(all, "STANDUP")
+	require.True(t, ok)
+	require.Equal(t, "file", got.Source)
+}
@@ -1,17 +1,43 @@
 package util
 
-import "github.com/sahilm/fuzzy"
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/sahilm/fuzzy"
+)
 
 // ScoreCompletions returns the top N matches for the input string from the candidates list.
-// TODO: Currently uses fuzzy matching; will incorporate frecency scoring in the future.
 func ScoreCompletions(input string, candidates []string, n int) []string {
+	return RankCompletions(input, candidates, nil, n)
+}
+
+// RankCompletions is ScoreCompletions with candidates boosted by their
+// frecency (see api.TagStat), so well-used candidates can outrank slightly
+// better matches. The boost grows with the logarithm of the frecency; with
+// empty input candidates are ordered by frecency alone.
+func RankCompletions(input string, candidates []string, frecency map[string]int, n int) []string {
 	if input == "" {
-		return candidates
+		out := candidates
+		if len(frecency) > 0 {
+			out = append([]string(nil), candidates...)
+			sort.SliceStable(out, func(i, j int) bool { return frecency[out[i]] > frecency[out[j]] })
+		}
+		if n > 0 && len(out) > n {
+			out = out[:n]
+		}
+		return out
 	}
 	matches := fuzzy.Find(input, candidates)
 	if len(matches) == 0 {
 		return nil
 	}
+	if len(frecency) > 0 {
+		for i := range matches {
+			matches[i].Score += frecencyBoost(frecency[matches[i].Str])
+		}
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	}
 
 	limit := n
 	if n <= 0 || len(matches) < limit {
@@ -24,3 +50,85 @@ func ScoreCompletions(input string, candidates []string, n int) []string {
 	}
 	return out
 }
+
+// frecencyBoost maps a frecency onto the fuzzy score scale: a tag used ten
+// times this week (1000) gains 21, about two first-character match bonuses.
+func frecencyBoost(f int) int {
+	if f <= 0 {
+		return 0
+	}
+	return 3 * bits.Len(uint(f/10))
+}
+
+// ClosestTerms returns up to n candidates within maxDist edits of input,
+// closest first. Ties are broken by the completion fuzzy score (so dropped
+// letters beat substitutions), then by freq (e.g. document frequency), then
+// alphabetically.
+func ClosestTerms(input string, freq map[string]int, maxDist, n int) []string {
+	in := []rune(input)
+	dist := map[string]int{}
+	var near []string
+	for term := range freq {
+		r := []rune(term)
+		if d := len(r) - len(in); d > maxDist || -d > maxDist || term == input {
+			continue
+		}
+		if d := editDistance(in, r); d <= maxDist {
+			dist[term] = d
+			near = append(near, term)
+		}
+	}
+	score := map[string]int{}
+	for _, m := range fuzzy.Find(input, near) {
+		score[m.Str] = m.Score
+	}
+	sort.Slice(near, func(i, j int) bool {
+		a, b := near[i], near[j]
+		if dist[a] != dist[b] {
+			return dist[a] < dist[b]
+		}
+		sa, oka := score[a]
+		sb, okb := score[b]
+		if oka != okb {
+			return oka
+		}
+		if sa != sb {
+			return sa > sb
+		}
+		if freq[a] != freq[b] {
+			return freq[a] > freq[b]
+		}
+		return a < b
+	})
+	if n > 0 && len(near) > n {
+		near = near[:n]
+	}
+	return near
+}
+
+// editDistance is the number of single-rune insertions, deletions,
+// substitutions and adjacent transpositions turning a into b (optimal string
+// alignment, computed three rows at a time).
+func editDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
@@ -7,22 +7,27 @@ import (
 	"time"
 )
 
-// parseTimeExpr parses relative ("2h", "3d", "2w", "1mo") and absolute
-// (RFC3339, "2006-01-02T15:04", "2006-01-02") time expressions.
+// parseTimeExpr parses relative ("2h", "3d", "2w", "1mo"), absolute
+// (RFC3339, "2006-01-02T15:04", "2006-01-02") and natural-language time
+// expressions (see parseNatural).
 func parseTimeExpr(s string, now time.Time) (time.Time, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
 		return time.Time{}, fmt.Errorf("empty time expression")
 	}
+	if t, ok := parseNatural(s, now); ok {
+		return t, nil
+	}
 
-	// Custom shorthands: mo (months), w (weeks), d (days)
+	// Custom shorthands: mo (months), w (weeks), d (days), counted in
+	// calendar days so a DST change does not shift the result by an hour
 	suffixes := []struct {
 		suffix string
 		apply  func(int) time.Time
 	}{
 		{"mo", func(n int) time.Time { return now.AddDate(0, -n, 0) }},
-		{"w", func(n int) time.Time { return now.Add(-time.Duration(n*7) * 24 * time.Hour) }},
-		{"d", func(n int) time.Time { return now.Add(-time.Duration(n) * 24 * time.Hour) }},
+		{"w", func(n int) time.Time { return now.AddDate(0, 0, -7*n) }},
+		{"d", func(n int) time.Time { return now.AddDate(0, 0, -n) }},
 	}
 	for _, sfx := range suffixes {
 		if strings.HasSuffix(s, sfx.suffix) {
@@ -53,6 +58,96 @@ func parseTimeExpr(s string, now time.Time) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid time expression: %q", s)
 }
 
+// ParseAt parses the time of a note: anything parseTimeExpr accepts, where
+// durations count back from now ("2h" is two hours ago).
+func ParseAt(s string, now time.Time) (time.Time, error) {
+	return parseTimeExpr(s, now)
+}
+
+// parseNatural parses, in now's location:
+//
+//	now, noon, midnight, 17:00, 5pm      today at that time
+//	today, yesterday, tomorrow           optionally with a clock ("yesterday 17:00")
+//	friday, last friday                  the latest Friday (today included),
+//	                                     or the one before today
+//	2025-03-01 17:00                     a date with a clock
+//	2025-W10, 2025-W10-5                 an ISO week's Monday, or its 5th day
+//
+// Days without a clock mean midnight.
+func parseNatural(s string, now time.Time) (time.Time, bool) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	loc := now.Location()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if s == "now" {
+		return now, true
+	}
+	if t, ok := parseISOWeek(s, loc); ok {
+		return t, true
+	}
+	if h, m, err := parseClock(s); err == nil {
+		return atClock(midnight, h, m), true
+	}
+
+	day, clock := splitClock(s)
+	var base time.Time
+	switch {
+	case day == "today":
+		base = midnight
+	case day == "yesterday":
+		base = midnight.AddDate(0, 0, -1)
+	case day == "tomorrow":
+		base = midnight.AddDate(0, 0, 1)
+	default:
+		name, last := strings.CutPrefix(day, "last ")
+		if wd, ok := parseWeekday(name); ok {
+			back := (int(now.Weekday()) - int(wd) + 7) % 7
+			if last && back == 0 {
+				back = 7
+			}
+			base = midnight.AddDate(0, 0, -back)
+			break
+		}
+		// A date needs a clock here; a bare date keeps its UTC meaning.
+		t, err := time.ParseInLocation("2006-01-02", day, loc)
+		if err != nil || clock == "" {
+			return time.Time{}, false
+		}
+		base = t
+	}
+	if clock == "" {
+		return base, true
+	}
+	h, m, _ := parseClock(clock)
+	return atClock(base, h, m), true
+}
+
+// parseISOWeek parses "2025-W10" (that week's Monday) and "2025-W10-5"
+// (its fifth day, Friday), with or without dashes.
+func parseISOWeek(s string, loc *time.Location) (time.Time, bool) {
+	s = strings.ReplaceAll(s, "-", "")
+	if len(s) != 7 && len(s) != 8 || s[4] != 'w' {
+		return time.Time{}, false
+	}
+	year, err1 := strconv.Atoi(s[:4])
+	week, err2 := strconv.Atoi(s[5:7])
+	day := 1
+	var err3 error
+	if len(s) == 8 {
+		day, err3 = strconv.Atoi(s[7:])
+	}
+	if err1 != nil || err2 != nil || err3 != nil || week < 1 || week > 53 || day < 1 || day > 7 {
+		return time.Time{}, false
+	}
+	// January 4th is always in week 1.
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	t := monday.AddDate(0, 0, 7*(week-1)+day-1)
+	if y, w := t.ISOWeek(); y != year || w != week {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
 // NormalizeTimeRange parses since/until (empty allowed) and swaps if reversed.
 func NormalizeTimeRange(since, until string) (string, string, error) {
 	now := time.Now()
@@ -84,3 +179,26 @@ func NormalizeTimeRange(since, until string) (string, string, error) {
 	}
 	return sStr, uStr, nil
 }
+
+// ParseDuration parses a Go duration or a whole number of days ("3d") or
+// weeks ("2w").
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for _, sfx := range []struct {
+		suffix string
+		unit   time.Duration
+	}{{"d", 24 * time.Hour}, {"w", 7 * 24 * time.Hour}} {
+		if numStr, ok := strings.CutSuffix(s, sfx.suffix); ok {
+			n, err := strconv.Atoi(numStr)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid %s duration: %q", sfx.suffix, s)
+			}
+			return time.Duration(n) * sfx.unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+	return d, nil
+}
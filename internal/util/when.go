@@ -0,0 +1,182 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultHour is the time of day used when a day is given without a clock.
+const defaultHour = 9
+
+// ParseWhen parses a future time expression relative to now:
+//
+//	in 2h, 90m, 3d, 1w          a duration from now
+//	today, tonight, tomorrow    optionally followed by a clock ("tomorrow 9am")
+//	monday ... sunday           the next such day, optionally with a clock
+//	9am, 9:30pm, 14:00, noon    today if still ahead, otherwise tomorrow
+//	RFC3339, "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"
+//
+// Days without a clock default to 9:00, "tonight" to 20:00. Times are in
+// now's location.
+func ParseWhen(s string, now time.Time) (time.Time, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty time expression")
+	}
+	loc := now.Location()
+
+	// Absolutes
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	s = strings.ToLower(s)
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02t15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			if layout == "2006-01-02" {
+				t = atClock(t, defaultHour, 0)
+			}
+			return t, nil
+		}
+	}
+
+	// Durations: "in 2h", "2h", "3d"
+	if d, err := ParseDuration(strings.TrimPrefix(s, "in ")); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("time expression is not in the future: %q", s)
+		}
+		return now.Add(d), nil
+	}
+
+	day, clock := splitClock(s)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	var base time.Time
+	hour, min := defaultHour, 0
+	switch day {
+	case "today":
+		base = midnight
+	case "tonight":
+		base, hour = midnight, 20
+	case "tomorrow":
+		base = midnight.AddDate(0, 0, 1)
+	default:
+		wd, ok := parseWeekday(day)
+		if !ok {
+			// A bare clock: the next time it comes around.
+			h, m, err := parseClock(s)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid time expression: %q", s)
+			}
+			t := atClock(midnight, h, m)
+			if !t.After(now) {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, nil
+		}
+		ahead := (int(wd) - int(now.Weekday()) + 7) % 7
+		if ahead == 0 {
+			ahead = 7
+		}
+		base = midnight.AddDate(0, 0, ahead)
+	}
+	if clock != "" {
+		var err error
+		if hour, min, err = parseClock(clock); err != nil {
+			return time.Time{}, fmt.Errorf("invalid time expression: %q", s)
+		}
+	}
+	return atClock(base, hour, min), nil
+}
+
+// splitClock splits "friday at 5 pm" into the day and a trailing clock
+// ("friday", "5 pm"). clock is empty when s does not end in one.
+func splitClock(s string) (day, clock string) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' {
+			continue
+		}
+		rest := strings.TrimPrefix(s[i+1:], "at ")
+		if _, _, err := parseClock(rest); err == nil {
+			return s[:i], rest
+		}
+	}
+	return s, ""
+}
+
+// atClock is day at hour:min, staying correct across DST changes.
+func atClock(day time.Time, hour, min int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, min, 0, 0, day.Location())
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock parses "9am", "9:30pm", "14:00", "noon" and "midnight".
+func parseClock(s string) (hour, min int, err error) {
+	s = strings.ReplaceAll(s, " ", "")
+	switch s {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+	pm := strings.HasSuffix(s, "pm")
+	twelve := pm || strings.HasSuffix(s, "am")
+	if twelve {
+		s = s[:len(s)-2]
+	}
+	hs, ms, hasMin := strings.Cut(s, ":")
+	if hour, err = strconv.Atoi(hs); err != nil {
+		return 0, 0, fmt.Errorf("invalid clock: %q", s)
+	}
+	if hasMin {
+		if min, err = strconv.Atoi(ms); err != nil || len(ms) != 2 || min > 59 {
+			return 0, 0, fmt.Errorf("invalid clock: %q", s)
+		}
+	} else if !twelve {
+		return 0, 0, fmt.Errorf("invalid clock: %q", s)
+	}
+	switch {
+	case twelve && (hour < 1 || hour > 12):
+		return 0, 0, fmt.Errorf("invalid clock: %q", s)
+	case !twelve && (hour < 0 || hour > 23):
+		return 0, 0, fmt.Errorf("invalid clock: %q", s)
+	}
+	if twelve {
+		hour %= 12
+		if pm {
+			hour += 12
+		}
+	}
+	return hour, min, nil
+}
+
+// ParseDay parses a day relative to now: anything ParseAt accepts, such as
+// today, yesterday, a weekday (the latest one, today included), 3d (three
+// days ago) or 2025-W10, truncated to local midnight. An empty s is today
+// and a bare date is that local day.
+func ParseDay(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		s = "today"
+	}
+	t, ok := parseNatural(s, now)
+	if !ok {
+		var err error
+		if t, err = time.ParseInLocation("2006-01-02", s, now.Location()); err != nil {
+			if t, err = parseTimeExpr(s, now); err != nil {
+				return time.Time{}, fmt.Errorf("invalid day: %q", s)
+			}
+		}
+	}
+	t = t.In(now.Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+}
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newYork has DST changes on 2025-03-09 and 2025-11-02.
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	return loc
+}
+
+type timeCase struct {
+	in   string
+	want time.Time
+	err  bool
+}
+
+func runTimeCases(t *testing.T, parse func(string, time.Time) (time.Time, error), now time.Time, cases []timeCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parse(tc.in, now)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tc.want.Equal(got), "got %s, want %s", got, tc.want)
+		})
+	}
+}
+
+func TestParseAt(t *testing.T) {
+	ny := newYork(t)
+	at := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, ny) }
+	// A Wednesday, three days after clocks went forward.
+	now := at(2025, 3, 12, 10, 30)
+	runTimeCases(t, ParseAt, now, []timeCase{
+		{in: "now", want: now},
+		{in: "5pm", want: at(2025, 3, 12, 17, 0)},
+		{in: "yesterday at 5pm", want: at(2025, 3, 11, 17, 0)},
+		{in: "Yesterday 17:00", want: at(2025, 3, 11, 17, 0)},
+		{in: "wednesday", want: at(2025, 3, 12, 0, 0)},
+		{in: "last wednesday", want: at(2025, 3, 5, 0, 0)},
+		{in: "last friday at 9:15am", want: at(2025, 3, 7, 9, 15)},
+		{in: "2025-03-01 17:00", want: at(2025, 3, 1, 17, 0)},
+		{in: "2025-W10", want: at(2025, 3, 3, 0, 0)},
+		{in: "2025W105", want: at(2025, 3, 7, 0, 0)},
+		{in: "2020-W53", want: at(2020, 12, 28, 0, 0)},
+		{in: "2020-W53-5", want: at(2021, 1, 1, 0, 0)},
+		{in: "2021-W53", err: true},
+		{in: "2025-W10-8", err: true},
+		{in: "2h", want: now.Add(-2 * time.Hour)},
+		// Calendar days: the DST change does not shift the clock.
+		{in: "3d", want: at(2025, 3, 9, 10, 30)},
+		{in: "2025-03-01", want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{in: "someday", err: true},
+	})
+}
+
+func TestParseDay(t *testing.T) {
+	ny := newYork(t)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, ny) }
+	now := time.Date(2025, 3, 12, 10, 30, 0, 0, ny)
+	runTimeCases(t, ParseDay, now, []timeCase{
+		{in: "", want: day(2025, 3, 12)},
+		{in: "today", want: day(2025, 3, 12)},
+		{in: "yesterday", want: day(2025, 3, 11)},
+		{in: "yesterday 5pm", want: day(2025, 3, 11)},
+		{in: "tomorrow", want: day(2025, 3, 13)},
+		{in: "wed", want: day(2025, 3, 12)},
+		{in: "last wed", want: day(2025, 3, 5)},
+		{in: "friday", want: day(2025, 3, 7)},
+		{in: "2025-03-01", want: day(2025, 3, 1)},
+		{in: "2025-W10-5", want: day(2025, 3, 7)},
+		{in: "2021-W53", err: true},
+		{in: "bogus", err: true},
+	})
+
+	// Just after midnight on the day after clocks went forward, and late on
+	// the day they went back.
+	runTimeCases(t, ParseDay, time.Date(2025, 3, 10, 0, 30, 0, 0, ny), []timeCase{
+		{in: "yesterday", want: day(2025, 3, 9)},
+		{in: "3d", want: day(2025, 3, 7)},
+	})
+	runTimeCases(t, ParseDay, time.Date(2025, 11, 2, 23, 30, 0, 0, ny), []timeCase{
+		{in: "today", want: day(2025, 11, 2)},
+		{in: "1d", want: day(2025, 11, 1)},
+	})
+}
+
+func TestParseWhen(t *testing.T) {
+	ny := newYork(t)
+	at := func(y int, m time.Month, d, h, min int) time.Time { return time.Date(y, m, d, h, min, 0, 0, ny) }
+	now := at(2025, 3, 12, 10, 30)
+	runTimeCases(t, ParseWhen, now, []timeCase{
+		// A clock that has passed today means tomorrow.
+		{in: "9am", want: at(2025, 3, 13, 9, 0)},
+		{in: "10:30", want: at(2025, 3, 13, 10, 30)},
+		{in: "11am", want: at(2025, 3, 12, 11, 0)},
+		{in: "noon", want: at(2025, 3, 12, 12, 0)},
+		{in: "today", want: at(2025, 3, 12, 9, 0)},
+		{in: "tonight", want: at(2025, 3, 12, 20, 0)},
+		{in: "tomorrow at 9 am", want: at(2025, 3, 13, 9, 0)},
+		{in: "wednesday", want: at(2025, 3, 19, 9, 0)},
+		{in: "Fri 5pm", want: at(2025, 3, 14, 17, 0)},
+		{in: "in 2h", want: now.Add(2 * time.Hour)},
+		{in: "2025-03-20", want: at(2025, 3, 20, 9, 0)},
+		{in: "2025-03-20 18:45", want: at(2025, 3, 20, 18, 45)},
+		{in: "-1h", err: true},
+		{in: "13pm", err: true},
+		{in: "tomorrow later", err: true},
+	})
+
+	// The evening before clocks go back: 9:00 tomorrow is still 9:00.
+	runTimeCases(t, ParseWhen, at(2025, 11, 1, 22, 0), []timeCase{
+		{in: "9am", want: at(2025, 11, 2, 9, 0)},
+		{in: "tomorrow", want: at(2025, 11, 2, 9, 0)},
+		{in: "in 1d", want: at(2025, 11, 2, 21, 0)},
+	})
+}
@@ -0,0 +1,14 @@
+package api
+
+import "strings"
+
+// AppendSection appends section to body after a blank line, or returns
+// section alone when body is blank. Daily notes and "note append" both grow
+// a note this way.
+func AppendSection(body, section string) string {
+	body = strings.TrimRight(body, "\n")
+	if strings.TrimSpace(body) == "" {
+		return section
+	}
+	return body + "\n\n" + section
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendSection(t *testing.T) {
+	assert.Equal(t, "## 09:00\n\nhi", AppendSection("", "## 09:00\n\nhi"))
+	assert.Equal(t, "more", AppendSection(" \n\n", "more"))
+	assert.Equal(t, "first\n\nmore", AppendSection("first\n\n\n", "more"))
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Entry.Fields hold custom values keyed by lowercase name. A value is a
+// string, a float64 or a bool; NormalizeFields brings other Go values to
+// one of those.
+
+// reservedFields are note attributes that cannot be custom field names.
+var reservedFields = map[string]bool{
+	"id": true, "version": true, "title": true, "body": true, "tags": true,
+	"namespace": true, "created_at": true, "updated_at": true, "remind": true,
+}
+
+// ValidFieldName reports whether name can name a custom field: a letter
+// followed by letters, digits, '_' or '-', and not a built-in attribute.
+func ValidFieldName(name string) bool {
+	if name == "" || len(name) > 64 || reservedFields[strings.ToLower(name)] {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+		case i > 0 && (unicode.IsDigit(r) || r == '_' || r == '-'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeFields returns in with lowercase names and values reduced to
+// string, float64 or bool: integers become float64, times RFC3339 text and
+// lists their items joined by ", ". NaN and infinite numbers are rejected,
+// as JSON cannot hold them. Nil values are dropped and an empty result is
+// nil.
+func NormalizeFields(in map[string]any) (map[string]any, error) {
+	var out map[string]any
+	for name, v := range in {
+		if !ValidFieldName(name) {
+			return nil, fmt.Errorf("invalid field name %q", name)
+		}
+		if v == nil {
+			continue
+		}
+		nv, err := normalizeField(v)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		if out == nil {
+			out = map[string]any{}
+		}
+		out[strings.ToLower(name)] = nv
+	}
+	return out, nil
+}
+
+func normalizeField(v any) (any, error) {
+	switch x := v.(type) {
+	case string, bool:
+		return x, nil
+	case float64:
+		return finite(x)
+	case time.Time:
+		return x.Format(time.RFC3339), nil
+	case fmt.Stringer:
+		return x.String(), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32:
+		return finite(rv.Float())
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item, err := normalizeField(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, FormatField(item))
+		}
+		return strings.Join(parts, ", "), nil
+	}
+	return nil, fmt.Errorf("unsupported value %v (want text, a number or true/false)", v)
+}
+
+func finite(f float64) (any, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("unsupported value %v (want a finite number)", f)
+	}
+	return f, nil
+}
+
+// FieldNumber returns v as a number for comparing and sorting: numbers as
+// they are, true and false as 1 and 0, and text that is a number or a
+// duration such as 45m (in seconds). Text reading as NaN or infinity is
+// not a number.
+func FieldNumber(v any) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		s := strings.TrimSpace(x)
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+		}
+		if d, err := time.ParseDuration(s); err == nil {
+			return d.Seconds(), true
+		}
+	}
+	return 0, false
+}
+
+// FormatField renders a field value as text.
+func FormatField(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// ParseFieldValue reads text as a field value: true/false, a finite number,
+// or the text itself (so "nan" and "inf" stay text).
+func ParseFieldValue(s string) any {
+	s = strings.TrimSpace(s)
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	return s
+}
+
+// FieldNames returns the names of fields in order.
+func FieldNames(fields map[string]any) []string {
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeFields(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	got, err := NormalizeFields(map[string]any{"Mood": 4, "project": "ginkgo", "done": true, "at": at, "people": []any{"ann", 2}, "gone": nil})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"mood": 4.0, "project": "ginkgo", "done": true, "at": "2025-03-01T09:00:00Z", "people": "ann, 2"}, got)
+
+	none, err := NormalizeFields(map[string]any{"gone": nil})
+	require.NoError(t, err)
+	assert.Nil(t, none)
+
+	for _, name := range []string{"title", "2x", "a b", ""} {
+		_, err := NormalizeFields(map[string]any{name: 1})
+		assert.Error(t, err, name)
+	}
+	_, err = NormalizeFields(map[string]any{"m": map[string]any{"a": 1}})
+	assert.ErrorContains(t, err, "field m")
+
+	// JSON cannot hold NaN or infinity, so event payloads could not either.
+	for _, v := range []any{math.NaN(), math.Inf(1), float32(math.Inf(-1))} {
+		_, err := NormalizeFields(map[string]any{"x": v})
+		assert.ErrorContains(t, err, "finite number", v)
+	}
+}
+
+func TestFieldNumber(t *testing.T) {
+	for v, want := range map[any]float64{4.5: 4.5, true: 1, "12": 12, "45m": 2700} {
+		got, ok := FieldNumber(v)
+		assert.True(t, ok, v)
+		assert.Equal(t, want, got, v)
+	}
+	for _, v := range []string{"ginkgo", "nan", "inf", "-Infinity"} {
+		_, ok := FieldNumber(v)
+		assert.False(t, ok, v)
+	}
+
+	assert.Equal(t, "4", FormatField(4.0))
+	assert.Equal(t, 4.0, ParseFieldValue("4"))
+	assert.Equal(t, true, ParseFieldValue("true"))
+	assert.Equal(t, "45m", ParseFieldValue("45m"))
+	for _, s := range []string{"nan", "NaN", "inf", "+Inf", "Infinity"} {
+		assert.Equal(t, s, ParseFieldValue(s))
+	}
+}
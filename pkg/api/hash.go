@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/hex"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -9,7 +10,8 @@ import (
 )
 
 // Hash returns a deterministic BLAKE3 hash of the entry content.
-// It includes ID, Title, Body, Tags (sorted), Namespace, and Timestamps.
+// It includes ID, Title, Body, Tags (sorted), Namespace, Timestamps and,
+// when there are any, Fields (by name).
 func (e Entry) Hash() string {
 	h := blake3.New()
 
@@ -47,8 +49,28 @@ func (e Entry) Hash() string {
 		h.Write([]byte(e.UpdatedAt.UTC().Format(timeRFC3339Nano)))
 	}
 
+	// Fields come last so entries without them keep their hash. Values are
+	// typed: 4 and "4" differ.
+	for _, k := range FieldNames(e.Fields) {
+		h.Write([]byte{0})
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(fmt.Sprintf("%T:%s", e.Fields[k], FormatField(e.Fields[k]))))
+	}
+
 	sum := h.Sum(nil)
 	return hex.EncodeToString(sum)
 }
 
 const timeRFC3339Nano = "2006-01-02T15:04:05.999999999Z07:00"
+
+// ContentHash returns a BLAKE3 hash of the title and body with case and
+// whitespace normalized. Unlike Hash it ignores ID, tags, namespace and
+// timestamps, so copies of the same text share it.
+func (e Entry) ContentHash() string {
+	h := blake3.New()
+	h.Write([]byte(strings.ToLower(strings.Join(strings.Fields(e.Title), " "))))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.ToLower(strings.Join(strings.Fields(e.Body), " "))))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -80,4 +80,19 @@ func TestEntry_Hash(t *testing.T) {
 
 		assert.Equal(t, e1.Hash(), e2.Hash(), "Empty slice and nil slice should result in same hash")
 	})
+
+	t.Run("fields", func(t *testing.T) {
+		e1 := baseEntry
+		e1.Fields = map[string]any{}
+		assert.Equal(t, baseEntry.Hash(), e1.Hash(), "No fields should keep the hash")
+
+		e1.Fields = map[string]any{"mood": 4.0, "project": "ginkgo"}
+		e2 := baseEntry
+		e2.Fields = map[string]any{"project": "ginkgo", "mood": 4.0}
+		assert.Equal(t, e1.Hash(), e2.Hash())
+		assert.NotEqual(t, baseEntry.Hash(), e1.Hash())
+
+		e2.Fields = map[string]any{"project": "ginkgo", "mood": "4"}
+		assert.NotEqual(t, e1.Hash(), e2.Hash(), "Field types should matter")
+	})
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"strconv"
 	"time"
@@ -16,3 +17,10 @@ func NewID() string {
 	_, _ = rand.Read(buf[:])
 	return ts + "-" + hex.EncodeToString(buf[:])
 }
+
+// DailyID is the ID of namespace's daily note for day. It is derived from
+// the date rather than random, so every device opens the same note.
+func DailyID(namespace string, day time.Time) string {
+	sum := sha256.Sum256([]byte(namespace))
+	return "daily-" + day.Format("20060102") + "-" + hex.EncodeToString(sum[:4])
+}
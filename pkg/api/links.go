@@ -0,0 +1,69 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Link is a [[target]] reference in a note body. Entry is the note the
+// target resolves to (by ID, else by title), nil when none matches.
+type Link struct {
+	Target string `json:"target"`
+	Entry  *Entry `json:"entry,omitempty"`
+}
+
+// wikiLink matches [[target]] and [[target|label]].
+var wikiLink = regexp.MustCompile(`\[\[([^\[\]|\n]+)(?:\|([^\[\]\n]*))?\]\]`)
+
+// LinkTargets returns the distinct link targets of body in order of first
+// appearance, trimmed. Links inside code are ignored.
+func LinkTargets(body string) []string {
+	var out []string
+	seen := map[string]bool{}
+	ReplaceLinks(body, func(target, label string) string {
+		if k := strings.ToLower(target); !seen[k] {
+			seen[k] = true
+			out = append(out, target)
+		}
+		return ""
+	})
+	return out
+}
+
+// ReplaceLinks rewrites every link in body outside code blocks and code
+// spans with fn(target, label). label is the target when the link has none.
+func ReplaceLinks(body string, fn func(target, label string) string) string {
+	lines := strings.SplitAfter(body, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		// Odd segments are inline code spans.
+		parts := strings.Split(line, "`")
+		for j := 0; j < len(parts); j += 2 {
+			parts[j] = wikiLink.ReplaceAllStringFunc(parts[j], func(m string) string {
+				sub := wikiLink.FindStringSubmatch(m)
+				target := strings.TrimSpace(sub[1])
+				if target == "" {
+					return m
+				}
+				label := strings.TrimSpace(sub[2])
+				if label == "" {
+					label = target
+				}
+				return fn(target, label)
+			})
+		}
+		lines[i] = strings.Join(parts, "`")
+	}
+	return strings.Join(lines, "")
+}
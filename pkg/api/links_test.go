@@ -0,0 +1,22 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkTargets(t *testing.T) {
+	body := "See [[Deploy Plan]] and [[01J8ZK3Q|the runbook]].\n" +
+		"```\n[[not a link]]\n```\n" +
+		"Again [[deploy plan]], `[[code]]`, [[ ]] and [[a|]].\n"
+	assert.Equal(t, []string{"Deploy Plan", "01J8ZK3Q", "a"}, LinkTargets(body))
+	assert.Empty(t, LinkTargets("no links [here]"))
+}
+
+func TestReplaceLinks(t *testing.T) {
+	got := ReplaceLinks("[[x|label]] and [[y]]\n~~~\n[[z]]\n~~~\n", func(target, label string) string {
+		return "<" + target + ":" + label + ">"
+	})
+	assert.Equal(t, "<x:label> and <y:y>\n~~~\n[[z]]\n~~~\n", got)
+}
@@ -11,6 +11,8 @@ type Entry struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Namespace string    `json:"namespace"`
+	// Fields are custom values such as mood: 4 (see fields.go).
+	Fields map[string]any `json:"fields,omitempty"`
 }
 
 type EventType string
@@ -18,12 +20,20 @@ type EventType string
 const (
 	EventUpsert EventType = "upsert"
 	EventDelete EventType = "delete"
+	// View events replicate saved views; ID is the view name.
+	EventViewUpsert EventType = "view_upsert"
+	EventViewDelete EventType = "view_delete"
+	// Reminder events replicate note reminders; ID is the note ID.
+	EventReminderUpsert EventType = "reminder_upsert"
+	EventReminderDelete EventType = "reminder_delete"
 )
 
 type Event struct {
 	Time        time.Time `json:"time"`
 	Type        EventType `json:"type"`
 	Entry       *Entry    `json:"entry,omitempty"`
+	View        *View     `json:"view,omitempty"`
+	Reminder    *Reminder `json:"reminder,omitempty"`
 	ID          string    `json:"id"`
 	Namespace   string    `json:"namespace,omitempty"`
 	PayloadType string    `json:"payload_type,omitempty"`
@@ -33,6 +43,48 @@ type Event struct {
 	Sig         []byte    `json:"sig,omitempty"`
 }
 
+// View is a named set of list filters saved per namespace. Since and Until
+// keep the form they were given in (e.g. "7d") so relative ranges are
+// re-evaluated on every run.
+type View struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Query     string    `json:"query,omitempty"`
+	TagsAny   []string  `json:"tags_any,omitempty"`
+	TagsAll   []string  `json:"tags_all,omitempty"`
+	Since     string    `json:"since,omitempty"`
+	Until     string    `json:"until,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Reminder asks for a notification about a note at At. Reminders replicate
+// to every device but fire only on the daemon whose device name is Device
+// (any daemon when empty); FiredAt records when that happened. Title is the note's title, filled in
+// when reminders are listed.
+type Reminder struct {
+	NoteID    string    `json:"note_id"`
+	Namespace string    `json:"namespace"`
+	At        time.Time `json:"at"`
+	Device    string    `json:"device,omitempty"`
+	FiredAt   time.Time `json:"fired_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Title     string    `json:"title,omitempty"`
+}
+
+// Draft is a note being written in an editor outside the daemon. Path is
+// the editor's file and BaseVersion the version of note ID the edit started
+// from (0 for a new note). Content is the daemon's latest snapshot of the
+// file, taken at UpdatedAt. Drafts stay on the device; they never replicate.
+type Draft struct {
+	ID          string    `json:"id"`
+	Namespace   string    `json:"namespace"`
+	Path        string    `json:"path"`
+	BaseVersion int64     `json:"base_version,omitempty"`
+	Content     string    `json:"content,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // Cursor can be extended later for pagination.
 type Cursor struct {
 	After time.Time `json:"after"`
@@ -51,6 +103,60 @@ type SearchQuery struct {
 	Cursor      string    `json:"cursor,omitempty"`
 	Reverse     bool      `json:"reverse,omitempty"`
 	IncludeBody bool      `json:"include_body,omitempty"`
+	// SortBy is "created" (default, newest first) or "relevance" (BM25).
+	SortBy string `json:"sort_by,omitempty"`
+}
+
+// Sort orders for SearchQuery.SortBy.
+const (
+	SortCreated   = "created"
+	SortRelevance = "relevance"
+)
+
+// SearchHit is a search result with its relevance score and the fragments
+// of the note that matched. Score is higher for better matches and zero when
+// the query had no text terms.
+type SearchHit struct {
+	Entry    Entry     `json:"entry"`
+	Score    float64   `json:"score"`
+	Snippets []Snippet `json:"snippets,omitempty"`
+}
+
+// Snippet is a fragment of one field ("title", "body" or "tags") around the
+// matched terms. Matches are byte ranges into Text.
+type Snippet struct {
+	Field   string      `json:"field"`
+	Text    string      `json:"text"`
+	Matches []TextRange `json:"matches,omitempty"`
+}
+
+// TextRange is a half-open byte range [Start, End).
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// TermSuggestion lists indexed terms close to a query term that does not
+// occur in any note, best first.
+type TermSuggestion struct {
+	Term        string   `json:"term"`
+	Suggestions []string `json:"suggestions"`
+}
+
+// Duplicate is a stored note whose content matches another note's. Exact
+// means the same title and body up to case and whitespace; otherwise
+// Distance is the SimHash Hamming distance (smaller is closer).
+type Duplicate struct {
+	Entry    Entry `json:"entry"`
+	Exact    bool  `json:"exact"`
+	Distance int   `json:"distance"`
+}
+
+// DupeCluster groups notes that duplicate each other, oldest first. Exact
+// is set when every note has the same content hash.
+type DupeCluster struct {
+	Entries []Entry `json:"entries"`
+	Exact   bool    `json:"exact"`
 }
 
 // Page describes pagination cursors for list/search results.
@@ -65,6 +171,8 @@ type TagStat struct {
 	Tag         string `json:"tag"`
 	Count       int    `json:"count"`
 	Description string `json:"description,omitempty"`
+	// Frecency weighs recent uses of the tag over old ones.
+	Frecency int `json:"frecency,omitempty"`
 }
 
 // TagsQuery filters tag listing.
@@ -79,13 +187,19 @@ type TagsQuery struct {
 // Any: match if note contains at least one of these tags.
 // All: match if note contains all of these tags.
 type ListQuery struct {
-	Namespace   string    `json:"namespace"`
-	Any         []string  `json:"any,omitempty"`
-	All         []string  `json:"all,omitempty"`
-	Limit       int       `json:"limit"`
+	Namespace string   `json:"namespace"`
+	Any       []string `json:"any,omitempty"`
+	All       []string `json:"all,omitempty"`
+	Limit     int      `json:"limit"`
+	// Query is an optional search-language expression (see internal/query).
+	Query       string    `json:"query,omitempty"`
 	Since       time.Time `json:"since,omitempty"`
 	Until       time.Time `json:"until,omitempty"`
 	Cursor      string    `json:"cursor,omitempty"`
 	Reverse     bool      `json:"reverse,omitempty"`
 	IncludeBody bool      `json:"include_body,omitempty"`
+	// Sort orders by a custom field, ascending or, with a leading '-',
+	// descending; notes without it come last. Such lists page forward
+	// with offset cursors.
+	Sort string `json:"sort,omitempty"`
 }